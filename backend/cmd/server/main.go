@@ -4,6 +4,7 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"net/http"
@@ -22,6 +23,8 @@ import (
 const (
 	timeoutDuration   = 10 * time.Second
 	maxBodySize       = 1024 * 1024 // 1mb
+	importTimeout     = 2 * time.Minute
+	importMaxBodySize = 10 * 1024 * 1024 // 10mb
 	requestsPerSecond = 5
 	burstSize         = 10
 )
@@ -78,6 +81,30 @@ func main() {
 		return chainMiddleware(handler, baseMiddleware...)
 	}
 
+	// for long-running downloads that are written out as they go - no timeout, which would also race the handler's writes
+	streamedMiddleware := []func(http.HandlerFunc) http.HandlerFunc{
+		authMiddleware.AuthenticateJWT,
+		loggingMiddleware,
+		rateLimitMiddleware,
+	}
+
+	streamed := func(handler http.HandlerFunc) http.HandlerFunc {
+		return chainMiddleware(handler, streamedMiddleware...)
+	}
+
+	// for file uploads that are parsed & saved in batches - a bigger body & more time than a regular request
+	importMiddleware := []func(http.HandlerFunc) http.HandlerFunc{
+		authMiddleware.AuthenticateJWT,
+		middleware.TimeoutMiddleware(importTimeout),
+		loggingMiddleware,
+		middleware.MaxBodySizeMiddleware(importMaxBodySize),
+		rateLimitMiddleware,
+	}
+
+	imports := func(handler http.HandlerFunc) http.HandlerFunc {
+		return chainMiddleware(handler, importMiddleware...)
+	}
+
 	// Initialize handlers
 	authHandler := handlers.NewAuthHandler(queries, authMiddleware)
 	userHandler := handlers.NewUserHandler(queries)
@@ -85,12 +112,27 @@ func main() {
 	userProfileHandler := handlers.NewUserProfileHandler(queries)
 	userProfileByIDHandler := handlers.NewUserProfileByIDHandler(queries)
 	muscleHandler := handlers.NewMuscleHandler(queries)
+	muscleGroupHandler := handlers.NewMuscleGroupHandler(queries)
 	exerciseHandler := handlers.NewExerciseHandler(queries)
 	exerciseByIDHandler := handlers.NewExerciseByIDHandler(queries)
 	workoutHandler := handlers.NewWorkoutHandler(queries, jwtConfig.AccessSecret)
 	workoutByIDHandler := handlers.NewWorkoutByIDHandler(queries)
-	workoutSetHandler := handlers.NewWorkoutSetHandler(queries, jwtConfig.AccessSecret)
-	workoutSetByIDHandler := handlers.NewWorkoutSetByIDHandler(queries, jwtConfig.AccessSecret)
+	workoutSetHandler := handlers.NewWorkoutSetHandler(db, queries, jwtConfig.AccessSecret)
+	workoutSetByIDHandler := handlers.NewWorkoutSetByIDHandler(db, queries, jwtConfig.AccessSecret)
+	workoutSetByExerciseHandler := handlers.NewWorkoutSetByExerciseHandler(db, queries, jwtConfig.AccessSecret)
+	personalRecordHandler := handlers.NewPersonalRecordHandler(queries)
+	reportHandler := handlers.NewReportHandler(queries)
+	recoveryHandler := handlers.NewRecoveryHandler(queries)
+	bodyMeasurementHandler := handlers.NewBodyMeasurementHandler(db, queries)
+	calendarHandler := handlers.NewCalendarHandler(queries)
+	goalHandler := handlers.NewGoalHandler(queries)
+	exportHandler := handlers.NewExportHandler(queries)
+	importHandler := handlers.NewImportHandler(db, queries)
+	accountDataHandler := handlers.NewAccountDataHandler(db, queries)
+	calendarFeedHandler := handlers.NewCalendarFeedHandler(queries)
+
+	// builds requested data exports & erases accounts once their grace period is over
+	go accountDataHandler.RunBackgroundJobs(context.Background())
 
 	mux := http.NewServeMux()
 
@@ -105,7 +147,7 @@ func main() {
 	mux.HandleFunc("/users/", protected(userByIDHandler.HandleUserByID))                                                  // GET, PATCH, DELETE
 	mux.HandleFunc("/user-profiles", protected(userProfileHandler.HandleUserProfiles))                                    // GET(all), GET(active), POST
 	mux.HandleFunc("/user-profiles/", protected(userProfileByIDHandler.HandleUserProfilesByID))                           // GET, PATCH, DELETE
-	mux.HandleFunc("/muscles", protected(muscleHandler.HandleMuscles))                                                    // GET, POST, DELETE
+	mux.HandleFunc("/muscles", protected(muscleHandler.HandleMuscles))                                                    // GET(all), GET(name), POST (admin), DELETE (admin)
 	mux.HandleFunc("/exercises", protected(exerciseHandler.HandleExercises))                                              // GET(all), POST
 	mux.HandleFunc("/exercises/", protected(exerciseByIDHandler.HandleExercisesByID))                                     // GET, PATCH, DELETE
 	mux.HandleFunc("/workouts/{workout_id}/workout-sets", protected(workoutSetHandler.HandleWorkoutSets))                 // POST, GET(all), DELETE
@@ -113,6 +155,77 @@ func main() {
 	mux.HandleFunc("/workouts", protected(workoutHandler.HandleWorkouts))                                                 // GET(all),POST
 	mux.HandleFunc("/workouts/", protected(workoutByIDHandler.HandleWorkoutsByID))                                        // GET, PATCH, DELETE
 
+	// Live workout session routes
+	mux.HandleFunc("/workouts/active", protected(workoutHandler.HandleActiveWorkout))                                                  // GET
+	mux.HandleFunc("/workouts/{workout_id}/start", protected(workoutByIDHandler.HandleStartWorkout))                                   // POST
+	mux.HandleFunc("/workouts/{workout_id}/finish", protected(workoutByIDHandler.HandleFinishWorkout))                                 // POST
+	mux.HandleFunc("/workouts/{workout_id}/workout-sets/{set_id}/complete", protected(workoutSetByIDHandler.HandleCompleteWorkoutSet)) // POST
+
+	// Exercise catalog routes
+	mux.HandleFunc("/exercises/search", protected(exerciseHandler.HandleSearchExercises))           // GET
+	mux.HandleFunc("/exercises/{id}/promote", protected(exerciseByIDHandler.HandlePromoteExercise)) // POST (admin)
+
+	// Exercise-muscle mapping routes
+	mux.HandleFunc("/exercises/{id}/muscles", protected(exerciseByIDHandler.HandleExerciseMuscles))                // GET, POST
+	mux.HandleFunc("/exercises/{id}/muscles/{muscle_id}", protected(exerciseByIDHandler.HandleExerciseMuscleByID)) // PATCH, DELETE
+	mux.HandleFunc("/muscles/{id}/exercises", protected(muscleHandler.HandleMuscleExercises))                      // GET
+
+	// Exercise substitution routes
+	mux.HandleFunc("/exercises/{id}/alternatives", protected(exerciseByIDHandler.HandleExerciseAlternatives))                        // GET
+	mux.HandleFunc("/workouts/{workout_id}/exercises/{exercise_id}/swap", protected(workoutSetByExerciseHandler.HandleSwapExercise)) // POST
+
+	// Muscle & muscle group routes
+	mux.HandleFunc("/muscles/{id}", protected(muscleHandler.HandleMuscleByID))                 // GET, PATCH (admin), DELETE (admin)
+	mux.HandleFunc("/muscle-groups", protected(muscleGroupHandler.HandleMuscleGroups))         // GET(all), POST (admin)
+	mux.HandleFunc("/muscle-groups/{id}", protected(muscleGroupHandler.HandleMuscleGroupByID)) // GET, PATCH (admin), DELETE (admin)
+
+	// Analytics routes
+	mux.HandleFunc("/exercises/one-rep-maxes", protected(exerciseHandler.HandleOneRepMaxes))      // GET
+	mux.HandleFunc("/exercises/{id}/one-rep-max", protected(exerciseByIDHandler.HandleOneRepMax)) // GET
+
+	// Personal record routes
+	mux.HandleFunc("/me/records", protected(personalRecordHandler.HandlePersonalRecords)) // GET
+
+	// Recovery routes
+	mux.HandleFunc("/me/recovery", protected(recoveryHandler.HandleRecovery)) // GET
+
+	// Report routes
+	mux.HandleFunc("/reports/volume", protected(reportHandler.HandleVolumeReport)) // GET
+	mux.HandleFunc("/reports/load", protected(reportHandler.HandleLoadReport))     // GET
+
+	// Progression routes
+	mux.HandleFunc("/exercises/{id}/next-target", protected(exerciseByIDHandler.HandleNextTarget))          // GET
+	mux.HandleFunc("/exercises/{id}/progression", protected(exerciseByIDHandler.HandleProgressionSettings)) // GET, PUT
+
+	// Body measurement routes
+	mux.HandleFunc("/body-measurements", protected(bodyMeasurementHandler.HandleBodyMeasurements))           // GET(all), POST
+	mux.HandleFunc("/body-measurements/trend", protected(bodyMeasurementHandler.HandleBodyMeasurementTrend)) // GET
+	mux.HandleFunc("/body-measurements/{id}", protected(bodyMeasurementHandler.HandleBodyMeasurementByID))   // GET, PATCH, DELETE
+	mux.HandleFunc("/me/relative-strength", protected(bodyMeasurementHandler.HandleRelativeStrength))        // GET
+
+	// Calendar routes
+	mux.HandleFunc("/calendar", protected(calendarHandler.HandleCalendar)) // GET
+
+	// Goal routes
+	mux.HandleFunc("/goals", protected(goalHandler.HandleGoals))         // GET(all), POST
+	mux.HandleFunc("/goals/{id}", protected(goalHandler.HandleGoalByID)) // GET, PATCH, DELETE
+
+	// Export routes
+	mux.HandleFunc("/me/export", streamed(exportHandler.HandleExport)) // GET
+
+	// Import routes
+	mux.HandleFunc("/imports", imports(importHandler.HandleImports)) // POST
+
+	// Privacy routes
+	mux.HandleFunc("/me/data-exports", protected(accountDataHandler.HandleDataExports))                      // GET(all), POST
+	mux.HandleFunc("/me/data-exports/{id}", protected(accountDataHandler.HandleDataExportByID))              // GET
+	mux.HandleFunc("/me/data-exports/{id}/download", protected(accountDataHandler.HandleDataExportDownload)) // GET
+	mux.HandleFunc("/me/erasure", protected(accountDataHandler.HandleErasure))                               // GET, POST, DELETE
+
+	// Calendar feed routes - the feed itself is unprotected since calendar apps can't log in; its token is the secret
+	mux.HandleFunc("/me/calendar-feed", protected(calendarFeedHandler.HandleCalendarFeed))            // GET, POST, DELETE
+	mux.HandleFunc("/calendar-feeds/{token}", unprotected(calendarFeedHandler.HandleCalendarFeedICS)) // GET
+
 	// Default/root handler
 	mux.HandleFunc("/", unprotected(func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "text/html")
@@ -131,10 +244,47 @@ func main() {
 <li>/muscles</li>
 <li>/exercises</li>
 <li>/exercises/{id}</li>
+<li>/exercises/{id}/promote</li>
+<li>/exercises/search</li>
+<li>/exercises/{id}/muscles</li>
+<li>/exercises/{id}/muscles/{muscle_id}</li>
+<li>/muscles/{id}/exercises</li>
+<li>/exercises/{id}/alternatives</li>
+<li>/workouts/{workout_id}/exercises/{exercise_id}/swap</li>
+<li>/muscles/{id}</li>
+<li>/muscle-groups</li>
+<li>/muscle-groups/{id}</li>
 <li>/workouts</li>
 <li>/workouts/{id}</li>
 <li>/workouts/{workout_id}/workout-sets</li>
 <li>/workouts/{workout_id}/workout-sets/{set_id}</li>
+<li>/workouts/active</li>
+<li>/workouts/{workout_id}/start</li>
+<li>/workouts/{workout_id}/finish</li>
+<li>/workouts/{workout_id}/workout-sets/{set_id}/complete</li>
+<li>/exercises/one-rep-maxes</li>
+<li>/exercises/{id}/one-rep-max</li>
+<li>/me/records</li>
+<li>/me/recovery</li>
+<li>/reports/volume</li>
+<li>/reports/load</li>
+<li>/exercises/{id}/next-target</li>
+<li>/exercises/{id}/progression</li>
+<li>/body-measurements</li>
+<li>/body-measurements/{id}</li>
+<li>/body-measurements/trend</li>
+<li>/me/relative-strength</li>
+<li>/calendar</li>
+<li>/goals</li>
+<li>/goals/{id}</li>
+<li>/me/export</li>
+<li>/imports</li>
+<li>/me/data-exports</li>
+<li>/me/data-exports/{id}</li>
+<li>/me/data-exports/{id}/download</li>
+<li>/me/erasure</li>
+<li>/me/calendar-feed</li>
+<li>/calendar-feeds/{token}</li>
 </body>
 </html>`)
 	}))
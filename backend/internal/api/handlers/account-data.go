@@ -0,0 +1,557 @@
+// GET, POST, DELETE - privacy requests: "download my data" archives built in the background, and erasing the account
+// for good after a grace period. DELETE /users/{id} only deactivates an account; this is the one that removes it.
+package handlers
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+
+	"go-reppy/backend/internal/api/middleware"
+	"go-reppy/backend/internal/api/response"
+	"go-reppy/backend/internal/api/utils"
+	"go-reppy/backend/internal/database/sqlc"
+)
+
+type AccountDataHandler struct {
+	db      *sql.DB // for erasing an account atomically
+	queries *sqlc.Queries
+	wake    chan struct{} // nudges the background worker when an export is requested
+}
+
+func NewAccountDataHandler(db *sql.DB, q *sqlc.Queries) *AccountDataHandler {
+	return &AccountDataHandler{
+		db:      db,
+		queries: q,
+		wake:    make(chan struct{}, 1),
+	}
+}
+
+const (
+	accountJobInterval        = time.Minute
+	accountExportRetention    = 7 * 24 * time.Hour
+	accountErasureGracePeriod = 30 * 24 * time.Hour
+)
+
+type AccountDataExportResponse struct {
+	ExportID    int32      `json:"ExportID"`
+	Status      string     `json:"Status"` // 'pending', 'running', 'completed' or 'failed'
+	SizeBytes   *int32     `json:"SizeBytes"`
+	Error       *string    `json:"Error"`
+	RequestedAt time.Time  `json:"RequestedAt"`
+	StartedAt   *time.Time `json:"StartedAt"`
+	CompletedAt *time.Time `json:"CompletedAt"`
+	ExpiresAt   *time.Time `json:"ExpiresAt"`   // the archive is deleted after this
+	DownloadURL *string    `json:"DownloadURL"` // completed exports only
+}
+
+type AccountErasureRequest struct {
+	Password string `json:"password"` // re-entered to confirm it's really the account holder
+}
+
+type AccountErasureResponse struct {
+	RequestedAt  time.Time `json:"RequestedAt"`
+	ScheduledFor time.Time `json:"ScheduledFor"` // the account & everything in it is erased at (or shortly after) this time
+}
+
+// What each file in the archive holds, written into the archive as manifest.json
+var accountArchiveFiles = []struct {
+	name        string
+	description string
+	content     func(sqlc.GetAccountDataJSONRow) string
+}{
+	{"user.json", "the account itself (the password hash is left out)", func(d sqlc.GetAccountDataJSONRow) string { return d.UserJson }},
+	{"profile.json", "profile details & preferences", func(d sqlc.GetAccountDataJSONRow) string { return d.ProfileJson }},
+	{"workouts.json", "every workout", func(d sqlc.GetAccountDataJSONRow) string { return d.WorkoutsJson }},
+	{"workout_sets.json", "every set, with its exercise's name; weights in kg & distances in meters", func(d sqlc.GetAccountDataJSONRow) string { return d.WorkoutSetsJson }},
+	{"workout_set_groups.json", "supersets, circuits & other set groups", func(d sqlc.GetAccountDataJSONRow) string { return d.WorkoutSetGroupsJson }},
+	{"custom_exercises.json", "exercises created on this account, with their aliases", func(d sqlc.GetAccountDataJSONRow) string { return d.CustomExercisesJson }},
+	{"body_measurements.json", "bodyweight, body fat & circumference entries", func(d sqlc.GetAccountDataJSONRow) string { return d.BodyMeasurementsJson }},
+	{"personal_records.json", "personal records set", func(d sqlc.GetAccountDataJSONRow) string { return d.PersonalRecordsJson }},
+	{"progression_settings.json", "per-exercise progression settings", func(d sqlc.GetAccountDataJSONRow) string { return d.ProgressionSettingsJson }},
+	{"goals.json", "goals, including finished ones", func(d sqlc.GetAccountDataJSONRow) string { return d.GoalsJson }},
+}
+
+// Kinds of data people ask about that aren't kept, explained in the manifest rather than shipped as empty files
+var accountArchiveNotKept = map[string]string{
+	"sessions":     "Logins use signed tokens that expire on their own and aren't stored, so there are no session records.",
+	"audit_events": "No audit log of account activity is kept.",
+}
+
+// "/me/data-exports"
+func (h *AccountDataHandler) HandleDataExports(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.GetDataExports(w, r)
+	case http.MethodPost:
+		h.CreateDataExport(w, r)
+	default:
+		response.SendError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+}
+
+// "/me/data-exports/{id}"
+func (h *AccountDataHandler) HandleDataExportByID(w http.ResponseWriter, r *http.Request) {
+	id, err := utils.GetIDFromPath(r.URL.Path)
+	if err != nil {
+		response.SendError(w, "Invalid export ID", http.StatusBadRequest)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		h.GetDataExportByID(w, r, id)
+	default:
+		response.SendError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+}
+
+// "/me/data-exports/{id}/download"
+func (h *AccountDataHandler) HandleDataExportDownload(w http.ResponseWriter, r *http.Request) {
+	pathParts := strings.Split(r.URL.Path, "/")
+	if len(pathParts) < 5 {
+		response.SendError(w, "Invalid path URL", http.StatusBadRequest)
+		return
+	}
+
+	exportID, err := strconv.ParseInt(pathParts[3], 10, 32)
+	if err != nil {
+		response.SendError(w, "Invalid export ID", http.StatusBadRequest)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		h.DownloadDataExport(w, r, int32(exportID))
+	default:
+		response.SendError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+}
+
+// "/me/erasure"
+func (h *AccountDataHandler) HandleErasure(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.GetErasure(w, r)
+	case http.MethodPost:
+		h.ScheduleErasure(w, r)
+	case http.MethodDelete:
+		h.CancelErasure(w, r)
+	default:
+		response.SendError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+}
+
+// "/me/data-exports" - newest first
+func (h *AccountDataHandler) GetDataExports(w http.ResponseWriter, r *http.Request) {
+	userID, err := middleware.GetUserIDFromContext(r.Context())
+	if err != nil {
+		response.SendError(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	exports, err := h.queries.GetAccountDataExportsForUser(r.Context(), int32(userID))
+	if err != nil {
+		response.SendError(w, "Failed to get data exports", http.StatusInternalServerError)
+		return
+	}
+
+	resp := make([]AccountDataExportResponse, len(exports))
+	for i, export := range exports {
+		resp[i] = toAccountDataExportResponse(sqlc.GetAccountDataExportByIDForUserRow(export))
+	}
+	response.SendSuccess(w, resp)
+}
+
+/*
+"/me/data-exports" - no body. Queues an export & returns straight away; poll "/me/data-exports/{id}" until it's
+completed, then download the ZIP from its DownloadURL. Only one export can be in progress at a time.
+*/
+func (h *AccountDataHandler) CreateDataExport(w http.ResponseWriter, r *http.Request) {
+	userID, err := middleware.GetUserIDFromContext(r.Context())
+	if err != nil {
+		response.SendError(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	export, err := h.queries.CreateAccountDataExport(r.Context(), int32(userID))
+	if err != nil {
+		if strings.Contains(err.Error(), "idx_account_data_exports_one_in_progress") {
+			response.SendError(w, "A data export is already in progress", http.StatusConflict)
+			return
+		}
+		response.SendError(w, "Failed to request data export", http.StatusInternalServerError)
+		return
+	}
+
+	// the worker may already be busy; it'll get to this one on its next pass either way
+	select {
+	case h.wake <- struct{}{}:
+	default:
+	}
+
+	response.SendSuccess(w, toAccountDataExportResponse(sqlc.GetAccountDataExportByIDForUserRow(export)), http.StatusAccepted)
+}
+
+// "/me/data-exports/3"
+func (h *AccountDataHandler) GetDataExportByID(w http.ResponseWriter, r *http.Request, exportID int32) {
+	userID, err := middleware.GetUserIDFromContext(r.Context())
+	if err != nil {
+		response.SendError(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	export, err := h.queries.GetAccountDataExportByIDForUser(r.Context(), sqlc.GetAccountDataExportByIDForUserParams{
+		ExportID: exportID,
+		UserID:   int32(userID),
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			response.SendError(w, "Data export not found", http.StatusNotFound)
+			return
+		}
+		response.SendError(w, "Failed to get data export", http.StatusInternalServerError)
+		return
+	}
+
+	response.SendSuccess(w, toAccountDataExportResponse(export))
+}
+
+// "/me/data-exports/3/download"
+func (h *AccountDataHandler) DownloadDataExport(w http.ResponseWriter, r *http.Request, exportID int32) {
+	userID, err := middleware.GetUserIDFromContext(r.Context())
+	if err != nil {
+		response.SendError(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	archive, err := h.queries.GetAccountDataExportArchive(r.Context(), sqlc.GetAccountDataExportArchiveParams{
+		ExportID: exportID,
+		UserID:   int32(userID),
+	})
+	if err == sql.ErrNoRows {
+		// tell "not yet" & "not at all" apart
+		export, err := h.queries.GetAccountDataExportByIDForUser(r.Context(), sqlc.GetAccountDataExportByIDForUserParams{
+			ExportID: exportID,
+			UserID:   int32(userID),
+		})
+		switch {
+		case err == sql.ErrNoRows:
+			response.SendError(w, "Data export not found", http.StatusNotFound)
+		case err != nil:
+			response.SendError(w, "Failed to get data export", http.StatusInternalServerError)
+		case export.Status == sqlc.AccountExportStatusEnumCompleted:
+			response.SendError(w, "Data export has expired; request a new one", http.StatusGone)
+		default:
+			response.SendError(w, fmt.Sprintf("Data export is %s, not ready to download", export.Status), http.StatusConflict)
+		}
+		return
+	}
+	if err != nil {
+		response.SendError(w, "Failed to get data export", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/zip")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="reppy-data-%d.zip"`, exportID))
+	w.Header().Set("Content-Length", strconv.Itoa(len(archive)))
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write(archive); err != nil {
+		log.Printf("data export %d download failed: %v", exportID, err)
+	}
+}
+
+// "/me/erasure"
+func (h *AccountDataHandler) GetErasure(w http.ResponseWriter, r *http.Request) {
+	userID, err := middleware.GetUserIDFromContext(r.Context())
+	if err != nil {
+		response.SendError(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	erasure, err := h.queries.GetAccountErasureForUser(r.Context(), int32(userID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			response.SendError(w, "No account erasure is scheduled", http.StatusNotFound)
+			return
+		}
+		response.SendError(w, "Failed to get account erasure", http.StatusInternalServerError)
+		return
+	}
+
+	response.SendSuccess(w, AccountErasureResponse{RequestedAt: erasure.RequestedAt, ScheduledFor: erasure.ScheduledFor})
+}
+
+/*
+"/me/erasure"
+sample req body:
+
+	{
+	  "password": "current password"
+	}
+
+Schedules the account to be erased for good once the 30-day grace period is over. The account keeps working until then,
+and DELETE "/me/erasure" calls it off. Erasure can't be undone: workouts, sets, measurements, custom exercises, goals,
+records, data exports, the profile & the account itself are all deleted.
+*/
+func (h *AccountDataHandler) ScheduleErasure(w http.ResponseWriter, r *http.Request) {
+	var request AccountErasureRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		response.SendError(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	if request.Password == "" {
+		response.SendError(w, "password is required", http.StatusBadRequest)
+		return
+	}
+
+	userID, err := middleware.GetUserIDFromContext(r.Context())
+	if err != nil {
+		response.SendError(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	user, err := h.queries.GetUser(r.Context(), int32(userID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			response.SendError(w, "User not found", http.StatusNotFound)
+			return
+		}
+		response.SendError(w, "Failed to get user", http.StatusInternalServerError)
+		return
+	}
+	if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(request.Password)); err != nil {
+		response.SendError(w, "Incorrect password", http.StatusUnauthorized)
+		return
+	}
+
+	erasure, err := h.queries.CreateAccountErasure(r.Context(), sqlc.CreateAccountErasureParams{
+		UserID:       int32(userID),
+		ScheduledFor: time.Now().Add(accountErasureGracePeriod),
+	})
+	if err != nil {
+		if strings.Contains(err.Error(), "account_erasures_pkey") {
+			response.SendError(w, "Account erasure is already scheduled", http.StatusConflict)
+			return
+		}
+		response.SendError(w, "Failed to schedule account erasure", http.StatusInternalServerError)
+		return
+	}
+
+	response.SendSuccess(w, AccountErasureResponse{RequestedAt: erasure.RequestedAt, ScheduledFor: erasure.ScheduledFor}, http.StatusAccepted)
+}
+
+// "/me/erasure"
+func (h *AccountDataHandler) CancelErasure(w http.ResponseWriter, r *http.Request) {
+	userID, err := middleware.GetUserIDFromContext(r.Context())
+	if err != nil {
+		response.SendError(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	if _, err := h.queries.DeleteAccountErasure(r.Context(), int32(userID)); err != nil {
+		if err == sql.ErrNoRows {
+			response.SendError(w, "No account erasure is scheduled", http.StatusNotFound)
+			return
+		}
+		response.SendError(w, "Failed to cancel account erasure", http.StatusInternalServerError)
+		return
+	}
+
+	response.SendSuccess(w, nil, http.StatusNoContent)
+}
+
+// Builds queued data exports, clears expired archives & erases accounts whose grace period is over: every
+// accountJobInterval, and straight away when an export is requested. Blocks until ctx is done.
+func (h *AccountDataHandler) RunBackgroundJobs(ctx context.Context) {
+	ticker := time.NewTicker(accountJobInterval)
+	defer ticker.Stop()
+
+	for {
+		h.runPendingExports(ctx)
+		if cleared, err := h.queries.ClearExpiredAccountDataExportArchives(ctx); err != nil {
+			log.Printf("failed to clear expired data export archives: %v", err)
+		} else if cleared > 0 {
+			log.Printf("cleared %d expired data export archive(s)", cleared)
+		}
+		h.eraseDueAccounts(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-h.wake:
+		}
+	}
+}
+
+func (h *AccountDataHandler) runPendingExports(ctx context.Context) {
+	for ctx.Err() == nil {
+		job, err := h.queries.ClaimNextAccountDataExport(ctx)
+		if err == sql.ErrNoRows {
+			return
+		}
+		if err != nil {
+			log.Printf("failed to claim data export: %v", err)
+			return
+		}
+
+		archive, err := h.buildAccountArchive(ctx, job.UserID)
+		if err != nil {
+			log.Printf("data export %d for user %d failed: %v", job.ExportID, job.UserID, err)
+			if err := h.queries.FailAccountDataExport(ctx, sqlc.FailAccountDataExportParams{
+				ExportID: job.ExportID,
+				Error:    utils.ToNullString("The export could not be built; please request a new one"),
+			}); err != nil {
+				log.Printf("failed to mark data export %d as failed: %v", job.ExportID, err)
+			}
+			continue
+		}
+
+		if err := h.queries.CompleteAccountDataExport(ctx, sqlc.CompleteAccountDataExportParams{
+			Archive:   archive,
+			ExpiresAt: utils.ToNullTime(time.Now().Add(accountExportRetention)),
+			ExportID:  job.ExportID,
+		}); err != nil {
+			log.Printf("failed to save data export %d: %v", job.ExportID, err)
+		}
+	}
+}
+
+// A ZIP of one JSON file per kind of data plus manifest.json describing them
+func (h *AccountDataHandler) buildAccountArchive(ctx context.Context, userID int32) ([]byte, error) {
+	data, err := h.queries.GetAccountDataJSON(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	generatedAt := time.Now().UTC()
+
+	manifest := struct {
+		GeneratedAt time.Time         `json:"generated_at"`
+		UserID      int32             `json:"user_id"`
+		Files       map[string]string `json:"files"`
+		NotKept     map[string]string `json:"not_kept"`
+	}{
+		GeneratedAt: generatedAt,
+		UserID:      userID,
+		Files:       make(map[string]string, len(accountArchiveFiles)),
+		NotKept:     accountArchiveNotKept,
+	}
+
+	var buf bytes.Buffer
+	archive := zip.NewWriter(&buf)
+	writeFile := func(name string, content []byte) error {
+		file, err := archive.CreateHeader(&zip.FileHeader{Name: name, Method: zip.Deflate, Modified: generatedAt})
+		if err != nil {
+			return err
+		}
+		var indented bytes.Buffer
+		if err := json.Indent(&indented, content, "", "  "); err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+		_, err = file.Write(indented.Bytes())
+		return err
+	}
+
+	for _, file := range accountArchiveFiles {
+		manifest.Files[file.name] = file.description
+		if err := writeFile(file.name, []byte(file.content(data))); err != nil {
+			return nil, err
+		}
+	}
+	manifestJSON, err := json.Marshal(manifest)
+	if err != nil {
+		return nil, err
+	}
+	if err := writeFile("manifest.json", manifestJSON); err != nil {
+		return nil, err
+	}
+
+	if err := archive.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (h *AccountDataHandler) eraseDueAccounts(ctx context.Context) {
+	userIDs, err := h.queries.GetDueAccountErasures(ctx)
+	if err != nil {
+		log.Printf("failed to get due account erasures: %v", err)
+		return
+	}
+
+	for _, userID := range userIDs {
+		if err := h.eraseAccount(ctx, userID); err != nil {
+			log.Printf("failed to erase user %d (will retry): %v", userID, err)
+			continue
+		}
+		log.Printf("erased user %d", userID)
+	}
+}
+
+// Deletes every row tied to the user in one transaction, so a failure leaves the account whole to retry later
+func (h *AccountDataHandler) eraseAccount(ctx context.Context, userID int32) error {
+	tx, err := h.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	qtx := h.queries.WithTx(tx)
+
+	if err := qtx.EraseWorkoutSetsForUser(ctx, utils.ToNullInt32(userID)); err != nil {
+		return err
+	}
+	if err := qtx.EraseWorkoutsForUser(ctx, utils.ToNullInt32(userID)); err != nil {
+		return err
+	}
+	if err := qtx.EraseUserProfile(ctx, utils.ToNullInt32(userID)); err != nil {
+		return err
+	}
+	if err := qtx.EraseUser(ctx, userID); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func toAccountDataExportResponse(export sqlc.GetAccountDataExportByIDForUserRow) AccountDataExportResponse {
+	resp := AccountDataExportResponse{
+		ExportID:    export.ExportID,
+		Status:      string(export.Status),
+		RequestedAt: export.RequestedAt,
+	}
+	if export.SizeBytes.Valid {
+		resp.SizeBytes = &export.SizeBytes.Int32
+	}
+	if export.Error.Valid {
+		resp.Error = &export.Error.String
+	}
+	if export.StartedAt.Valid {
+		resp.StartedAt = &export.StartedAt.Time
+	}
+	if export.CompletedAt.Valid {
+		resp.CompletedAt = &export.CompletedAt.Time
+	}
+	if export.ExpiresAt.Valid {
+		resp.ExpiresAt = &export.ExpiresAt.Time
+	}
+	if export.Status == sqlc.AccountExportStatusEnumCompleted {
+		url := fmt.Sprintf("/me/data-exports/%d/download", export.ExportID)
+		resp.DownloadURL = &url
+	}
+	return resp
+}
@@ -0,0 +1,611 @@
+// GET (all, one, trend), POST, PATCH, DELETE - the dated bodyweight, body fat & circumference log
+package handlers
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"go-reppy/backend/internal/api/middleware"
+	"go-reppy/backend/internal/api/response"
+	"go-reppy/backend/internal/api/utils"
+	"go-reppy/backend/internal/database/sqlc"
+	"go-reppy/backend/internal/training"
+)
+
+type BodyMeasurementHandler struct {
+	db      *sql.DB // for saving a measurement & the bodyweight goals it completes atomically
+	queries *sqlc.Queries
+}
+
+func NewBodyMeasurementHandler(db *sql.DB, q *sqlc.Queries) *BodyMeasurementHandler {
+	return &BodyMeasurementHandler{
+		db:      db,
+		queries: q,
+	}
+}
+
+// Weights are in lbs or kg & circumferences in inches or cm, per unit preference (or "?units=")
+type BodyMeasurementRequest struct {
+	MeasuredOn     string   `json:"measured_on"` // YYYY-MM-DD; defaults to today when creating
+	Bodyweight     *float64 `json:"bodyweight"`
+	BodyFatPercent *float64 `json:"body_fat_percent"`
+	Neck           *float64 `json:"neck"`
+	Chest          *float64 `json:"chest"`
+	Waist          *float64 `json:"waist"`
+	Hips           *float64 `json:"hips"`
+	Arm            *float64 `json:"arm"`
+	Forearm        *float64 `json:"forearm"`
+	Thigh          *float64 `json:"thigh"`
+	Calf           *float64 `json:"calf"`
+	Notes          *string  `json:"notes"`
+}
+
+type BodyMeasurementResponse struct {
+	MeasurementID  int32          `json:"MeasurementID"`
+	MeasuredOn     string         `json:"MeasuredOn"`
+	Bodyweight     sql.NullString `json:"Bodyweight"`
+	WeightUnit     string         `json:"WeightUnit"`
+	BodyFatPercent sql.NullString `json:"BodyFatPercent"`
+	Neck           sql.NullString `json:"Neck"`
+	Chest          sql.NullString `json:"Chest"`
+	Waist          sql.NullString `json:"Waist"`
+	Hips           sql.NullString `json:"Hips"`
+	Arm            sql.NullString `json:"Arm"`
+	Forearm        sql.NullString `json:"Forearm"`
+	Thigh          sql.NullString `json:"Thigh"`
+	Calf           sql.NullString `json:"Calf"`
+	LengthUnit     string         `json:"LengthUnit"`
+	Notes          sql.NullString `json:"Notes"`
+	CreatedAt      sql.NullTime   `json:"CreatedAt"`
+	UpdatedAt      sql.NullTime   `json:"UpdatedAt"`
+}
+
+// "/body-measurements"
+func (h *BodyMeasurementHandler) HandleBodyMeasurements(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.GetBodyMeasurements(w, r)
+	case http.MethodPost:
+		h.CreateBodyMeasurement(w, r)
+	default:
+		response.SendError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+}
+
+// "/body-measurements/{id}"
+func (h *BodyMeasurementHandler) HandleBodyMeasurementByID(w http.ResponseWriter, r *http.Request) {
+	id, err := utils.GetIDFromPath(r.URL.Path)
+	if err != nil {
+		response.SendError(w, "Invalid measurement ID", http.StatusBadRequest)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		h.GetBodyMeasurementByID(w, r, id)
+	case http.MethodPatch:
+		h.UpdateBodyMeasurement(w, r, id)
+	case http.MethodDelete:
+		h.DeleteBodyMeasurement(w, r, id)
+	default:
+		response.SendError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+}
+
+// "/body-measurements?from=2024-01-01&to=2024-03-31" (both optional, all time by default)
+func (h *BodyMeasurementHandler) GetBodyMeasurements(w http.ResponseWriter, r *http.Request) {
+	userID, err := middleware.GetUserIDFromContext(r.Context())
+	if err != nil {
+		response.SendError(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	from, to, err := utils.ParseOptionalDateRange(r)
+	if err != nil {
+		response.SendError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	units, ok := resolveRequestUnits(w, r, h.queries)
+	if !ok {
+		return
+	}
+
+	measurements, err := h.queries.GetBodyMeasurementsForUser(r.Context(), sqlc.GetBodyMeasurementsForUserParams{
+		UserID:   int32(userID),
+		FromDate: from,
+		ToDate:   to,
+	})
+	if err != nil {
+		response.SendError(w, "Failed to retrieve body measurements", http.StatusInternalServerError)
+		return
+	}
+
+	resp := make([]BodyMeasurementResponse, len(measurements))
+	for i, measurement := range measurements {
+		resp[i] = toBodyMeasurementResponse(measurement, units)
+	}
+
+	response.SendSuccess(w, resp)
+}
+
+// "/body-measurements"
+func (h *BodyMeasurementHandler) CreateBodyMeasurement(w http.ResponseWriter, r *http.Request) {
+	var request BodyMeasurementRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		response.SendError(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	userID, err := middleware.GetUserIDFromContext(r.Context())
+	if err != nil {
+		response.SendError(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	if err := request.validate(); err != nil {
+		response.SendError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if !request.hasMeasurement() {
+		response.SendError(w, "At least one measurement is required", http.StatusBadRequest)
+		return
+	}
+
+	today, err := utils.ClientToday(r)
+	if err != nil {
+		response.SendError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	measuredOn := today
+	if request.MeasuredOn != "" {
+		measuredOn, err = time.Parse("2006-01-02", request.MeasuredOn)
+		if err != nil {
+			response.SendError(w, "Invalid date format for measured_on (use YYYY-MM-DD)", http.StatusBadRequest)
+			return
+		}
+	}
+
+	units, ok := resolveRequestUnits(w, r, h.queries)
+	if !ok {
+		return
+	}
+
+	tx, err := h.db.BeginTx(r.Context(), nil)
+	if err != nil {
+		response.SendError(w, "Failed to create body measurement", http.StatusInternalServerError)
+		return
+	}
+	defer tx.Rollback()
+	qtx := h.queries.WithTx(tx)
+
+	measurement, err := qtx.CreateBodyMeasurement(r.Context(), sqlc.CreateBodyMeasurementParams{
+		UserID:         int32(userID),
+		MeasuredOn:     measuredOn,
+		BodyweightKg:   utils.ToKgFromFloatPtr(request.Bodyweight, units),
+		BodyFatPercent: toNullDecimalFromFloatPtr(request.BodyFatPercent),
+		NeckCm:         utils.ToCmFromFloatPtr(request.Neck, units),
+		ChestCm:        utils.ToCmFromFloatPtr(request.Chest, units),
+		WaistCm:        utils.ToCmFromFloatPtr(request.Waist, units),
+		HipsCm:         utils.ToCmFromFloatPtr(request.Hips, units),
+		ArmCm:          utils.ToCmFromFloatPtr(request.Arm, units),
+		ForearmCm:      utils.ToCmFromFloatPtr(request.Forearm, units),
+		ThighCm:        utils.ToCmFromFloatPtr(request.Thigh, units),
+		CalfCm:         utils.ToCmFromFloatPtr(request.Calf, units),
+		Notes:          utils.ToNullStringFromStringPtr(request.Notes),
+	})
+	if err != nil {
+		response.SendError(w, "Failed to create body measurement", http.StatusInternalServerError)
+		return
+	}
+
+	if err := detectCompletedGoals(r.Context(), qtx, int32(userID), today); err != nil {
+		response.SendError(w, "Failed to check goals", http.StatusInternalServerError)
+		return
+	}
+
+	if err := tx.Commit(); err != nil {
+		response.SendError(w, "Failed to create body measurement", http.StatusInternalServerError)
+		return
+	}
+
+	response.SendSuccess(w, toBodyMeasurementResponse(measurement, units), http.StatusCreated)
+}
+
+// "/body-measurements/{id}"
+func (h *BodyMeasurementHandler) GetBodyMeasurementByID(w http.ResponseWriter, r *http.Request, id int32) {
+	userID, err := middleware.GetUserIDFromContext(r.Context())
+	if err != nil {
+		response.SendError(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	units, ok := resolveRequestUnits(w, r, h.queries)
+	if !ok {
+		return
+	}
+
+	measurement, err := h.queries.GetBodyMeasurementByIDForUser(r.Context(), sqlc.GetBodyMeasurementByIDForUserParams{
+		MeasurementID: id,
+		UserID:        int32(userID),
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			response.SendError(w, "Body measurement not found", http.StatusNotFound)
+			return
+		}
+		response.SendError(w, "Failed to retrieve body measurement", http.StatusInternalServerError)
+		return
+	}
+
+	response.SendSuccess(w, toBodyMeasurementResponse(measurement, units))
+}
+
+// "/body-measurements/{id}" - fields left out keep their current value
+func (h *BodyMeasurementHandler) UpdateBodyMeasurement(w http.ResponseWriter, r *http.Request, id int32) {
+	var request BodyMeasurementRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		response.SendError(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	userID, err := middleware.GetUserIDFromContext(r.Context())
+	if err != nil {
+		response.SendError(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	if err := request.validate(); err != nil {
+		response.SendError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var measuredOn sql.NullTime
+	if request.MeasuredOn != "" {
+		date, err := time.Parse("2006-01-02", request.MeasuredOn)
+		if err != nil {
+			response.SendError(w, "Invalid date format for measured_on (use YYYY-MM-DD)", http.StatusBadRequest)
+			return
+		}
+		measuredOn = utils.ToNullTime(date)
+	}
+
+	units, ok := resolveRequestUnits(w, r, h.queries)
+	if !ok {
+		return
+	}
+
+	today, err := utils.ClientToday(r)
+	if err != nil {
+		response.SendError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	tx, err := h.db.BeginTx(r.Context(), nil)
+	if err != nil {
+		response.SendError(w, "Failed to update body measurement", http.StatusInternalServerError)
+		return
+	}
+	defer tx.Rollback()
+	qtx := h.queries.WithTx(tx)
+
+	measurement, err := qtx.UpdateBodyMeasurement(r.Context(), sqlc.UpdateBodyMeasurementParams{
+		MeasurementID:  id,
+		UserID:         int32(userID),
+		MeasuredOn:     measuredOn,
+		BodyweightKg:   utils.ToKgFromFloatPtr(request.Bodyweight, units),
+		BodyFatPercent: toNullDecimalFromFloatPtr(request.BodyFatPercent),
+		NeckCm:         utils.ToCmFromFloatPtr(request.Neck, units),
+		ChestCm:        utils.ToCmFromFloatPtr(request.Chest, units),
+		WaistCm:        utils.ToCmFromFloatPtr(request.Waist, units),
+		HipsCm:         utils.ToCmFromFloatPtr(request.Hips, units),
+		ArmCm:          utils.ToCmFromFloatPtr(request.Arm, units),
+		ForearmCm:      utils.ToCmFromFloatPtr(request.Forearm, units),
+		ThighCm:        utils.ToCmFromFloatPtr(request.Thigh, units),
+		CalfCm:         utils.ToCmFromFloatPtr(request.Calf, units),
+		Notes:          utils.ToNullStringFromStringPtr(request.Notes),
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			response.SendError(w, "Body measurement not found", http.StatusNotFound)
+			return
+		}
+		response.SendError(w, "Failed to update body measurement", http.StatusInternalServerError)
+		return
+	}
+
+	if err := detectCompletedGoals(r.Context(), qtx, int32(userID), today); err != nil {
+		response.SendError(w, "Failed to check goals", http.StatusInternalServerError)
+		return
+	}
+
+	if err := tx.Commit(); err != nil {
+		response.SendError(w, "Failed to update body measurement", http.StatusInternalServerError)
+		return
+	}
+
+	response.SendSuccess(w, toBodyMeasurementResponse(measurement, units))
+}
+
+// "/body-measurements/{id}"
+func (h *BodyMeasurementHandler) DeleteBodyMeasurement(w http.ResponseWriter, r *http.Request, id int32) {
+	userID, err := middleware.GetUserIDFromContext(r.Context())
+	if err != nil {
+		response.SendError(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	_, err = h.queries.DeleteBodyMeasurement(r.Context(), sqlc.DeleteBodyMeasurementParams{
+		MeasurementID: id,
+		UserID:        int32(userID),
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			response.SendError(w, "Body measurement not found", http.StatusNotFound)
+			return
+		}
+		response.SendError(w, "Failed to delete body measurement", http.StatusInternalServerError)
+		return
+	}
+
+	response.SendSuccess(w, nil, http.StatusNoContent)
+}
+
+// A trackable body metric: how to read it off a measurement & whether it converts like a weight or a length
+type bodyMetric struct {
+	value func(sqlc.BodyMeasurement) sql.NullString
+	kind  string // "weight", "length" or "percent"
+}
+
+var bodyMetrics = map[string]bodyMetric{
+	"bodyweight":       {func(m sqlc.BodyMeasurement) sql.NullString { return m.BodyweightKg }, "weight"},
+	"body_fat_percent": {func(m sqlc.BodyMeasurement) sql.NullString { return m.BodyFatPercent }, "percent"},
+	"neck":             {func(m sqlc.BodyMeasurement) sql.NullString { return m.NeckCm }, "length"},
+	"chest":            {func(m sqlc.BodyMeasurement) sql.NullString { return m.ChestCm }, "length"},
+	"waist":            {func(m sqlc.BodyMeasurement) sql.NullString { return m.WaistCm }, "length"},
+	"hips":             {func(m sqlc.BodyMeasurement) sql.NullString { return m.HipsCm }, "length"},
+	"arm":              {func(m sqlc.BodyMeasurement) sql.NullString { return m.ArmCm }, "length"},
+	"forearm":          {func(m sqlc.BodyMeasurement) sql.NullString { return m.ForearmCm }, "length"},
+	"thigh":            {func(m sqlc.BodyMeasurement) sql.NullString { return m.ThighCm }, "length"},
+	"calf":             {func(m sqlc.BodyMeasurement) sql.NullString { return m.CalfCm }, "length"},
+}
+
+const defaultTrendWindowDays = 7
+
+type BodyMetricPoint struct {
+	Date          string  `json:"Date"`
+	Value         float64 `json:"Value"`
+	MovingAverage float64 `json:"MovingAverage"`
+}
+
+type BodyMetricTrendResponse struct {
+	Metric       string            `json:"Metric"`
+	Unit         string            `json:"Unit"`
+	WindowDays   int               `json:"WindowDays"`
+	TrendPerWeek *float64          `json:"TrendPerWeek"` // least-squares slope; nil with fewer than two readings
+	Points       []BodyMetricPoint `json:"Points"`       // oldest first, only dates with a reading
+}
+
+/*
+"/body-measurements/trend?metric=waist&window_days=14&from=2024-01-01"
+optional params: metric ('bodyweight' (default), 'body_fat_percent', 'neck', 'chest', 'waist', 'hips', 'arm', 'forearm',
+'thigh', 'calf'), window_days (moving average window, default 7), from, to, units
+*/
+func (h *BodyMeasurementHandler) HandleBodyMeasurementTrend(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		response.SendError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	userID, err := middleware.GetUserIDFromContext(r.Context())
+	if err != nil {
+		response.SendError(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	query := r.URL.Query()
+	metricName := query.Get("metric")
+	if metricName == "" {
+		metricName = "bodyweight"
+	}
+	metric, ok := bodyMetrics[metricName]
+	if !ok {
+		response.SendError(w, "metric must be one of 'bodyweight', 'body_fat_percent', 'neck', 'chest', 'waist', 'hips', 'arm', 'forearm', 'thigh', 'calf'", http.StatusBadRequest)
+		return
+	}
+
+	windowDays := defaultTrendWindowDays
+	if windowStr := query.Get("window_days"); windowStr != "" {
+		windowDays, err = strconv.Atoi(windowStr)
+		if err != nil || windowDays <= 0 {
+			response.SendError(w, "window_days must be a positive integer", http.StatusBadRequest)
+			return
+		}
+	}
+
+	from, to, err := utils.ParseOptionalDateRange(r)
+	if err != nil {
+		response.SendError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	units, ok := resolveRequestUnits(w, r, h.queries)
+	if !ok {
+		return
+	}
+
+	measurements, err := h.queries.GetBodyMeasurementsForUser(r.Context(), sqlc.GetBodyMeasurementsForUserParams{
+		UserID:   int32(userID),
+		FromDate: from,
+		ToDate:   to,
+	})
+	if err != nil {
+		response.SendError(w, "Failed to retrieve body measurements", http.StatusInternalServerError)
+		return
+	}
+
+	var points []training.TrendPoint
+	for _, measurement := range measurements {
+		value := metric.value(measurement)
+		switch metric.kind {
+		case "weight":
+			value = utils.FromKg(value, units)
+		case "length":
+			value = utils.FromCm(value, units)
+		}
+		if !value.Valid {
+			continue
+		}
+		parsed, err := strconv.ParseFloat(value.String, 64)
+		if err != nil {
+			continue
+		}
+		points = append(points, training.TrendPoint{Date: measurement.MeasuredOn, Value: parsed})
+	}
+
+	result := BodyMetricTrendResponse{
+		Metric:     metricName,
+		Unit:       "%",
+		WindowDays: windowDays,
+		Points:     make([]BodyMetricPoint, len(points)),
+	}
+	switch metric.kind {
+	case "weight":
+		result.Unit = utils.WeightUnit(units)
+	case "length":
+		result.Unit = utils.HeightUnit(units)
+	}
+
+	averages := training.MovingAverage(points, windowDays)
+	for i, point := range points {
+		result.Points[i] = BodyMetricPoint{
+			Date:          point.Date.Format("2006-01-02"),
+			Value:         point.Value,
+			MovingAverage: averages[i],
+		}
+	}
+	if trend, ok := training.WeeklyTrend(points); ok {
+		result.TrendPerWeek = &trend
+	}
+
+	response.SendSuccess(w, result)
+}
+
+type RelativeStrengthResponse struct {
+	ExerciseID       int32   `json:"ExerciseID"`
+	ExerciseName     string  `json:"ExerciseName"`
+	WorkoutDate      string  `json:"WorkoutDate"`
+	Estimated1RM     float64 `json:"Estimated1RM"` // includes bodyweight for bodyweight exercises
+	Bodyweight       float64 `json:"Bodyweight"`   // the closest logged bodyweight to WorkoutDate
+	Unit             string  `json:"Unit"`
+	RelativeStrength float64 `json:"RelativeStrength"` // Estimated1RM / Bodyweight
+}
+
+// Each exercise's best estimated 1RM divided by bodyweight on the day it was lifted
+// "/me/relative-strength"
+func (h *BodyMeasurementHandler) HandleRelativeStrength(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		response.SendError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	userID, err := middleware.GetUserIDFromContext(r.Context())
+	if err != nil {
+		response.SendError(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	units, ok := resolveRequestUnits(w, r, h.queries)
+	if !ok {
+		return
+	}
+
+	rows, err := h.queries.GetRelativeStrengthForUser(r.Context(), utils.ToNullInt32(userID))
+	if err != nil {
+		response.SendError(w, "Failed to retrieve relative strength", http.StatusInternalServerError)
+		return
+	}
+
+	resp := make([]RelativeStrengthResponse, len(rows))
+	for i, row := range rows {
+		oneRMKg, _ := strconv.ParseFloat(row.Estimated1rmKg, 64)
+		bodyweightKg, _ := strconv.ParseFloat(row.BodyweightKg, 64)
+		relative, _ := strconv.ParseFloat(row.RelativeStrength, 64)
+		resp[i] = RelativeStrengthResponse{
+			ExerciseID:       row.ExerciseID,
+			ExerciseName:     row.ExerciseName,
+			WorkoutDate:      row.WorkoutDate.Format("2006-01-02"),
+			Estimated1RM:     utils.FromKgFloat(oneRMKg, units),
+			Bodyweight:       utils.FromKgFloat(bodyweightKg, units),
+			Unit:             utils.WeightUnit(units),
+			RelativeStrength: roundTo2(relative),
+		}
+	}
+
+	response.SendSuccess(w, resp)
+}
+
+func (request BodyMeasurementRequest) validate() error {
+	if request.BodyFatPercent != nil && (*request.BodyFatPercent < 0 || *request.BodyFatPercent > 100) {
+		return fmt.Errorf("body_fat_percent must be between 0 and 100")
+	}
+
+	positive := map[string]*float64{
+		"bodyweight": request.Bodyweight, "neck": request.Neck, "chest": request.Chest, "waist": request.Waist,
+		"hips": request.Hips, "arm": request.Arm, "forearm": request.Forearm, "thigh": request.Thigh, "calf": request.Calf,
+	}
+	for field, value := range positive {
+		if value != nil && *value <= 0 {
+			return fmt.Errorf("%s must be greater than 0", field)
+		}
+	}
+	return nil
+}
+
+func (request BodyMeasurementRequest) hasMeasurement() bool {
+	for _, value := range []*float64{
+		request.Bodyweight, request.BodyFatPercent, request.Neck, request.Chest, request.Waist,
+		request.Hips, request.Arm, request.Forearm, request.Thigh, request.Calf,
+	} {
+		if value != nil {
+			return true
+		}
+	}
+	return false
+}
+
+func toBodyMeasurementResponse(measurement sqlc.BodyMeasurement, units sqlc.UnitSystemEnum) BodyMeasurementResponse {
+	return BodyMeasurementResponse{
+		MeasurementID:  measurement.MeasurementID,
+		MeasuredOn:     measurement.MeasuredOn.Format("2006-01-02"),
+		Bodyweight:     utils.FromKg(measurement.BodyweightKg, units),
+		WeightUnit:     utils.WeightUnit(units),
+		BodyFatPercent: measurement.BodyFatPercent,
+		Neck:           utils.FromCm(measurement.NeckCm, units),
+		Chest:          utils.FromCm(measurement.ChestCm, units),
+		Waist:          utils.FromCm(measurement.WaistCm, units),
+		Hips:           utils.FromCm(measurement.HipsCm, units),
+		Arm:            utils.FromCm(measurement.ArmCm, units),
+		Forearm:        utils.FromCm(measurement.ForearmCm, units),
+		Thigh:          utils.FromCm(measurement.ThighCm, units),
+		Calf:           utils.FromCm(measurement.CalfCm, units),
+		LengthUnit:     utils.HeightUnit(units),
+		Notes:          measurement.Notes,
+		CreatedAt:      measurement.CreatedAt,
+		UpdatedAt:      measurement.UpdatedAt,
+	}
+}
+
+func toNullDecimalFromFloatPtr(f *float64) sql.NullString {
+	if f == nil {
+		return sql.NullString{}
+	}
+	return sql.NullString{String: strconv.FormatFloat(*f, 'f', -1, 64), Valid: true}
+}
@@ -0,0 +1,298 @@
+// GET, POST, DELETE - an iCalendar (RFC 5545) feed of upcoming workouts that calendar apps can subscribe to.
+// Calendar apps can't log in, so the feed lives at a secret URL; regenerating it revokes the old one.
+package handlers
+
+import (
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"go-reppy/backend/internal/api/middleware"
+	"go-reppy/backend/internal/api/response"
+	"go-reppy/backend/internal/api/utils"
+	"go-reppy/backend/internal/database/sqlc"
+)
+
+type CalendarFeedHandler struct {
+	queries *sqlc.Queries
+}
+
+func NewCalendarFeedHandler(q *sqlc.Queries) *CalendarFeedHandler {
+	return &CalendarFeedHandler{
+		queries: q,
+	}
+}
+
+const (
+	calendarFeedTokenBytes    = 32 // hex encoded, so 64 characters
+	calendarFeedMaxWorkouts   = 500
+	calendarFeedEventDuration = time.Hour // for workouts that have a start time but no end time
+	calendarFeedLineLimit     = 75        // octets per line before folding, per RFC 5545
+)
+
+type CalendarFeedResponse struct {
+	URL       string    `json:"URL"` // subscribe to this in a calendar app; anyone with it can read the feed
+	CreatedAt time.Time `json:"CreatedAt"`
+}
+
+// "/me/calendar-feed"
+func (h *CalendarFeedHandler) HandleCalendarFeed(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.GetCalendarFeed(w, r)
+	case http.MethodPost:
+		h.RegenerateCalendarFeed(w, r)
+	case http.MethodDelete:
+		h.DeleteCalendarFeed(w, r)
+	default:
+		response.SendError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+}
+
+// "/calendar-feeds/{token}" - unauthenticated, the token is the secret
+func (h *CalendarFeedHandler) HandleCalendarFeedICS(w http.ResponseWriter, r *http.Request) {
+	pathParts := strings.Split(r.URL.Path, "/")
+	if len(pathParts) < 3 || pathParts[2] == "" {
+		response.SendError(w, "Invalid path URL", http.StatusBadRequest)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet, http.MethodHead:
+		// some calendar apps insist on a ".ics" ending, so it's allowed but not needed
+		h.GetCalendarFeedICS(w, r, strings.TrimSuffix(pathParts[2], ".ics"))
+	default:
+		response.SendError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+}
+
+// "/me/calendar-feed"
+func (h *CalendarFeedHandler) GetCalendarFeed(w http.ResponseWriter, r *http.Request) {
+	userID, err := middleware.GetUserIDFromContext(r.Context())
+	if err != nil {
+		response.SendError(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	feed, err := h.queries.GetCalendarFeedTokenForUser(r.Context(), int32(userID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			response.SendError(w, "No calendar feed has been set up", http.StatusNotFound)
+			return
+		}
+		response.SendError(w, "Failed to get calendar feed", http.StatusInternalServerError)
+		return
+	}
+
+	response.SendSuccess(w, toCalendarFeedResponse(r, feed))
+}
+
+/*
+"/me/calendar-feed" - no body. Creates the feed URL, or replaces it if there already is one; the old URL stops
+working straight away, so use this if it's been shared by mistake.
+*/
+func (h *CalendarFeedHandler) RegenerateCalendarFeed(w http.ResponseWriter, r *http.Request) {
+	userID, err := middleware.GetUserIDFromContext(r.Context())
+	if err != nil {
+		response.SendError(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	token, err := newCalendarFeedToken()
+	if err != nil {
+		response.SendError(w, "Failed to create calendar feed", http.StatusInternalServerError)
+		return
+	}
+
+	feed, err := h.queries.UpsertCalendarFeedToken(r.Context(), sqlc.UpsertCalendarFeedTokenParams{
+		UserID: int32(userID),
+		Token:  token,
+	})
+	if err != nil {
+		response.SendError(w, "Failed to create calendar feed", http.StatusInternalServerError)
+		return
+	}
+
+	response.SendSuccess(w, toCalendarFeedResponse(r, feed), http.StatusCreated)
+}
+
+// "/me/calendar-feed" - turns the feed off
+func (h *CalendarFeedHandler) DeleteCalendarFeed(w http.ResponseWriter, r *http.Request) {
+	userID, err := middleware.GetUserIDFromContext(r.Context())
+	if err != nil {
+		response.SendError(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	deleted, err := h.queries.DeleteCalendarFeedToken(r.Context(), int32(userID))
+	if err != nil {
+		response.SendError(w, "Failed to delete calendar feed", http.StatusInternalServerError)
+		return
+	}
+	if deleted == 0 {
+		response.SendError(w, "No calendar feed has been set up", http.StatusNotFound)
+		return
+	}
+
+	response.SendSuccess(w, nil, http.StatusNoContent)
+}
+
+/*
+"/calendar-feeds/{token}.ics"
+Workouts from today on (today in the profile's timezone, UTC if it has none), one event each. Workouts with a start
+time are timed events in UTC, which calendar apps show in local time; the rest are all-day events on their date.
+Event UIDs come from the workout ID, so edits update the existing event instead of adding another.
+*/
+func (h *CalendarFeedHandler) GetCalendarFeedICS(w http.ResponseWriter, r *http.Request, token string) {
+	owner, err := h.queries.GetCalendarFeedOwner(r.Context(), token)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			response.SendError(w, "Calendar feed not found", http.StatusNotFound)
+			return
+		}
+		response.SendError(w, "Failed to get calendar feed", http.StatusInternalServerError)
+		return
+	}
+
+	location := time.UTC
+	if owner.Timezone.Valid {
+		// validated when saved, but a tzdata update could still drop a name
+		if loaded, err := time.LoadLocation(owner.Timezone.String); err == nil {
+			location = loaded
+		}
+	}
+	now := time.Now().In(location)
+
+	workouts, err := h.queries.GetCalendarFeedWorkouts(r.Context(), sqlc.GetCalendarFeedWorkoutsParams{
+		UserID:      utils.ToNullInt32(owner.UserID),
+		FromDate:    time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC),
+		MaxWorkouts: calendarFeedMaxWorkouts,
+	})
+	if err != nil {
+		response.SendError(w, "Failed to get calendar feed", http.StatusInternalServerError)
+		return
+	}
+
+	feed := buildCalendarFeed(workouts, location, now)
+
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	w.Header().Set("Content-Disposition", `inline; filename="reppy-workouts.ics"`)
+	w.Header().Set("Cache-Control", "private, max-age=300")
+	w.WriteHeader(http.StatusOK)
+	if r.Method == http.MethodHead {
+		return
+	}
+	if _, err := w.Write([]byte(feed)); err != nil {
+		log.Printf("calendar feed for user %d failed to write: %v", owner.UserID, err)
+	}
+}
+
+func buildCalendarFeed(workouts []sqlc.GetCalendarFeedWorkoutsRow, location *time.Location, now time.Time) string {
+	var b strings.Builder
+	line := func(name, value string) {
+		writeCalendarLine(&b, name+":"+value)
+	}
+
+	line("BEGIN", "VCALENDAR")
+	line("VERSION", "2.0")
+	line("PRODID", "-//Reppy//Planned Workouts//EN")
+	line("CALSCALE", "GREGORIAN")
+	line("METHOD", "PUBLISH")
+	line("X-WR-CALNAME", "Reppy workouts")
+	line("X-WR-TIMEZONE", location.String())
+	line("REFRESH-INTERVAL;VALUE=DURATION", "PT1H")
+	line("X-PUBLISHED-TTL", "PT1H")
+
+	stamp := formatCalendarTime(now)
+	for _, workout := range workouts {
+		title := "Workout"
+		if workout.Title.Valid && strings.TrimSpace(workout.Title.String) != "" {
+			title = workout.Title.String
+		}
+
+		line("BEGIN", "VEVENT")
+		line("UID", fmt.Sprintf("workout-%d@reppy", workout.WorkoutID))
+		line("DTSTAMP", stamp)
+		line("LAST-MODIFIED", formatCalendarTime(workout.LastModified))
+		if workout.StartedAt.Valid {
+			end := workout.StartedAt.Time.Add(calendarFeedEventDuration)
+			if workout.EndedAt.Valid && workout.EndedAt.Time.After(workout.StartedAt.Time) {
+				end = workout.EndedAt.Time
+			}
+			line("DTSTART", formatCalendarTime(workout.StartedAt.Time))
+			line("DTEND", formatCalendarTime(end))
+		} else {
+			// all-day events end on the (exclusive) next day
+			line("DTSTART;VALUE=DATE", workout.WorkoutDate.Format("20060102"))
+			line("DTEND;VALUE=DATE", workout.WorkoutDate.AddDate(0, 0, 1).Format("20060102"))
+		}
+		line("SUMMARY", escapeCalendarText(title))
+		if workout.Exercises != "" {
+			line("DESCRIPTION", escapeCalendarText(workout.Exercises))
+		}
+		line("END", "VEVENT")
+	}
+
+	line("END", "VCALENDAR")
+	return b.String()
+}
+
+// Writes a content line ending in CRLF, folded onto continuation lines (which start with a space) so none is
+// longer than 75 octets. Folds never split a multi-byte character.
+func writeCalendarLine(b *strings.Builder, content string) {
+	limit := calendarFeedLineLimit
+	for len(content) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(content[cut]) {
+			cut--
+		}
+		b.WriteString(content[:cut])
+		b.WriteString("\r\n ")
+		content = content[cut:]
+		limit = calendarFeedLineLimit - 1 // the leading space counts towards the limit
+	}
+	b.WriteString(content)
+	b.WriteString("\r\n")
+}
+
+var calendarTextEscaper = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`, "\r", `\n`)
+
+func escapeCalendarText(text string) string {
+	return calendarTextEscaper.Replace(text)
+}
+
+func formatCalendarTime(t time.Time) string {
+	return t.UTC().Format("20060102T150405Z")
+}
+
+func newCalendarFeedToken() (string, error) {
+	token := make([]byte, calendarFeedTokenBytes)
+	if _, err := rand.Read(token); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(token), nil
+}
+
+func toCalendarFeedResponse(r *http.Request, feed sqlc.CalendarFeedToken) CalendarFeedResponse {
+	// calendar apps need the full URL; behind a proxy the original scheme comes from X-Forwarded-Proto
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+	if proto := r.Header.Get("X-Forwarded-Proto"); proto == "http" || proto == "https" {
+		scheme = proto
+	}
+
+	return CalendarFeedResponse{
+		URL:       fmt.Sprintf("%s://%s/calendar-feeds/%s.ics", scheme, r.Host, feed.Token),
+		CreatedAt: feed.CreatedAt,
+	}
+}
@@ -0,0 +1,276 @@
+// GET only - a month of workouts at a glance, with streaks & adherence to the weekly session target
+package handlers
+
+import (
+	"database/sql"
+	"net/http"
+	"strconv"
+	"time"
+
+	"go-reppy/backend/internal/api/middleware"
+	"go-reppy/backend/internal/api/response"
+	"go-reppy/backend/internal/api/utils"
+	"go-reppy/backend/internal/database/sqlc"
+	"go-reppy/backend/internal/training"
+)
+
+type CalendarHandler struct {
+	queries *sqlc.Queries
+}
+
+func NewCalendarHandler(q *sqlc.Queries) *CalendarHandler {
+	return &CalendarHandler{
+		queries: q,
+	}
+}
+
+const (
+	workoutStatusPlanned    = "planned"     // nothing logged yet, today or later
+	workoutStatusInProgress = "in_progress" // started but not finished
+	workoutStatusCompleted  = "completed"   // has logged sets, whether ticked off live or entered after the fact
+	workoutStatusMissed     = "missed"      // its day has passed with nothing logged
+)
+
+type CalendarWorkout struct {
+	WorkoutID           int32      `json:"WorkoutID"`
+	Title               *string    `json:"Title"`
+	Status              string     `json:"Status"` // 'planned', 'in_progress', 'completed', 'missed'
+	SetCount            int32      `json:"SetCount"`
+	CompletedSetCount   int32      `json:"CompletedSetCount"` // sets ticked off during a live session
+	Tonnage             float64    `json:"Tonnage"`           // weight x reps of weighted & bodyweight sets, warm-ups left out
+	PrimaryMuscleGroups []string   `json:"PrimaryMuscleGroups"`
+	StartedAt           *time.Time `json:"StartedAt"` // in the client's timezone
+	EndedAt             *time.Time `json:"EndedAt"`
+	DurationMinutes     *float64   `json:"DurationMinutes"` // nil unless the workout was started & finished
+}
+
+type CalendarDay struct {
+	Date     string            `json:"Date"` // YYYY-MM-DD
+	Workouts []CalendarWorkout `json:"Workouts"`
+}
+
+type TrainingStreaks struct {
+	CurrentDays int  `json:"CurrentDays"` // still running if the last training day was today or yesterday
+	LongestDays int  `json:"LongestDays"`
+	TargetWeeks *int `json:"TargetWeeks"` // consecutive weeks that reached the weekly target; nil without a target
+}
+
+type WeekAdherence struct {
+	WeekStart string `json:"WeekStart"` // Monday, YYYY-MM-DD
+	Sessions  int    `json:"Sessions"`
+	Met       *bool  `json:"Met"` // so far, for the current week; nil without a target or for weeks that haven't started
+}
+
+type WeeklyAdherence struct {
+	Target  *int32          `json:"Target"`  // the profile's weekly_session_target
+	Weeks   []WeekAdherence `json:"Weeks"`   // every week that overlaps the month
+	Percent *float64        `json:"Percent"` // share of the month's finished weeks that met the target
+}
+
+type CalendarResponse struct {
+	Month           string          `json:"Month"` // YYYY-MM
+	Unit            string          `json:"Unit"`
+	Days            []CalendarDay   `json:"Days"` // every day of the month, including ones without workouts
+	Streaks         TrainingStreaks `json:"Streaks"`
+	WeeklyAdherence WeeklyAdherence `json:"WeeklyAdherence"`
+}
+
+/*
+"/calendar?month=2024-06&units=metric"
+optional params: month (default the current month in the client's timezone), units
+"today" (for statuses & streaks) follows the X-User-Timezone header
+*/
+func (h *CalendarHandler) HandleCalendar(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		response.SendError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	today, err := utils.ClientToday(r)
+	if err != nil {
+		response.SendError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	monthStart := time.Date(today.Year(), today.Month(), 1, 0, 0, 0, 0, time.UTC)
+	if monthStr := r.URL.Query().Get("month"); monthStr != "" {
+		monthStart, err = time.Parse("2006-01", monthStr)
+		if err != nil {
+			response.SendError(w, "Invalid month format. Use YYYY-MM", http.StatusBadRequest)
+			return
+		}
+	}
+	monthEnd := monthStart.AddDate(0, 1, -1)
+
+	userID, err := middleware.GetUserIDFromContext(r.Context())
+	if err != nil {
+		response.SendError(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	units, ok := resolveRequestUnits(w, r, h.queries)
+	if !ok {
+		return
+	}
+
+	workouts, err := h.queries.GetCalendarWorkouts(r.Context(), sqlc.GetCalendarWorkoutsParams{
+		UserID:   utils.ToNullInt32(userID),
+		FromDate: monthStart,
+		ToDate:   monthEnd,
+	})
+	if err != nil {
+		response.SendError(w, "Failed to retrieve workouts", http.StatusInternalServerError)
+		return
+	}
+
+	muscleGroupRows, err := h.queries.GetCalendarPrimaryMuscleGroups(r.Context(), sqlc.GetCalendarPrimaryMuscleGroupsParams{
+		UserID:   utils.ToNullInt32(userID),
+		FromDate: monthStart,
+		ToDate:   monthEnd,
+	})
+	if err != nil {
+		response.SendError(w, "Failed to retrieve muscle groups", http.StatusInternalServerError)
+		return
+	}
+	muscleGroups := make(map[int32][]string)
+	for _, row := range muscleGroupRows {
+		muscleGroups[row.WorkoutID] = append(muscleGroups[row.WorkoutID], row.MuscleGroup)
+	}
+
+	dayRows, err := h.queries.GetTrainingDaysForUser(r.Context(), sqlc.GetTrainingDaysForUserParams{
+		UserID: utils.ToNullInt32(userID),
+		Today:  today,
+	})
+	if err != nil {
+		response.SendError(w, "Failed to retrieve training history", http.StatusInternalServerError)
+		return
+	}
+	trainingDays := make([]training.TrainingDay, len(dayRows))
+	for i, row := range dayRows {
+		trainingDays[i] = training.TrainingDay{Date: row.WorkoutDate.UTC(), Sessions: int(row.Sessions)}
+	}
+
+	var target *int32
+	profile, err := h.queries.GetUserProfile(r.Context(), utils.ToNullInt32(userID))
+	if err != nil && err != sql.ErrNoRows {
+		response.SendError(w, "Failed to retrieve user profile", http.StatusInternalServerError)
+		return
+	}
+	if err == nil && profile.WeeklySessionTarget.Valid {
+		target = &profile.WeeklySessionTarget.Int32
+	}
+
+	days := make([]CalendarDay, 0, monthEnd.Day())
+	dayIndex := make(map[string]int, monthEnd.Day())
+	for date := monthStart; !date.After(monthEnd); date = date.AddDate(0, 0, 1) {
+		dayIndex[date.Format("2006-01-02")] = len(days)
+		days = append(days, CalendarDay{Date: date.Format("2006-01-02"), Workouts: []CalendarWorkout{}})
+	}
+	for _, workout := range workouts {
+		entry, err := calendarWorkout(r, workout, muscleGroups[workout.WorkoutID], today, units)
+		if err != nil {
+			response.SendError(w, "Timezone conversion error", http.StatusBadRequest)
+			return
+		}
+		day := &days[dayIndex[workout.WorkoutDate.UTC().Format("2006-01-02")]]
+		day.Workouts = append(day.Workouts, entry)
+	}
+
+	current, longest := training.DayStreaks(trainingDays, today)
+	weekly := training.WeeklySessions(trainingDays)
+	streaks := TrainingStreaks{CurrentDays: current, LongestDays: longest}
+	if target != nil {
+		targetWeeks := training.TargetWeekStreak(weekly, int(*target), today)
+		streaks.TargetWeeks = &targetWeeks
+	}
+
+	response.SendSuccess(w, CalendarResponse{
+		Month:           monthStart.Format("2006-01"),
+		Unit:            utils.WeightUnit(units),
+		Days:            days,
+		Streaks:         streaks,
+		WeeklyAdherence: weeklyAdherence(weekly, target, monthStart, monthEnd, today),
+	})
+}
+
+func calendarWorkout(r *http.Request, row sqlc.GetCalendarWorkoutsRow, muscleGroups []string, today time.Time, units sqlc.UnitSystemEnum) (CalendarWorkout, error) {
+	tonnageKg, _ := strconv.ParseFloat(row.TonnageKg, 64)
+	if muscleGroups == nil {
+		muscleGroups = []string{}
+	}
+
+	workout := CalendarWorkout{
+		WorkoutID:           row.WorkoutID,
+		Status:              workoutStatus(row, today),
+		SetCount:            row.SetCount,
+		CompletedSetCount:   row.CompletedSetCount,
+		Tonnage:             utils.FromKgFloat(tonnageKg, units),
+		PrimaryMuscleGroups: muscleGroups,
+	}
+	if row.Title.Valid {
+		workout.Title = &row.Title.String
+	}
+	if row.StartedAt.Valid {
+		startedAt, err := utils.FromUTCToClientTimezone(row.StartedAt.Time, r)
+		if err != nil {
+			return CalendarWorkout{}, err
+		}
+		workout.StartedAt = &startedAt
+	}
+	if row.EndedAt.Valid {
+		endedAt, err := utils.FromUTCToClientTimezone(row.EndedAt.Time, r)
+		if err != nil {
+			return CalendarWorkout{}, err
+		}
+		workout.EndedAt = &endedAt
+	}
+	if row.StartedAt.Valid && row.EndedAt.Valid {
+		minutes := roundTo2(row.EndedAt.Time.Sub(row.StartedAt.Time).Minutes())
+		workout.DurationMinutes = &minutes
+	}
+	return workout, nil
+}
+
+// Decided by what was logged rather than the date, the same way GetTrainingDaysForUser decides what counts towards
+// streaks. Outside a running session any set counts as logged, since sets entered after the fact (or imported, or left
+// unticked in a finished session) never get a completed_at.
+func workoutStatus(row sqlc.GetCalendarWorkoutsRow, today time.Time) string {
+	date := row.WorkoutDate.UTC()
+	switch {
+	case row.StartedAt.Valid && !row.EndedAt.Valid:
+		return workoutStatusInProgress
+	case row.SetCount > 0 && !date.After(today):
+		return workoutStatusCompleted
+	case date.Before(today):
+		return workoutStatusMissed
+	default:
+		return workoutStatusPlanned
+	}
+}
+
+// Every Monday-to-Sunday week that overlaps the month. Only weeks that are over count towards Percent, so a week in
+// progress can't drag it down.
+func weeklyAdherence(weekly map[time.Time]int, target *int32, monthStart, monthEnd, today time.Time) WeeklyAdherence {
+	adherence := WeeklyAdherence{Target: target, Weeks: []WeekAdherence{}}
+	finished, met := 0, 0
+	for week := training.WeekStart(monthStart); !week.After(monthEnd); week = week.AddDate(0, 0, 7) {
+		entry := WeekAdherence{WeekStart: week.Format("2006-01-02"), Sessions: weekly[week]}
+		if target != nil && !week.After(today) {
+			reached := weekly[week] >= int(*target)
+			entry.Met = &reached
+			if !week.AddDate(0, 0, 7).After(today) {
+				finished++
+				if reached {
+					met++
+				}
+			}
+		}
+		adherence.Weeks = append(adherence.Weeks, entry)
+	}
+
+	if finished > 0 {
+		percent := roundTo2(float64(met) / float64(finished) * 100)
+		adherence.Percent = &percent
+	}
+	return adherence
+}
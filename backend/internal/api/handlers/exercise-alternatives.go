@@ -0,0 +1,123 @@
+// Substitutes for an exercise when its station (or equipment) is taken
+package handlers
+
+import (
+	"database/sql"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"go-reppy/backend/internal/api/middleware"
+	"go-reppy/backend/internal/api/response"
+	"go-reppy/backend/internal/api/utils"
+	"go-reppy/backend/internal/database/sqlc"
+)
+
+const (
+	defaultExerciseAlternativesLimit = 10
+	maxExerciseAlternativesLimit     = 50
+)
+
+type ExerciseAlternative struct {
+	Exercise            sqlc.Exercise `json:"Exercise"`
+	SharedMuscles       int32         `json:"SharedMuscles"`
+	MuscleOverlap       float64       `json:"MuscleOverlap"` // 0-1, primary movers on both sides count the most
+	SameMovementPattern bool          `json:"SameMovementPattern"`
+	Score               float64       `json:"Score"` // 70% muscle overlap, 30% movement pattern
+}
+
+/*
+"/exercises/1/alternatives?equipment=dumbbell,cable,bodyweight&limit=5"
+optional params: equipment (comma-separated list of what's available; exercises needing anything else are left out),
+limit (default 10, max 50)
+*/
+func (h *ExerciseByIDHandler) HandleExerciseAlternatives(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		response.SendError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	exerciseID, ok := exerciseIDFromSubresourcePath(w, r)
+	if !ok {
+		return
+	}
+
+	query := r.URL.Query()
+	equipment := []string{}
+	if equipmentStr := query.Get("equipment"); equipmentStr != "" {
+		for _, item := range strings.Split(equipmentStr, ",") {
+			item = strings.TrimSpace(item)
+			if !slices.Contains(validEquipment, sqlc.EquipmentEnum(item)) {
+				response.SendError(w, fmt.Sprintf("Unknown equipment '%s' - must be one of 'barbell', 'dumbbell', 'cable', 'machine', 'band', 'bodyweight'", item), http.StatusBadRequest)
+				return
+			}
+			equipment = append(equipment, item)
+		}
+	}
+
+	limit := defaultExerciseAlternativesLimit
+	if limitStr := query.Get("limit"); limitStr != "" {
+		var err error
+		limit, err = strconv.Atoi(limitStr)
+		if err != nil || limit <= 0 || limit > maxExerciseAlternativesLimit {
+			response.SendError(w, "limit must be between 1 and 50", http.StatusBadRequest)
+			return
+		}
+	}
+
+	userID, err := middleware.GetUserIDFromContext(r.Context())
+	if err != nil {
+		response.SendError(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	_, err = h.queries.GetExerciseByIDForUser(r.Context(), sqlc.GetExerciseByIDForUserParams{
+		ExerciseID: exerciseID,
+		UserID:     utils.ToNullInt32(userID),
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			response.SendError(w, "Exercise not found", http.StatusNotFound)
+			return
+		}
+		response.SendError(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	rows, err := h.queries.GetExerciseAlternatives(r.Context(), sqlc.GetExerciseAlternativesParams{
+		ExerciseID: exerciseID,
+		UserID:     utils.ToNullInt32(userID),
+		Equipment:  equipment,
+		Limit:      int32(limit),
+	})
+	if err != nil {
+		response.SendError(w, "Failed to find exercise alternatives", http.StatusInternalServerError)
+		return
+	}
+
+	alternatives := make([]ExerciseAlternative, len(rows))
+	for i, row := range rows {
+		alternatives[i] = ExerciseAlternative{
+			Exercise: sqlc.Exercise{
+				ExerciseID:       row.ExerciseID,
+				ExerciseName:     row.ExerciseName,
+				Description:      row.Description,
+				CreatedAt:        row.CreatedAt,
+				TrackingMode:     row.TrackingMode,
+				OwnerUserID:      row.OwnerUserID,
+				Equipment:        row.Equipment,
+				MovementPattern:  row.MovementPattern,
+				Laterality:       row.Laterality,
+				ParentExerciseID: row.ParentExerciseID,
+			},
+			SharedMuscles:       row.SharedMuscles,
+			MuscleOverlap:       roundTo2(row.MuscleOverlap),
+			SameMovementPattern: row.SameMovementPattern,
+			Score:               roundTo2(row.Score),
+		}
+	}
+
+	response.SendSuccess(w, alternatives)
+}
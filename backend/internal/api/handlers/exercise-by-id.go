@@ -1,12 +1,16 @@
 package handlers
 
 import (
+	"database/sql"
 	"net/http"
 	"strconv"
 	"strings"
 
+	"go-reppy/backend/internal/api/middleware"
 	"go-reppy/backend/internal/api/response"
+	"go-reppy/backend/internal/api/utils"
 	"go-reppy/backend/internal/database/sqlc"
+	"go-reppy/backend/internal/training"
 )
 
 type ExerciseByIDHandler struct {
@@ -43,19 +47,44 @@ func (h *ExerciseByIDHandler) HandleExercisesByID(w http.ResponseWriter, r *http
 
 // "/exercises/"
 func (h *ExerciseByIDHandler) GetExerciseByID(w http.ResponseWriter, r *http.Request, id int32) {
-	exercise, err := h.queries.GetExerciseById(r.Context(), id)
+	userID, err := middleware.GetUserIDFromContext(r.Context())
+	if err != nil {
+		response.SendError(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	exercise, err := h.queries.GetExerciseByIDForUser(r.Context(), sqlc.GetExerciseByIDForUserParams{
+		ExerciseID: id,
+		UserID:     utils.ToNullInt32(userID),
+	})
 	if err != nil {
 		response.SendError(w, "Exercise not found", http.StatusNotFound)
 		return
 	}
 
-	response.SendSuccess(w, exercise)
+	muscles, err := h.exerciseMuscles(r, id)
+	if err != nil {
+		response.SendError(w, "Failed to retrieve exercise muscles", http.StatusInternalServerError)
+		return
+	}
+
+	response.SendSuccess(w, ExerciseWithMusclesResponse{Exercise: exercise, Muscles: muscles})
 }
 
-// "/exercises/"
+// "/exercises/" - users can delete their own custom exercises, only admins can delete from the global catalog. Refused
+// while anything logged still points at the exercise.
 func (h *ExerciseByIDHandler) DeleteExercise(w http.ResponseWriter, r *http.Request, id int32) {
+	if _, ok := h.editableExercise(w, r, id); !ok {
+		return
+	}
+
 	deletedExercise, err := h.queries.DeleteExercise(r.Context(), id)
 	if err != nil {
+		// logged sets, personal records & progression settings keep their exercise rather than vanishing with it
+		if strings.Contains(err.Error(), "foreign key constraint") {
+			response.SendError(w, "Exercise is in use by logged sets, records or progression settings - remove those first", http.StatusConflict)
+			return
+		}
 		response.SendError(w, "Failed to delete exercise", http.StatusInternalServerError)
 		return
 	}
@@ -65,3 +94,197 @@ func (h *ExerciseByIDHandler) DeleteExercise(w http.ResponseWriter, r *http.Requ
 		"exercise": deletedExercise,
 	}, http.StatusOK) // Not StatusNoContent bc this is a soft delete
 }
+
+// Single set's 1RM estimate; weights are in the request's units
+type OneRepMaxEstimate struct {
+	WorkoutID    int32    `json:"WorkoutID"`
+	WorkoutDate  string   `json:"WorkoutDate"`
+	SetNumber    int32    `json:"SetNumber"` // overall set number within the workout
+	Weight       float64  `json:"Weight"`
+	Reps         int32    `json:"Reps"`
+	RPE          *float64 `json:"RPE"`
+	Estimated1RM float64  `json:"Estimated1RM"`
+}
+
+type ExerciseOneRepMaxResponse struct {
+	ExerciseID   int32               `json:"ExerciseID"`
+	ExerciseName string              `json:"ExerciseName"`
+	Formula      training.Formula    `json:"Formula"`
+	MaxReps      int32               `json:"MaxReps"`
+	Unit         string              `json:"Unit"`
+	Best         *OneRepMaxEstimate  `json:"Best"`        // nil if no sets qualify
+	History      []OneRepMaxEstimate `json:"History"`     // best estimate per workout date, oldest first
+	SkippedSets  int                 `json:"SkippedSets"` // sets the formula couldn't handle (e.g. no RPE logged for the 'rpe' formula)
+}
+
+// Sets above this many reps are ignored unless "?max_reps=" says otherwise; the formulas get unreliable past ~10
+const defaultOneRepMaxRepCap = 10
+
+/*
+"/exercises/1/one-rep-max?formula=epley&max_reps=8&units=metric"
+optional params: formula ('epley', 'brzycki' (default), 'lombardi', 'wathan', 'rpe'), max_reps, include_warmups=true, units
+*/
+func (h *ExerciseByIDHandler) HandleOneRepMax(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		response.SendError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	pathParts := strings.Split(r.URL.Path, "/")
+	if len(pathParts) < 4 {
+		response.SendError(w, "Invalid path URL", http.StatusBadRequest)
+		return
+	}
+
+	exerciseID, err := strconv.ParseInt(pathParts[2], 10, 32)
+	if err != nil {
+		response.SendError(w, "Invalid exercise ID", http.StatusBadRequest)
+		return
+	}
+
+	query := r.URL.Query()
+	formula, err := training.ParseFormula(query.Get("formula"))
+	if err != nil {
+		response.SendError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	maxReps := int64(defaultOneRepMaxRepCap)
+	if maxRepsStr := query.Get("max_reps"); maxRepsStr != "" {
+		maxReps, err = strconv.ParseInt(maxRepsStr, 10, 32)
+		if err != nil || maxReps <= 0 {
+			response.SendError(w, "max_reps must be a positive integer", http.StatusBadRequest)
+			return
+		}
+	}
+
+	userID, err := middleware.GetUserIDFromContext(r.Context())
+	if err != nil {
+		response.SendError(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	units, ok := resolveRequestUnits(w, r, h.queries)
+	if !ok {
+		return
+	}
+
+	exercise, err := h.queries.GetExerciseByIDForUser(r.Context(), sqlc.GetExerciseByIDForUserParams{
+		ExerciseID: int32(exerciseID),
+		UserID:     utils.ToNullInt32(userID),
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			response.SendError(w, "Exercise not found", http.StatusNotFound)
+			return
+		}
+		response.SendError(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	sets, err := h.queries.GetOneRepMaxSetsForExercise(r.Context(), sqlc.GetOneRepMaxSetsForExerciseParams{
+		UserID:         utils.ToNullInt32(userID),
+		ExerciseID:     exercise.ExerciseID,
+		MaxReps:        int32(maxReps),
+		IncludeWarmups: query.Get("include_warmups") == "true",
+	})
+	if err != nil {
+		response.SendError(w, "Failed to retrieve sets", http.StatusInternalServerError)
+		return
+	}
+
+	result := ExerciseOneRepMaxResponse{
+		ExerciseID:   exercise.ExerciseID,
+		ExerciseName: exercise.ExerciseName,
+		Formula:      formula,
+		MaxReps:      int32(maxReps),
+		Unit:         utils.WeightUnit(units),
+		History:      []OneRepMaxEstimate{},
+	}
+
+	// sets come in date order, so the best estimate per date can be kept by replacing the last history entry
+	for _, set := range sets {
+		weightKg, err := strconv.ParseFloat(set.ResistanceValue.String, 64)
+		if err != nil {
+			result.SkippedSets++
+			continue
+		}
+
+		var rpe *float64
+		if set.Rpe.Valid {
+			if value, err := strconv.ParseFloat(set.Rpe.String, 64); err == nil {
+				rpe = &value
+			}
+		}
+
+		estimateKg, err := training.EstimateOneRepMax(formula, weightKg, set.Reps.Int32, rpe)
+		if err != nil {
+			result.SkippedSets++
+			continue
+		}
+
+		estimate := OneRepMaxEstimate{
+			WorkoutID:    set.WorkoutID,
+			WorkoutDate:  set.WorkoutDate.Format("2006-01-02"),
+			SetNumber:    set.OverallWorkoutSetNumber,
+			Weight:       utils.FromKgFloat(weightKg, units),
+			Reps:         set.Reps.Int32,
+			RPE:          rpe,
+			Estimated1RM: utils.FromKgFloat(estimateKg, units),
+		}
+
+		last := len(result.History) - 1
+		if last >= 0 && result.History[last].WorkoutDate == estimate.WorkoutDate {
+			if estimate.Estimated1RM > result.History[last].Estimated1RM {
+				result.History[last] = estimate
+			}
+		} else {
+			result.History = append(result.History, estimate)
+		}
+
+		if result.Best == nil || estimate.Estimated1RM > result.Best.Estimated1RM {
+			best := estimate
+			result.Best = &best
+		}
+	}
+
+	response.SendSuccess(w, result)
+}
+
+// Admins only - moves a user's custom exercise into the global catalog, where everyone can see it. Sets logged against it are kept.
+// "/exercises/{id}/promote"
+func (h *ExerciseByIDHandler) HandlePromoteExercise(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		response.SendError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	exerciseID, ok := exerciseIDFromSubresourcePath(w, r)
+	if !ok {
+		return
+	}
+
+	if !requireAdmin(w, r, h.queries) {
+		return
+	}
+
+	exercise, err := h.queries.PromoteExercise(r.Context(), exerciseID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			if _, err := h.queries.GetExerciseById(r.Context(), exerciseID); err == nil {
+				response.SendError(w, "Exercise is already in the global catalog", http.StatusConflict)
+				return
+			}
+			response.SendError(w, "Exercise not found", http.StatusNotFound)
+			return
+		}
+		if strings.Contains(err.Error(), "unique constraint") {
+			response.SendError(w, "An exercise with the same name is already in the global catalog", http.StatusConflict)
+			return
+		}
+		response.SendError(w, "Failed to promote exercise", http.StatusInternalServerError)
+		return
+	}
+
+	response.SendSuccess(w, exercise)
+}
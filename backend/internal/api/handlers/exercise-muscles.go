@@ -0,0 +1,338 @@
+// GET, POST, PATCH, DELETE - which muscles an exercise works, from either side of the mapping
+package handlers
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"go-reppy/backend/internal/api/middleware"
+	"go-reppy/backend/internal/api/response"
+	"go-reppy/backend/internal/api/utils"
+	"go-reppy/backend/internal/database/sqlc"
+)
+
+type ExerciseMuscleRequest struct {
+	MuscleID         int32  `json:"muscle_id"` // POST only; PATCH takes it from the path
+	InvolvementLevel string `json:"involvement_level"`
+}
+
+type ExerciseMuscleResponse struct {
+	MuscleID         int32                     `json:"MuscleID"`
+	MuscleName       string                    `json:"MuscleName"`
+	MuscleGroup      string                    `json:"MuscleGroup"`
+	InvolvementLevel sqlc.InvolvementLevelEnum `json:"InvolvementLevel"`
+}
+
+type MuscleExerciseResponse struct {
+	ExerciseID       int32                     `json:"ExerciseID"`
+	ExerciseName     string                    `json:"ExerciseName"`
+	InvolvementLevel sqlc.InvolvementLevelEnum `json:"InvolvementLevel"`
+}
+
+// GET "/exercises/{id}" - the exercise with the muscles it works
+type ExerciseWithMusclesResponse struct {
+	sqlc.Exercise
+	Muscles []ExerciseMuscleResponse `json:"Muscles"`
+}
+
+// "/exercises/{id}/muscles"
+func (h *ExerciseByIDHandler) HandleExerciseMuscles(w http.ResponseWriter, r *http.Request) {
+	exerciseID, ok := exerciseIDFromSubresourcePath(w, r)
+	if !ok {
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		h.GetExerciseMuscles(w, r, exerciseID)
+	case http.MethodPost:
+		h.CreateExerciseMuscle(w, r, exerciseID)
+	default:
+		response.SendError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+}
+
+// "/exercises/{id}/muscles/{muscle_id}"
+func (h *ExerciseByIDHandler) HandleExerciseMuscleByID(w http.ResponseWriter, r *http.Request) {
+	exerciseID, ok := exerciseIDFromSubresourcePath(w, r)
+	if !ok {
+		return
+	}
+
+	pathParts := strings.Split(r.URL.Path, "/")
+	if len(pathParts) < 5 {
+		response.SendError(w, "Invalid path URL", http.StatusBadRequest)
+		return
+	}
+
+	muscleID, err := strconv.ParseInt(pathParts[4], 10, 32)
+	if err != nil {
+		response.SendError(w, "Invalid muscle ID", http.StatusBadRequest)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodPatch:
+		h.UpdateExerciseMuscle(w, r, exerciseID, int32(muscleID))
+	case http.MethodDelete:
+		h.DeleteExerciseMuscle(w, r, exerciseID, int32(muscleID))
+	default:
+		response.SendError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+}
+
+// "/exercises/{id}/muscles"
+func (h *ExerciseByIDHandler) GetExerciseMuscles(w http.ResponseWriter, r *http.Request, exerciseID int32) {
+	userID, err := middleware.GetUserIDFromContext(r.Context())
+	if err != nil {
+		response.SendError(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	_, err = h.queries.GetExerciseByIDForUser(r.Context(), sqlc.GetExerciseByIDForUserParams{
+		ExerciseID: exerciseID,
+		UserID:     utils.ToNullInt32(userID),
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			response.SendError(w, "Exercise not found", http.StatusNotFound)
+			return
+		}
+		response.SendError(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	muscles, err := h.exerciseMuscles(r, exerciseID)
+	if err != nil {
+		response.SendError(w, "Failed to retrieve exercise muscles", http.StatusInternalServerError)
+		return
+	}
+
+	response.SendSuccess(w, muscles)
+}
+
+// "/exercises/{id}/muscles"
+func (h *ExerciseByIDHandler) CreateExerciseMuscle(w http.ResponseWriter, r *http.Request, exerciseID int32) {
+	var request ExerciseMuscleRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		response.SendError(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	involvement, err := parseInvolvementLevel(request.InvolvementLevel)
+	if err != nil {
+		response.SendError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if _, ok := h.editableExercise(w, r, exerciseID); !ok {
+		return
+	}
+
+	if _, err := h.queries.GetMuscleByID(r.Context(), request.MuscleID); err != nil {
+		if err == sql.ErrNoRows {
+			response.SendError(w, fmt.Sprintf("Muscle %d not found", request.MuscleID), http.StatusBadRequest)
+			return
+		}
+		response.SendError(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	mapping, err := h.queries.CreateExerciseMuscle(r.Context(), sqlc.CreateExerciseMuscleParams{
+		ExerciseID:       exerciseID,
+		MuscleID:         request.MuscleID,
+		InvolvementLevel: involvement,
+	})
+	if err != nil {
+		if strings.Contains(err.Error(), "unique constraint") {
+			response.SendError(w, "Muscle is already mapped to this exercise - use PATCH to change its involvement", http.StatusConflict)
+			return
+		}
+		response.SendError(w, "Failed to add exercise muscle", http.StatusInternalServerError)
+		return
+	}
+
+	response.SendSuccess(w, mapping, http.StatusCreated)
+}
+
+// "/exercises/{id}/muscles/{muscle_id}"
+func (h *ExerciseByIDHandler) UpdateExerciseMuscle(w http.ResponseWriter, r *http.Request, exerciseID, muscleID int32) {
+	var request ExerciseMuscleRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		response.SendError(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	involvement, err := parseInvolvementLevel(request.InvolvementLevel)
+	if err != nil {
+		response.SendError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if _, ok := h.editableExercise(w, r, exerciseID); !ok {
+		return
+	}
+
+	mapping, err := h.queries.UpdateExerciseMuscle(r.Context(), sqlc.UpdateExerciseMuscleParams{
+		ExerciseID:       exerciseID,
+		MuscleID:         muscleID,
+		InvolvementLevel: involvement,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			response.SendError(w, "Muscle is not mapped to this exercise", http.StatusNotFound)
+			return
+		}
+		response.SendError(w, "Failed to update exercise muscle", http.StatusInternalServerError)
+		return
+	}
+
+	response.SendSuccess(w, mapping)
+}
+
+// "/exercises/{id}/muscles/{muscle_id}"
+func (h *ExerciseByIDHandler) DeleteExerciseMuscle(w http.ResponseWriter, r *http.Request, exerciseID, muscleID int32) {
+	if _, ok := h.editableExercise(w, r, exerciseID); !ok {
+		return
+	}
+
+	exists, err := h.queries.ExerciseMuscleExists(r.Context(), sqlc.ExerciseMuscleExistsParams{
+		ExerciseID: exerciseID,
+		MuscleID:   muscleID,
+	})
+	if err != nil {
+		response.SendError(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+	if !exists {
+		response.SendError(w, "Muscle is not mapped to this exercise", http.StatusNotFound)
+		return
+	}
+
+	err = h.queries.DeleteExerciseMuscle(r.Context(), sqlc.DeleteExerciseMuscleParams{
+		ExerciseID: exerciseID,
+		MuscleID:   muscleID,
+	})
+	if err != nil {
+		response.SendError(w, "Failed to delete exercise muscle", http.StatusInternalServerError)
+		return
+	}
+
+	response.SendSuccess(w, nil, http.StatusNoContent)
+}
+
+// "/muscles/{id}/exercises" - primary movers first
+func (h *MuscleHandler) HandleMuscleExercises(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		response.SendError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	pathParts := strings.Split(r.URL.Path, "/")
+	if len(pathParts) < 4 {
+		response.SendError(w, "Invalid path URL", http.StatusBadRequest)
+		return
+	}
+
+	muscleID, err := strconv.ParseInt(pathParts[2], 10, 32)
+	if err != nil {
+		response.SendError(w, "Invalid muscle ID", http.StatusBadRequest)
+		return
+	}
+
+	userID, err := middleware.GetUserIDFromContext(r.Context())
+	if err != nil {
+		response.SendError(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	if _, err := h.queries.GetMuscleByID(r.Context(), int32(muscleID)); err != nil {
+		if err == sql.ErrNoRows {
+			response.SendError(w, "Muscle not found", http.StatusNotFound)
+			return
+		}
+		response.SendError(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	rows, err := h.queries.GetMuscleExercises(r.Context(), sqlc.GetMuscleExercisesParams{
+		MuscleID: int32(muscleID),
+		UserID:   utils.ToNullInt32(userID),
+	})
+	if err != nil {
+		response.SendError(w, "Failed to retrieve muscle exercises", http.StatusInternalServerError)
+		return
+	}
+
+	exercises := make([]MuscleExerciseResponse, len(rows))
+	for i, row := range rows {
+		exercises[i] = MuscleExerciseResponse{
+			ExerciseID:       row.ExerciseID,
+			ExerciseName:     row.ExerciseName,
+			InvolvementLevel: row.InvolvementLevel,
+		}
+	}
+
+	response.SendSuccess(w, exercises)
+}
+
+func (h *ExerciseByIDHandler) exerciseMuscles(r *http.Request, exerciseID int32) ([]ExerciseMuscleResponse, error) {
+	rows, err := h.queries.GetExerciseMuscles(r.Context(), exerciseID)
+	if err != nil {
+		return nil, err
+	}
+
+	muscles := make([]ExerciseMuscleResponse, len(rows))
+	for i, row := range rows {
+		muscles[i] = ExerciseMuscleResponse{
+			MuscleID:         row.MuscleID,
+			MuscleName:       row.MuscleName,
+			MuscleGroup:      row.MuscleGroup,
+			InvolvementLevel: row.InvolvementLevel,
+		}
+	}
+	return muscles, nil
+}
+
+// Looks up an exercise the caller is allowed to change: their own custom exercises, or anything in the global catalog for admins.
+// Sends the error response & returns false otherwise.
+func (h *ExerciseByIDHandler) editableExercise(w http.ResponseWriter, r *http.Request, exerciseID int32) (sqlc.Exercise, bool) {
+	userID, err := middleware.GetUserIDFromContext(r.Context())
+	if err != nil {
+		response.SendError(w, "Unauthorized", http.StatusUnauthorized)
+		return sqlc.Exercise{}, false
+	}
+
+	exercise, err := h.queries.GetExerciseByIDForUser(r.Context(), sqlc.GetExerciseByIDForUserParams{
+		ExerciseID: exerciseID,
+		UserID:     utils.ToNullInt32(userID),
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			response.SendError(w, "Exercise not found", http.StatusNotFound)
+			return sqlc.Exercise{}, false
+		}
+		response.SendError(w, "Internal server error", http.StatusInternalServerError)
+		return sqlc.Exercise{}, false
+	}
+
+	if !exercise.OwnerUserID.Valid && !requireAdmin(w, r, h.queries) {
+		return sqlc.Exercise{}, false
+	}
+	return exercise, true
+}
+
+func parseInvolvementLevel(level string) (sqlc.InvolvementLevelEnum, error) {
+	switch involvement := sqlc.InvolvementLevelEnum(level); involvement {
+	case sqlc.InvolvementLevelEnumPrimary, sqlc.InvolvementLevelEnumSecondary:
+		return involvement, nil
+	default:
+		return "", fmt.Errorf("involvement_level must be one of 'primary', 'secondary'")
+	}
+}
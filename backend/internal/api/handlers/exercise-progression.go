@@ -0,0 +1,381 @@
+// Next-session targets & the per-exercise settings that drive them
+package handlers
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"go-reppy/backend/internal/api/middleware"
+	"go-reppy/backend/internal/api/response"
+	"go-reppy/backend/internal/api/utils"
+	"go-reppy/backend/internal/database/sqlc"
+	"go-reppy/backend/internal/training"
+)
+
+// Sessions of history the strategies look at; more are loaded if deload_after_misses needs them
+const progressionHistorySessions = 6
+
+type ProgressionSettingsRequest struct {
+	Strategy          *string  `json:"strategy"` // 'double_progression', 'rpe'
+	MinReps           *int32   `json:"min_reps"`
+	MaxReps           *int32   `json:"max_reps"`
+	LoadIncrement     *float64 `json:"load_increment"` // in the request's units
+	TargetRPE         *float64 `json:"target_rpe"`
+	DeloadAfterMisses *int32   `json:"deload_after_misses"` // 0 turns deloads off
+	DeloadPercent     *float64 `json:"deload_percent"`
+}
+
+type ProgressionSettingsResponse struct {
+	ExerciseID        int32   `json:"ExerciseID"`
+	Strategy          string  `json:"Strategy"`
+	MinReps           int32   `json:"MinReps"`
+	MaxReps           int32   `json:"MaxReps"`
+	LoadIncrement     float64 `json:"LoadIncrement"`
+	TargetRPE         float64 `json:"TargetRPE"`
+	DeloadAfterMisses int32   `json:"DeloadAfterMisses"`
+	DeloadPercent     float64 `json:"DeloadPercent"`
+	Unit              string  `json:"Unit"`
+	IsDefault         bool    `json:"IsDefault"` // true until the user saves settings for this exercise
+}
+
+type NextTargetResponse struct {
+	ExerciseID   int32    `json:"ExerciseID"`
+	ExerciseName string   `json:"ExerciseName"`
+	Strategy     string   `json:"Strategy"`
+	Weight       float64  `json:"Weight"`
+	Reps         int32    `json:"Reps"`
+	Sets         int      `json:"Sets"`
+	TargetRPE    *float64 `json:"TargetRPE"` // rpe strategy only
+	Deload       bool     `json:"Deload"`
+	Unit         string   `json:"Unit"`
+	Explanation  string   `json:"Explanation"`
+}
+
+/*
+"/exercises/1/next-target?strategy=rpe&units=metric"
+optional params: strategy (overrides the exercise's saved strategy for this request), units
+*/
+func (h *ExerciseByIDHandler) HandleNextTarget(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		response.SendError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	exerciseID, ok := exerciseIDFromSubresourcePath(w, r)
+	if !ok {
+		return
+	}
+
+	userID, err := middleware.GetUserIDFromContext(r.Context())
+	if err != nil {
+		response.SendError(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	exercise, err := h.queries.GetExerciseByIDForUser(r.Context(), sqlc.GetExerciseByIDForUserParams{
+		ExerciseID: exerciseID,
+		UserID:     utils.ToNullInt32(userID),
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			response.SendError(w, "Exercise not found", http.StatusNotFound)
+			return
+		}
+		response.SendError(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	strategy, settings, _, err := h.loadProgressionSettings(r, int32(userID), exerciseID)
+	if err != nil {
+		response.SendError(w, "Failed to retrieve progression settings", http.StatusInternalServerError)
+		return
+	}
+	if override := r.URL.Query().Get("strategy"); override != "" {
+		strategy, err = training.ParseProgressionStrategy(override)
+		if err != nil {
+			response.SendError(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+
+	units, ok := resolveRequestUnits(w, r, h.queries)
+	if !ok {
+		return
+	}
+
+	rows, err := h.queries.GetRecentWorkingSetsForExercise(r.Context(), sqlc.GetRecentWorkingSetsForExerciseParams{
+		UserID:     utils.ToNullInt32(userID),
+		ExerciseID: exerciseID,
+		Sessions:   max(progressionHistorySessions, settings.DeloadAfterMisses),
+	})
+	if err != nil {
+		response.SendError(w, "Failed to retrieve workout history", http.StatusInternalServerError)
+		return
+	}
+
+	format := func(kg float64) string {
+		return fmt.Sprintf("%g %s", utils.FromKgFloat(kg, units), utils.WeightUnit(units))
+	}
+	target, err := training.NextTarget(strategy, progressionSessions(rows), settings, format)
+	if err != nil {
+		if err == training.ErrNoProgressionHistory {
+			response.SendError(w, "No working sets logged for this exercise yet", http.StatusNotFound)
+			return
+		}
+		response.SendError(w, "Failed to pick a next target", http.StatusInternalServerError)
+		return
+	}
+
+	response.SendSuccess(w, NextTargetResponse{
+		ExerciseID:   exercise.ExerciseID,
+		ExerciseName: exercise.ExerciseName,
+		Strategy:     target.Strategy,
+		Weight:       utils.FromKgFloat(target.WeightKg, units),
+		Reps:         target.Reps,
+		Sets:         target.Sets,
+		TargetRPE:    target.TargetRPE,
+		Deload:       target.Deload,
+		Unit:         utils.WeightUnit(units),
+		Explanation:  target.Explanation,
+	})
+}
+
+// "/exercises/1/progression" - GET the user's settings for an exercise (defaults if never saved), PUT to change them
+func (h *ExerciseByIDHandler) HandleProgressionSettings(w http.ResponseWriter, r *http.Request) {
+	exerciseID, ok := exerciseIDFromSubresourcePath(w, r)
+	if !ok {
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		h.GetProgressionSettings(w, r, exerciseID)
+	case http.MethodPut:
+		h.UpdateProgressionSettings(w, r, exerciseID)
+	default:
+		response.SendError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+}
+
+func (h *ExerciseByIDHandler) GetProgressionSettings(w http.ResponseWriter, r *http.Request, exerciseID int32) {
+	userID, err := middleware.GetUserIDFromContext(r.Context())
+	if err != nil {
+		response.SendError(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	units, ok := resolveRequestUnits(w, r, h.queries)
+	if !ok {
+		return
+	}
+
+	strategy, settings, isDefault, err := h.loadProgressionSettings(r, int32(userID), exerciseID)
+	if err != nil {
+		response.SendError(w, "Failed to retrieve progression settings", http.StatusInternalServerError)
+		return
+	}
+
+	response.SendSuccess(w, toProgressionSettingsResponse(exerciseID, strategy, settings, isDefault, units))
+}
+
+// Fields left out of the request keep their current (or default) values
+func (h *ExerciseByIDHandler) UpdateProgressionSettings(w http.ResponseWriter, r *http.Request, exerciseID int32) {
+	var request ProgressionSettingsRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		response.SendError(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	userID, err := middleware.GetUserIDFromContext(r.Context())
+	if err != nil {
+		response.SendError(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	_, err = h.queries.GetExerciseByIDForUser(r.Context(), sqlc.GetExerciseByIDForUserParams{
+		ExerciseID: exerciseID,
+		UserID:     utils.ToNullInt32(userID),
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			response.SendError(w, "Exercise not found", http.StatusNotFound)
+			return
+		}
+		response.SendError(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	units, ok := resolveRequestUnits(w, r, h.queries)
+	if !ok {
+		return
+	}
+
+	strategy, settings, _, err := h.loadProgressionSettings(r, int32(userID), exerciseID)
+	if err != nil {
+		response.SendError(w, "Failed to retrieve progression settings", http.StatusInternalServerError)
+		return
+	}
+
+	if request.Strategy != nil {
+		// unlike ?strategy=, an empty string isn't a valid saved strategy
+		if *request.Strategy == "" {
+			response.SendError(w, "strategy must be one of 'double_progression', 'rpe'", http.StatusBadRequest)
+			return
+		}
+		strategy, err = training.ParseProgressionStrategy(*request.Strategy)
+		if err != nil {
+			response.SendError(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+	if request.MinReps != nil {
+		settings.MinReps = *request.MinReps
+	}
+	if request.MaxReps != nil {
+		settings.MaxReps = *request.MaxReps
+	}
+	if request.LoadIncrement != nil {
+		kg, _ := strconv.ParseFloat(utils.ToKgFromFloatPtr(request.LoadIncrement, units).String, 64)
+		settings.LoadIncrementKg = kg
+	}
+	if request.TargetRPE != nil {
+		settings.TargetRPE = *request.TargetRPE
+	}
+	if request.DeloadAfterMisses != nil {
+		settings.DeloadAfterMisses = *request.DeloadAfterMisses
+	}
+	if request.DeloadPercent != nil {
+		settings.DeloadPercent = *request.DeloadPercent
+	}
+
+	if err := validateProgressionSettings(settings); err != nil {
+		response.SendError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	saved, err := h.queries.UpsertProgressionSettings(r.Context(), sqlc.UpsertProgressionSettingsParams{
+		UserID:            int32(userID),
+		ExerciseID:        exerciseID,
+		Strategy:          sqlc.ProgressionStrategyEnum(strategy),
+		MinReps:           settings.MinReps,
+		MaxReps:           settings.MaxReps,
+		LoadIncrementKg:   strconv.FormatFloat(settings.LoadIncrementKg, 'f', 3, 64),
+		TargetRpe:         strconv.FormatFloat(settings.TargetRPE, 'f', 1, 64),
+		DeloadAfterMisses: settings.DeloadAfterMisses,
+		DeloadPercent:     strconv.FormatFloat(settings.DeloadPercent, 'f', 1, 64),
+	})
+	if err != nil {
+		response.SendError(w, "Failed to save progression settings", http.StatusInternalServerError)
+		return
+	}
+
+	response.SendSuccess(w, toProgressionSettingsResponse(exerciseID, string(saved.Strategy), progressionSettingsFromRow(saved), false, units))
+}
+
+// Same rules as the exercise_progression_settings CHECKs, so users get a readable error instead of a constraint name
+func validateProgressionSettings(settings training.ProgressionSettings) error {
+	if settings.MinReps <= 0 || settings.MaxReps < settings.MinReps {
+		return fmt.Errorf("min_reps must be greater than 0 and max_reps must not be below min_reps")
+	}
+	if settings.LoadIncrementKg <= 0 {
+		return fmt.Errorf("load_increment must be greater than 0")
+	}
+	if settings.TargetRPE < 6.5 || settings.TargetRPE > 10 {
+		return fmt.Errorf("target_rpe must be between 6.5 and 10")
+	}
+	if settings.DeloadAfterMisses < 0 {
+		return fmt.Errorf("deload_after_misses must not be negative")
+	}
+	if settings.DeloadPercent <= 0 || settings.DeloadPercent >= 100 {
+		return fmt.Errorf("deload_percent must be between 0 and 100")
+	}
+	return nil
+}
+
+// The user's saved strategy & settings for an exercise, or the defaults (isDefault) if they never saved any
+func (h *ExerciseByIDHandler) loadProgressionSettings(r *http.Request, userID, exerciseID int32) (string, training.ProgressionSettings, bool, error) {
+	row, err := h.queries.GetProgressionSettings(r.Context(), sqlc.GetProgressionSettingsParams{
+		UserID:     userID,
+		ExerciseID: exerciseID,
+	})
+	if err == sql.ErrNoRows {
+		return training.DefaultProgressionStrategy, training.DefaultProgressionSettings, true, nil
+	}
+	if err != nil {
+		return "", training.ProgressionSettings{}, false, err
+	}
+	return string(row.Strategy), progressionSettingsFromRow(row), false, nil
+}
+
+func progressionSettingsFromRow(row sqlc.ExerciseProgressionSetting) training.ProgressionSettings {
+	loadIncrementKg, _ := strconv.ParseFloat(row.LoadIncrementKg, 64)
+	targetRPE, _ := strconv.ParseFloat(row.TargetRpe, 64)
+	deloadPercent, _ := strconv.ParseFloat(row.DeloadPercent, 64)
+	return training.ProgressionSettings{
+		MinReps:           row.MinReps,
+		MaxReps:           row.MaxReps,
+		LoadIncrementKg:   loadIncrementKg,
+		TargetRPE:         targetRPE,
+		DeloadAfterMisses: row.DeloadAfterMisses,
+		DeloadPercent:     deloadPercent,
+	}
+}
+
+func toProgressionSettingsResponse(exerciseID int32, strategy string, settings training.ProgressionSettings, isDefault bool, units sqlc.UnitSystemEnum) ProgressionSettingsResponse {
+	return ProgressionSettingsResponse{
+		ExerciseID:        exerciseID,
+		Strategy:          strategy,
+		MinReps:           settings.MinReps,
+		MaxReps:           settings.MaxReps,
+		LoadIncrement:     utils.FromKgFloat(settings.LoadIncrementKg, units),
+		TargetRPE:         settings.TargetRPE,
+		DeloadAfterMisses: settings.DeloadAfterMisses,
+		DeloadPercent:     settings.DeloadPercent,
+		Unit:              utils.WeightUnit(units),
+		IsDefault:         isDefault,
+	}
+}
+
+// Groups the newest-first set rows into sessions for the training package
+func progressionSessions(rows []sqlc.GetRecentWorkingSetsForExerciseRow) []training.Session {
+	var sessions []training.Session
+	for _, row := range rows {
+		if len(sessions) == 0 || sessions[len(sessions)-1].WorkoutID != row.WorkoutID {
+			sessions = append(sessions, training.Session{WorkoutID: row.WorkoutID, Date: row.WorkoutDate})
+		}
+		session := &sessions[len(sessions)-1]
+
+		set := training.SessionSet{Reps: row.Reps.Int32}
+		if row.ResistanceValue.Valid {
+			set.WeightKg, _ = strconv.ParseFloat(row.ResistanceValue.String, 64)
+		}
+		if row.Rpe.Valid {
+			if rpe, err := strconv.ParseFloat(row.Rpe.String, 64); err == nil {
+				set.RPE = &rpe
+			}
+		}
+		session.Sets = append(session.Sets, set)
+	}
+	return sessions
+}
+
+// Parses the ID out of "/exercises/{id}/<subresource>" paths
+func exerciseIDFromSubresourcePath(w http.ResponseWriter, r *http.Request) (int32, bool) {
+	pathParts := strings.Split(r.URL.Path, "/")
+	if len(pathParts) < 4 {
+		response.SendError(w, "Invalid path URL", http.StatusBadRequest)
+		return 0, false
+	}
+
+	exerciseID, err := strconv.ParseInt(pathParts[2], 10, 32)
+	if err != nil {
+		response.SendError(w, "Invalid exercise ID", http.StatusBadRequest)
+		return 0, false
+	}
+	return int32(exerciseID), true
+}
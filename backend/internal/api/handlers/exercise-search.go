@@ -0,0 +1,146 @@
+package handlers
+
+import (
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"go-reppy/backend/internal/api/middleware"
+	"go-reppy/backend/internal/api/response"
+	"go-reppy/backend/internal/api/utils"
+	"go-reppy/backend/internal/database/sqlc"
+)
+
+const (
+	defaultExerciseSearchLimit = 20
+	maxExerciseSearchLimit     = 50
+)
+
+// A [Start, End) span of characters (not bytes) in MatchedText
+type TextRange struct {
+	Start int `json:"Start"`
+	End   int `json:"End"`
+}
+
+type ExerciseSearchResult struct {
+	Exercise       sqlc.Exercise `json:"Exercise"`
+	MatchedField   string        `json:"MatchedField"` // 'exercise_name', 'alias' or 'description'
+	MatchedText    string        `json:"MatchedText"`
+	Highlights     []TextRange   `json:"Highlights"`     // where the query's words appear in MatchedText; empty for typo-level matches
+	RecentSetCount int32         `json:"RecentSetCount"` // sets logged in the last 90 days, which boosts the ranking
+	Score          float64       `json:"Score"`
+}
+
+/*
+"/exercises/search?q=rdl&limit=10"
+optional params: limit (default 20, max 50)
+*/
+func (h *ExerciseHandler) HandleSearchExercises(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		response.SendError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	query := r.URL.Query()
+	q := strings.TrimSpace(query.Get("q"))
+	if q == "" {
+		response.SendError(w, "q must be provided", http.StatusBadRequest)
+		return
+	}
+
+	limit := defaultExerciseSearchLimit
+	if limitStr := query.Get("limit"); limitStr != "" {
+		var err error
+		limit, err = strconv.Atoi(limitStr)
+		if err != nil || limit <= 0 || limit > maxExerciseSearchLimit {
+			response.SendError(w, "limit must be between 1 and 50", http.StatusBadRequest)
+			return
+		}
+	}
+
+	userID, err := middleware.GetUserIDFromContext(r.Context())
+	if err != nil {
+		response.SendError(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	rows, err := h.queries.SearchExercises(r.Context(), sqlc.SearchExercisesParams{
+		Query:  q,
+		UserID: utils.ToNullInt32(userID),
+		Limit:  int32(limit),
+	})
+	if err != nil {
+		response.SendError(w, "Failed to search exercises", http.StatusInternalServerError)
+		return
+	}
+
+	results := make([]ExerciseSearchResult, len(rows))
+	for i, row := range rows {
+		results[i] = ExerciseSearchResult{
+			Exercise: sqlc.Exercise{
+				ExerciseID:       row.ExerciseID,
+				ExerciseName:     row.ExerciseName,
+				Description:      row.Description,
+				CreatedAt:        row.CreatedAt,
+				TrackingMode:     row.TrackingMode,
+				OwnerUserID:      row.OwnerUserID,
+				Equipment:        row.Equipment,
+				MovementPattern:  row.MovementPattern,
+				Laterality:       row.Laterality,
+				ParentExerciseID: row.ParentExerciseID,
+			},
+			MatchedField:   row.MatchedField,
+			MatchedText:    row.MatchedText,
+			Highlights:     highlightMatches(row.MatchedText, q),
+			RecentSetCount: row.RecentSetCount,
+			Score:          roundTo2(row.Rank),
+		}
+	}
+
+	response.SendSuccess(w, results)
+}
+
+// Finds each word of the query in text, ignoring case, spaces & punctuation the same way the search does, so "pullup"
+// highlights all of "Pull-up". Overlapping spans are merged.
+func highlightMatches(text, query string) []TextRange {
+	// the text's letters & digits, lowercased, with each one's character position in the original
+	var normalized []rune
+	var positions []int
+	for i, r := range []rune(text) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			normalized = append(normalized, unicode.ToLower(r))
+			positions = append(positions, i)
+		}
+	}
+	haystack := string(normalized)
+
+	var ranges []TextRange
+	words := strings.FieldsFunc(strings.ToLower(query), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	for _, word := range words {
+		wordLength := len([]rune(word))
+		for offset := 0; ; {
+			index := strings.Index(haystack[offset:], word)
+			if index < 0 {
+				break
+			}
+			start := len([]rune(haystack[:offset+index]))
+			ranges = append(ranges, TextRange{Start: positions[start], End: positions[start+wordLength-1] + 1})
+			offset += index + len(word)
+		}
+	}
+
+	sort.Slice(ranges, func(i, j int) bool { return ranges[i].Start < ranges[j].Start })
+	merged := []TextRange{}
+	for _, r := range ranges {
+		if last := len(merged) - 1; last >= 0 && r.Start <= merged[last].End {
+			merged[last].End = max(merged[last].End, r.End)
+			continue
+		}
+		merged = append(merged, r)
+	}
+	return merged
+}
@@ -1,9 +1,15 @@
 package handlers
 
 import (
+	"database/sql"
 	"encoding/json"
+	"fmt"
 	"net/http"
+	"slices"
+	"strconv"
+	"strings"
 
+	"go-reppy/backend/internal/api/middleware"
 	"go-reppy/backend/internal/api/response"
 	"go-reppy/backend/internal/api/utils"
 	"go-reppy/backend/internal/database/sqlc"
@@ -14,8 +20,35 @@ type ExerciseHandler struct {
 }
 
 type CreateExerciseRequest struct {
-	ExerciseName string `json:"exercise_name"`
-	Description  string `json:"description"`
+	ExerciseName     string  `json:"exercise_name"`
+	Description      string  `json:"description"`
+	TrackingMode     *string `json:"tracking_mode"`      // 'reps_load' (default), 'reps_only', 'time', 'distance', 'time_distance'
+	Equipment        *string `json:"equipment"`          // 'barbell', 'dumbbell', 'cable', 'machine', 'band', 'bodyweight'
+	MovementPattern  *string `json:"movement_pattern"`   // 'hinge', 'squat', 'push', 'pull', 'carry'
+	Laterality       *string `json:"laterality"`         // 'bilateral', 'unilateral'
+	ParentExerciseID *int32  `json:"parent_exercise_id"` // makes this a variation of another exercise
+	Global           bool    `json:"global"`             // admins only - adds straight to the global catalog instead of creating a custom exercise
+}
+
+// Optional, validated taxonomy attributes; NULL where not given
+type exerciseTaxonomy struct {
+	Equipment       sqlc.NullEquipmentEnum
+	MovementPattern sqlc.NullMovementPatternEnum
+	Laterality      sqlc.NullLateralityEnum
+}
+
+var (
+	validEquipment        = []sqlc.EquipmentEnum{sqlc.EquipmentEnumBarbell, sqlc.EquipmentEnumDumbbell, sqlc.EquipmentEnumCable, sqlc.EquipmentEnumMachine, sqlc.EquipmentEnumBand, sqlc.EquipmentEnumBodyweight}
+	validMovementPatterns = []sqlc.MovementPatternEnum{sqlc.MovementPatternEnumHinge, sqlc.MovementPatternEnumSquat, sqlc.MovementPatternEnumPush, sqlc.MovementPatternEnumPull, sqlc.MovementPatternEnumCarry}
+	validLateralities     = []sqlc.LateralityEnum{sqlc.LateralityEnumBilateral, sqlc.LateralityEnumUnilateral}
+)
+
+// Estimated 1RMs from the exercise_one_rm view (warm-ups excluded), in the request's units
+type OneRepMaxResponse struct {
+	ExerciseID   int32          `json:"ExerciseID"` // a custom exercise can share its name with one in the catalog
+	ExerciseName string         `json:"ExerciseName"`
+	Estimated1RM sql.NullString `json:"Estimated1RM"`
+	Unit         string         `json:"Unit"`
 }
 
 func NewExerciseHandler(q *sqlc.Queries) *ExerciseHandler {
@@ -39,7 +72,7 @@ func (h *ExerciseHandler) HandleExercises(w http.ResponseWriter, r *http.Request
 	}
 }
 
-// "/exercises"
+// "/exercises" - creates a custom exercise owned by the caller unless an admin asks for a global one
 func (h *ExerciseHandler) CreateExercise(w http.ResponseWriter, r *http.Request) {
 	var request CreateExerciseRequest
 	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
@@ -47,11 +80,89 @@ func (h *ExerciseHandler) CreateExercise(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	request.ExerciseName = strings.TrimSpace(request.ExerciseName)
+	if request.ExerciseName == "" {
+		response.SendError(w, "Exercise name must be provided", http.StatusBadRequest)
+		return
+	}
+
+	userID, err := middleware.GetUserIDFromContext(r.Context())
+	if err != nil {
+		response.SendError(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	trackingMode := sqlc.ExerciseTrackingModeEnumRepsLoad
+	if request.TrackingMode != nil {
+		trackingMode = sqlc.ExerciseTrackingModeEnum(*request.TrackingMode)
+		if _, ok := fieldsByTrackingMode[trackingMode]; !ok {
+			response.SendError(w, "tracking_mode must be one of 'reps_load', 'reps_only', 'time', 'distance', 'time_distance'", http.StatusBadRequest)
+			return
+		}
+	}
+
+	taxonomy, err := parseExerciseTaxonomy(derefString(request.Equipment), derefString(request.MovementPattern), derefString(request.Laterality))
+	if err != nil {
+		response.SendError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	owner := utils.ToNullInt32(userID)
+	if request.Global {
+		if !requireAdmin(w, r, h.queries) {
+			return
+		}
+		owner = sql.NullInt32{}
+	} else {
+		// a custom exercise can't shadow one in the catalog, or the merged list would show the same name twice
+		existing, err := h.queries.GetExerciseByName(r.Context(), sqlc.GetExerciseByNameParams{
+			ExerciseName: request.ExerciseName,
+			UserID:       owner,
+		})
+		if err == nil && !existing.OwnerUserID.Valid {
+			response.SendError(w, fmt.Sprintf("'%s' is already in the exercise catalog", request.ExerciseName), http.StatusConflict)
+			return
+		} else if err != nil && err != sql.ErrNoRows {
+			response.SendError(w, "Internal server error", http.StatusInternalServerError)
+			return
+		}
+	}
+
+	// variations have to hang off an exercise the new one's audience can see - a global exercise only off another global one
+	if request.ParentExerciseID != nil {
+		parent, err := h.queries.GetExerciseByIDForUser(r.Context(), sqlc.GetExerciseByIDForUserParams{
+			ExerciseID: *request.ParentExerciseID,
+			UserID:     owner,
+		})
+		if err != nil {
+			if err == sql.ErrNoRows {
+				response.SendError(w, fmt.Sprintf("Parent exercise %d not found", *request.ParentExerciseID), http.StatusBadRequest)
+				return
+			}
+			response.SendError(w, "Internal server error", http.StatusInternalServerError)
+			return
+		}
+		if request.Global && parent.OwnerUserID.Valid {
+			response.SendError(w, "A global exercise can't be a variation of a custom exercise", http.StatusBadRequest)
+			return
+		}
+	}
+
 	exercise, err := h.queries.CreateExercise(r.Context(), sqlc.CreateExerciseParams{
-		ExerciseName: request.ExerciseName,
-		Description:  utils.ToNullString(request.Description),
+		ExerciseName:     request.ExerciseName,
+		Description:      utils.ToNullString(request.Description),
+		TrackingMode:     trackingMode,
+		OwnerUserID:      owner,
+		Equipment:        taxonomy.Equipment,
+		MovementPattern:  taxonomy.MovementPattern,
+		Laterality:       taxonomy.Laterality,
+		ParentExerciseID: utils.ToNullInt32FromIntPtr(request.ParentExerciseID),
 	})
 	if err != nil {
+		if strings.Contains(err.Error(), "unique constraint") {
+			response.SendError(w, fmt.Sprintf("An exercise named '%s' already exists", request.ExerciseName), http.StatusConflict)
+			return
+		}
 		response.SendError(w, "Failed to create exercise", http.StatusInternalServerError)
 		return
 	}
@@ -63,21 +174,165 @@ func (h *ExerciseHandler) CreateExercise(w http.ResponseWriter, r *http.Request)
 func (h *ExerciseHandler) GetExerciseByName(w http.ResponseWriter, r *http.Request) {
 	exerciseName := r.URL.Query().Get("name")
 
-	exercise, err := h.queries.GetExerciseByName(r.Context(), exerciseName)
+	userID, err := middleware.GetUserIDFromContext(r.Context())
+	if err != nil {
+		response.SendError(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	exercise, err := h.queries.GetExerciseByName(r.Context(), sqlc.GetExerciseByNameParams{
+		ExerciseName: exerciseName,
+		UserID:       utils.ToNullInt32(userID),
+	})
 	if err != nil {
-		response.SendError(w, "Exercise not found", http.StatusInternalServerError)
+		if err == sql.ErrNoRows {
+			response.SendError(w, "Exercise not found", http.StatusNotFound)
+			return
+		}
+		response.SendError(w, "Internal server error", http.StatusInternalServerError)
+		return
 	}
 
 	response.SendSuccess(w, exercise)
 }
 
-// "/exercises"
+/*
+"/exercises?scope=mine&equipment=dumbbell&movement_pattern=push&laterality=unilateral&parent_id=1"
+optional params: scope ('all' (default) - the global catalog plus your custom exercises, 'global', 'mine'), equipment,
+movement_pattern, laterality, parent_id (variations of that exercise)
+*/
 func (h *ExerciseHandler) GetAllExercises(w http.ResponseWriter, r *http.Request) {
-	exercises, err := h.queries.GetAllExercises(r.Context())
+	query := r.URL.Query()
+	scope := query.Get("scope")
+	if scope != "" && scope != "all" && scope != "global" && scope != "mine" {
+		response.SendError(w, "scope must be one of 'all', 'global', 'mine'", http.StatusBadRequest)
+		return
+	}
+
+	taxonomy, err := parseExerciseTaxonomy(query.Get("equipment"), query.Get("movement_pattern"), query.Get("laterality"))
+	if err != nil {
+		response.SendError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var parentID sql.NullInt32
+	if parentStr := query.Get("parent_id"); parentStr != "" {
+		id, err := strconv.ParseInt(parentStr, 10, 32)
+		if err != nil {
+			response.SendError(w, "Invalid parent_id", http.StatusBadRequest)
+			return
+		}
+		parentID = utils.ToNullInt32(id)
+	}
+
+	userID, err := middleware.GetUserIDFromContext(r.Context())
+	if err != nil {
+		response.SendError(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	exercises, err := h.queries.GetExercisesForUser(r.Context(), sqlc.GetExercisesForUserParams{
+		UserID:           utils.ToNullInt32(userID),
+		Equipment:        taxonomy.Equipment,
+		MovementPattern:  taxonomy.MovementPattern,
+		Laterality:       taxonomy.Laterality,
+		ParentExerciseID: parentID,
+	})
 	if err != nil {
 		response.SendError(w, "Failed to retrieve all exercises", http.StatusInternalServerError)
 		return
 	}
 
+	if scope == "global" || scope == "mine" {
+		filtered := make([]sqlc.Exercise, 0, len(exercises))
+		for _, exercise := range exercises {
+			if exercise.OwnerUserID.Valid == (scope == "mine") {
+				filtered = append(filtered, exercise)
+			}
+		}
+		exercises = filtered
+	}
+
 	response.SendSuccess(w, exercises)
 }
+
+// "/exercises/one-rep-maxes"
+func (h *ExerciseHandler) HandleOneRepMaxes(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		response.SendError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	userID, err := middleware.GetUserIDFromContext(r.Context())
+	if err != nil {
+		response.SendError(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	units, ok := resolveRequestUnits(w, r, h.queries)
+	if !ok {
+		return
+	}
+
+	rows, err := h.queries.GetEstimatedOneRepMaxesForUser(r.Context(), utils.ToNullInt32(userID))
+	if err != nil {
+		response.SendError(w, "Failed to retrieve one-rep maxes", http.StatusInternalServerError)
+		return
+	}
+
+	oneRepMaxes := make([]OneRepMaxResponse, len(rows))
+	for i, row := range rows {
+		oneRepMaxes[i] = OneRepMaxResponse{
+			ExerciseID:   row.ExerciseID,
+			ExerciseName: row.ExerciseName,
+			Estimated1RM: utils.FromKg(utils.ToNullString(row.Estimated1rmKg), units),
+			Unit:         utils.WeightUnit(units),
+		}
+	}
+
+	response.SendSuccess(w, oneRepMaxes)
+}
+
+// Empty strings are left NULL; anything else has to be one of the enum's values
+func parseExerciseTaxonomy(equipment, movementPattern, laterality string) (exerciseTaxonomy, error) {
+	var taxonomy exerciseTaxonomy
+	if equipment != "" {
+		if !slices.Contains(validEquipment, sqlc.EquipmentEnum(equipment)) {
+			return taxonomy, fmt.Errorf("equipment must be one of 'barbell', 'dumbbell', 'cable', 'machine', 'band', 'bodyweight'")
+		}
+		taxonomy.Equipment = sqlc.NullEquipmentEnum{EquipmentEnum: sqlc.EquipmentEnum(equipment), Valid: true}
+	}
+	if movementPattern != "" {
+		if !slices.Contains(validMovementPatterns, sqlc.MovementPatternEnum(movementPattern)) {
+			return taxonomy, fmt.Errorf("movement_pattern must be one of 'hinge', 'squat', 'push', 'pull', 'carry'")
+		}
+		taxonomy.MovementPattern = sqlc.NullMovementPatternEnum{MovementPatternEnum: sqlc.MovementPatternEnum(movementPattern), Valid: true}
+	}
+	if laterality != "" {
+		if !slices.Contains(validLateralities, sqlc.LateralityEnum(laterality)) {
+			return taxonomy, fmt.Errorf("laterality must be one of 'bilateral', 'unilateral'")
+		}
+		taxonomy.Laterality = sqlc.NullLateralityEnum{LateralityEnum: sqlc.LateralityEnum(laterality), Valid: true}
+	}
+	return taxonomy, nil
+}
+
+// Sends a 403 & returns false unless the caller is an admin
+func requireAdmin(w http.ResponseWriter, r *http.Request, queries *sqlc.Queries) bool {
+	userID, err := middleware.GetUserIDFromContext(r.Context())
+	if err != nil {
+		response.SendError(w, "Unauthorized", http.StatusUnauthorized)
+		return false
+	}
+
+	isAdmin, err := queries.GetUserIsAdmin(r.Context(), int32(userID))
+	if err != nil && err != sql.ErrNoRows {
+		response.SendError(w, "Internal server error", http.StatusInternalServerError)
+		return false
+	}
+	if !isAdmin {
+		response.SendError(w, "Admin access required", http.StatusForbidden)
+		return false
+	}
+	return true
+}
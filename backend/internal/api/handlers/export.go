@@ -0,0 +1,410 @@
+// GET only - the user's full training history as a CSV, JSON or NDJSON download, streamed a page at a time
+package handlers
+
+import (
+	"database/sql"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"go-reppy/backend/internal/api/middleware"
+	"go-reppy/backend/internal/api/response"
+	"go-reppy/backend/internal/api/utils"
+	"go-reppy/backend/internal/database/sqlc"
+)
+
+type ExportHandler struct {
+	queries *sqlc.Queries
+}
+
+func NewExportHandler(q *sqlc.Queries) *ExportHandler {
+	return &ExportHandler{
+		queries: q,
+	}
+}
+
+const exportPageSize = 500
+
+var validExportFormats = []string{"csv", "json", "ndjson"}
+
+var exportContentTypes = map[string]string{
+	"csv":    "text/csv; charset=utf-8",
+	"json":   "application/json",
+	"ndjson": "application/x-ndjson",
+}
+
+/*
+CSV columns, in this order. The set is stable - columns are only ever appended, never renamed or reordered - & the JSON
+formats use the same names as keys. Empty cells (null in JSON) mean "not logged".
+
+	workout_id           the workout
+	workout_date         YYYY-MM-DD
+	workout_title
+	started_at           RFC 3339, UTC; only for workouts run as a live session
+	ended_at             RFC 3339, UTC
+	overall_set_number   the set's position in the workout; empty (with every column after exercise_name) for workouts without sets
+	set_number           the set's position within its exercise
+	exercise_id
+	exercise_name
+	primary_muscles      ';'-separated (a list in JSON)
+	secondary_muscles    ';'-separated (a list in JSON)
+	set_type             'warmup', 'working', 'drop', 'amrap', 'failure', 'rest_pause'
+	parent_set_number    the overall_set_number a drop set follows on from
+	group_id             the superset/circuit the set belongs to
+	reps
+	resistance_value     in weight_unit
+	weight_unit          'kg' or 'lbs', per unit preference or "?units="
+	resistance_type      'weight', 'band' or 'bodyweight'
+	resistance_detail
+	rpe
+	percent_1rm
+	duration_seconds
+	distance_meters      always meters, whatever unit the distance was entered in
+	distance_unit        the unit the distance was entered in
+	calories
+	pace_seconds_per_km
+	notes
+	created_at           RFC 3339, UTC
+	completed_at         RFC 3339, UTC; when the set was ticked off in a live session
+*/
+var exportColumns = []string{
+	"workout_id", "workout_date", "workout_title", "started_at", "ended_at",
+	"overall_set_number", "set_number", "exercise_id", "exercise_name", "primary_muscles", "secondary_muscles",
+	"set_type", "parent_set_number", "group_id", "reps", "resistance_value", "weight_unit", "resistance_type",
+	"resistance_detail", "rpe", "percent_1rm", "duration_seconds", "distance_meters", "distance_unit", "calories",
+	"pace_seconds_per_km", "notes", "created_at", "completed_at",
+}
+
+// One exported set (or set-less workout); field order & names match exportColumns
+type ExportRow struct {
+	WorkoutID        int32    `json:"workout_id"`
+	WorkoutDate      string   `json:"workout_date"`
+	WorkoutTitle     *string  `json:"workout_title"`
+	StartedAt        *string  `json:"started_at"`
+	EndedAt          *string  `json:"ended_at"`
+	OverallSetNumber *int32   `json:"overall_set_number"`
+	SetNumber        *int32   `json:"set_number"`
+	ExerciseID       *int32   `json:"exercise_id"`
+	ExerciseName     *string  `json:"exercise_name"`
+	PrimaryMuscles   []string `json:"primary_muscles"`
+	SecondaryMuscles []string `json:"secondary_muscles"`
+	SetType          *string  `json:"set_type"`
+	ParentSetNumber  *int32   `json:"parent_set_number"`
+	GroupID          *int32   `json:"group_id"`
+	Reps             *int32   `json:"reps"`
+	ResistanceValue  *string  `json:"resistance_value"`
+	WeightUnit       string   `json:"weight_unit"`
+	ResistanceType   *string  `json:"resistance_type"`
+	ResistanceDetail *string  `json:"resistance_detail"`
+	RPE              *string  `json:"rpe"`
+	Percent1RM       *string  `json:"percent_1rm"`
+	DurationSeconds  *int32   `json:"duration_seconds"`
+	DistanceMeters   *string  `json:"distance_meters"`
+	DistanceUnit     *string  `json:"distance_unit"`
+	Calories         *int32   `json:"calories"`
+	PaceSecondsPerKm *string  `json:"pace_seconds_per_km"`
+	Notes            *string  `json:"notes"`
+	CreatedAt        *string  `json:"created_at"`
+	CompletedAt      *string  `json:"completed_at"`
+}
+
+/*
+"/me/export?format=csv&from=2023-01-01&to=2023-12-31&units=metric"
+optional params: format ('csv' (default), 'json', 'ndjson'), from, to (workout dates, all time by default), units
+Sent as an attachment & written page by page, so it's routed without the request timeout. If the database fails
+part-way through, the download just stops (a JSON export is left without its closing bracket).
+*/
+func (h *ExportHandler) HandleExport(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		response.SendError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = "csv"
+	}
+	if !slices.Contains(validExportFormats, format) {
+		response.SendError(w, "Invalid format. Must be one of: "+strings.Join(validExportFormats, ", "), http.StatusBadRequest)
+		return
+	}
+
+	from, to, err := utils.ParseOptionalDateRange(r)
+	if err != nil {
+		response.SendError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	userID, err := middleware.GetUserIDFromContext(r.Context())
+	if err != nil {
+		response.SendError(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	units, ok := resolveRequestUnits(w, r, h.queries)
+	if !ok {
+		return
+	}
+
+	params := sqlc.GetExportPageParams{
+		UserID:    utils.ToNullInt32(userID),
+		ToDate:    to,
+		AfterDate: from,
+		PageSize:  exportPageSize,
+	}
+	// the first page is fetched up front so a failure can still be sent as a normal error response
+	page, err := h.queries.GetExportPage(r.Context(), params)
+	if err != nil {
+		response.SendError(w, "Failed to retrieve training history", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", exportContentTypes[format])
+	w.Header().Set("Content-Disposition", `attachment; filename="reppy-export-`+time.Now().UTC().Format("2006-01-02")+"."+format+`"`)
+	writer := newExportWriter(format, w)
+	flusher, _ := w.(http.Flusher)
+
+	if err := writer.begin(); err != nil {
+		return
+	}
+	for {
+		for _, row := range page {
+			if err := writer.write(toExportRow(row, units)); err != nil {
+				return // the client went away
+			}
+		}
+		if err := writer.flush(); err != nil {
+			return
+		}
+		if flusher != nil {
+			flusher.Flush()
+		}
+		if len(page) < exportPageSize {
+			break
+		}
+
+		last := page[len(page)-1]
+		params.AfterDate = last.WorkoutDate
+		params.AfterWorkoutID = last.WorkoutID
+		params.AfterSetNumber = last.OverallWorkoutSetNumber.Int32 // 0 for a workout without sets
+		page, err = h.queries.GetExportPage(r.Context(), params)
+		if err != nil {
+			log.Printf("Export for user %d stopped part-way: %v", userID, err)
+			return
+		}
+	}
+	writer.end()
+}
+
+// Writes rows in one of the export formats. begin & end bracket the rows (the JSON array), flush pushes out anything
+// buffered so it can be sent to the client.
+type exportWriter interface {
+	begin() error
+	write(row ExportRow) error
+	flush() error
+	end() error
+}
+
+func newExportWriter(format string, w io.Writer) exportWriter {
+	switch format {
+	case "json":
+		return &jsonExportWriter{w: w, encoder: json.NewEncoder(w)}
+	case "ndjson":
+		return &ndjsonExportWriter{encoder: json.NewEncoder(w)}
+	default:
+		return &csvExportWriter{writer: csv.NewWriter(w)}
+	}
+}
+
+type csvExportWriter struct {
+	writer *csv.Writer
+}
+
+func (c *csvExportWriter) begin() error {
+	return c.writer.Write(exportColumns)
+}
+
+func (c *csvExportWriter) write(row ExportRow) error {
+	return c.writer.Write(row.csvRecord())
+}
+
+func (c *csvExportWriter) flush() error {
+	c.writer.Flush()
+	return c.writer.Error()
+}
+
+func (c *csvExportWriter) end() error {
+	return c.flush()
+}
+
+// One JSON array, written an element at a time
+type jsonExportWriter struct {
+	w       io.Writer
+	encoder *json.Encoder
+	started bool
+}
+
+func (j *jsonExportWriter) begin() error {
+	_, err := io.WriteString(j.w, "[\n")
+	return err
+}
+
+func (j *jsonExportWriter) write(row ExportRow) error {
+	if j.started {
+		if _, err := io.WriteString(j.w, ","); err != nil {
+			return err
+		}
+	}
+	j.started = true
+	return j.encoder.Encode(row) // Encode ends each element with a newline
+}
+
+func (j *jsonExportWriter) flush() error { return nil }
+
+func (j *jsonExportWriter) end() error {
+	_, err := io.WriteString(j.w, "]\n")
+	return err
+}
+
+// One JSON object per line
+type ndjsonExportWriter struct {
+	encoder *json.Encoder
+}
+
+func (n *ndjsonExportWriter) begin() error { return nil }
+
+func (n *ndjsonExportWriter) write(row ExportRow) error {
+	return n.encoder.Encode(row)
+}
+
+func (n *ndjsonExportWriter) flush() error { return nil }
+
+func (n *ndjsonExportWriter) end() error { return nil }
+
+func toExportRow(row sqlc.GetExportPageRow, units sqlc.UnitSystemEnum) ExportRow {
+	export := ExportRow{
+		WorkoutID:        row.WorkoutID,
+		WorkoutDate:      row.WorkoutDate.UTC().Format("2006-01-02"),
+		WorkoutTitle:     exportString(row.WorkoutTitle),
+		StartedAt:        exportTime(row.StartedAt),
+		EndedAt:          exportTime(row.EndedAt),
+		OverallSetNumber: exportInt32(row.OverallWorkoutSetNumber),
+		SetNumber:        exportInt32(row.SetNumber),
+		ExerciseID:       exportInt32(row.ExerciseID),
+		ExerciseName:     exportString(row.ExerciseName),
+		PrimaryMuscles:   exportList(row.PrimaryMuscles),
+		SecondaryMuscles: exportList(row.SecondaryMuscles),
+		ParentSetNumber:  exportInt32(row.ParentSetNumber),
+		GroupID:          exportInt32(row.GroupID),
+		Reps:             exportInt32(row.Reps),
+		ResistanceValue:  exportString(utils.FromKg(row.ResistanceValue, units)),
+		WeightUnit:       utils.WeightUnit(units),
+		ResistanceDetail: exportString(row.ResistanceDetail),
+		RPE:              exportString(row.Rpe),
+		Percent1RM:       exportString(row.Percent1rm),
+		DurationSeconds:  exportInt32(row.DurationSeconds),
+		DistanceMeters:   exportString(row.DistanceMeters),
+		Calories:         exportInt32(row.Calories),
+		PaceSecondsPerKm: exportString(row.PaceSecondsPerKm),
+		Notes:            exportString(row.Notes),
+		CreatedAt:        exportTime(row.CreatedAt),
+		CompletedAt:      exportTime(row.CompletedAt),
+	}
+	if row.SetType.Valid {
+		setType := string(row.SetType.SetTypeEnum)
+		export.SetType = &setType
+	}
+	if row.ResistanceType.Valid {
+		resistanceType := string(row.ResistanceType.ResistanceTypeEnum)
+		export.ResistanceType = &resistanceType
+	}
+	if row.DistanceUnit.Valid {
+		distanceUnit := string(row.DistanceUnit.DistanceUnitEnum)
+		export.DistanceUnit = &distanceUnit
+	}
+	return export
+}
+
+// The row's cells in exportColumns order
+func (row ExportRow) csvRecord() []string {
+	return []string{
+		strconv.Itoa(int(row.WorkoutID)),
+		row.WorkoutDate,
+		csvString(row.WorkoutTitle),
+		csvString(row.StartedAt),
+		csvString(row.EndedAt),
+		csvInt32(row.OverallSetNumber),
+		csvInt32(row.SetNumber),
+		csvInt32(row.ExerciseID),
+		csvString(row.ExerciseName),
+		strings.Join(row.PrimaryMuscles, ";"),
+		strings.Join(row.SecondaryMuscles, ";"),
+		csvString(row.SetType),
+		csvInt32(row.ParentSetNumber),
+		csvInt32(row.GroupID),
+		csvInt32(row.Reps),
+		csvString(row.ResistanceValue),
+		row.WeightUnit,
+		csvString(row.ResistanceType),
+		csvString(row.ResistanceDetail),
+		csvString(row.RPE),
+		csvString(row.Percent1RM),
+		csvInt32(row.DurationSeconds),
+		csvString(row.DistanceMeters),
+		csvString(row.DistanceUnit),
+		csvInt32(row.Calories),
+		csvString(row.PaceSecondsPerKm),
+		csvString(row.Notes),
+		csvString(row.CreatedAt),
+		csvString(row.CompletedAt),
+	}
+}
+
+func exportString(s sql.NullString) *string {
+	if !s.Valid {
+		return nil
+	}
+	return &s.String
+}
+
+func exportInt32(i sql.NullInt32) *int32 {
+	if !i.Valid {
+		return nil
+	}
+	return &i.Int32
+}
+
+func exportTime(t sql.NullTime) *string {
+	if !t.Valid {
+		return nil
+	}
+	formatted := t.Time.UTC().Format(time.RFC3339)
+	return &formatted
+}
+
+func exportList(joined string) []string {
+	if joined == "" {
+		return []string{}
+	}
+	return strings.Split(joined, ";")
+}
+
+func csvString(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+func csvInt32(i *int32) string {
+	if i == nil {
+		return ""
+	}
+	return strconv.Itoa(int(*i))
+}
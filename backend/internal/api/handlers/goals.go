@@ -0,0 +1,751 @@
+// GET (all, one), POST, PATCH, DELETE - lift, bodyweight & training frequency goals. Progress is worked out from the logged
+// data on every read; goals are also checked for completion whenever sets or body measurements are saved.
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"go-reppy/backend/internal/api/middleware"
+	"go-reppy/backend/internal/api/response"
+	"go-reppy/backend/internal/api/utils"
+	"go-reppy/backend/internal/database/sqlc"
+	"go-reppy/backend/internal/training"
+)
+
+type GoalHandler struct {
+	queries *sqlc.Queries
+}
+
+func NewGoalHandler(q *sqlc.Queries) *GoalHandler {
+	return &GoalHandler{
+		queries: q,
+	}
+}
+
+const (
+	goalTrendDays = 56 // projected completion dates follow the trend over the last 8 weeks
+	maxGoalWeeks  = 52
+)
+
+var validGoalTypes = []string{"estimated_1rm", "max_weight", "bodyweight", "weekly_sessions"}
+var validGoalStatuses = []string{"active", "completed", "missed", "abandoned"}
+
+type CreateGoalRequest struct {
+	GoalType   string  `json:"goal_type"`   // 'estimated_1rm', 'max_weight', 'bodyweight', 'weekly_sessions'
+	ExerciseID *int32  `json:"exercise_id"` // required for estimated_1rm & max_weight
+	Target     float64 `json:"target"`      // lbs or kg per unit preference, or sessions per week
+	Deadline   *string `json:"deadline"`    // YYYY-MM-DD
+	Weeks      *int32  `json:"weeks"`       // weekly_sessions alternative to deadline: the goal runs this many 7-day blocks from today
+}
+
+type UpdateGoalRequest struct {
+	Target   *float64 `json:"target"`
+	Deadline *string  `json:"deadline"` // YYYY-MM-DD
+	Status   *string  `json:"status"`   // 'abandoned', or 'active' to reopen; completed & missed are set automatically
+}
+
+type GoalResponse struct {
+	GoalID              int32               `json:"GoalID"`
+	GoalType            sqlc.GoalTypeEnum   `json:"GoalType"`
+	ExerciseID          sql.NullInt32       `json:"ExerciseID"`
+	ExerciseName        sql.NullString      `json:"ExerciseName"`
+	Target              float64             `json:"Target"`
+	StartValue          *float64            `json:"StartValue"` // nil if nothing was logged when the goal was set
+	Current             *float64            `json:"Current"`    // sessions so far this block for weekly_sessions
+	Unit                string              `json:"Unit"`       // the weight unit, or "sessions"
+	StartDate           string              `json:"StartDate"`
+	Deadline            *string             `json:"Deadline"`
+	Status              sqlc.GoalStatusEnum `json:"Status"`
+	CompletedAt         sql.NullTime        `json:"CompletedAt"`
+	ProgressPercent     float64             `json:"ProgressPercent"`
+	ProjectedCompletion *string             `json:"ProjectedCompletion"` // nil once finished, or when the trend won't get there
+	WeeksMet            *int                `json:"WeeksMet"`            // weekly_sessions only
+	WeeksTotal          *int                `json:"WeeksTotal"`
+}
+
+// Where a goal stands right now
+type goalProgress struct {
+	current   *float64 // kg, or sessions in the current block
+	percent   float64
+	reached   bool
+	missed    bool
+	projected *time.Time
+	blocks    *training.SessionBlocks // weekly_sessions only
+}
+
+// "/goals"
+func (h *GoalHandler) HandleGoals(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.GetGoals(w, r)
+	case http.MethodPost:
+		h.CreateGoal(w, r)
+	default:
+		response.SendError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+}
+
+// "/goals/{id}"
+func (h *GoalHandler) HandleGoalByID(w http.ResponseWriter, r *http.Request) {
+	id, err := utils.GetIDFromPath(r.URL.Path)
+	if err != nil {
+		response.SendError(w, "Invalid goal ID", http.StatusBadRequest)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		h.GetGoalByID(w, r, id)
+	case http.MethodPatch:
+		h.UpdateGoal(w, r, id)
+	case http.MethodDelete:
+		h.DeleteGoal(w, r, id)
+	default:
+		response.SendError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+}
+
+/*
+"/goals?status=active&units=imperial"
+optional params: status ('active', 'completed', 'missed', 'abandoned'), units
+*/
+func (h *GoalHandler) GetGoals(w http.ResponseWriter, r *http.Request) {
+	userID, err := middleware.GetUserIDFromContext(r.Context())
+	if err != nil {
+		response.SendError(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	var status sqlc.NullGoalStatusEnum
+	if statusStr := r.URL.Query().Get("status"); statusStr != "" {
+		if !slices.Contains(validGoalStatuses, statusStr) {
+			response.SendError(w, "Invalid status. Must be one of: "+strings.Join(validGoalStatuses, ", "), http.StatusBadRequest)
+			return
+		}
+		status = sqlc.NullGoalStatusEnum{GoalStatusEnum: sqlc.GoalStatusEnum(statusStr), Valid: true}
+	}
+
+	today, err := utils.ClientToday(r)
+	if err != nil {
+		response.SendError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	units, ok := resolveRequestUnits(w, r, h.queries)
+	if !ok {
+		return
+	}
+
+	rows, err := h.queries.GetGoalsForUser(r.Context(), sqlc.GetGoalsForUserParams{
+		UserID: int32(userID),
+		Status: status,
+	})
+	if err != nil {
+		response.SendError(w, "Failed to retrieve goals", http.StatusInternalServerError)
+		return
+	}
+
+	goals := make([]GoalResponse, 0, len(rows))
+	for _, row := range rows {
+		goal, err := h.evaluatedGoalResponse(r.Context(), sqlc.GetGoalByIDForUserRow(row), today, units)
+		if err != nil {
+			response.SendError(w, "Failed to calculate goal progress", http.StatusInternalServerError)
+			return
+		}
+		// a goal that only just finished no longer matches an "active" filter
+		if status.Valid && goal.Status != status.GoalStatusEnum {
+			continue
+		}
+		goals = append(goals, goal)
+	}
+
+	response.SendSuccess(w, goals)
+}
+
+// "/goals"
+func (h *GoalHandler) CreateGoal(w http.ResponseWriter, r *http.Request) {
+	var request CreateGoalRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		response.SendError(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if !slices.Contains(validGoalTypes, request.GoalType) {
+		response.SendError(w, "Invalid goal_type. Must be one of: "+strings.Join(validGoalTypes, ", "), http.StatusBadRequest)
+		return
+	}
+	goalType := sqlc.GoalTypeEnum(request.GoalType)
+	liftGoal := goalType == sqlc.GoalTypeEnumEstimated1rm || goalType == sqlc.GoalTypeEnumMaxWeight
+
+	if liftGoal && request.ExerciseID == nil {
+		response.SendError(w, "exercise_id is required for "+request.GoalType+" goals", http.StatusBadRequest)
+		return
+	}
+	if !liftGoal && request.ExerciseID != nil {
+		response.SendError(w, "exercise_id is only used by estimated_1rm & max_weight goals", http.StatusBadRequest)
+		return
+	}
+	if err := validateGoalTarget(goalType, request.Target); err != nil {
+		response.SendError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	today, err := utils.ClientToday(r)
+	if err != nil {
+		response.SendError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var deadline sql.NullTime
+	switch {
+	case request.Weeks != nil && request.Deadline != nil:
+		response.SendError(w, "Set either deadline or weeks, not both", http.StatusBadRequest)
+		return
+	case request.Weeks != nil:
+		if goalType != sqlc.GoalTypeEnumWeeklySessions {
+			response.SendError(w, "weeks is only used by weekly_sessions goals", http.StatusBadRequest)
+			return
+		}
+		if *request.Weeks < 1 || *request.Weeks > maxGoalWeeks {
+			response.SendError(w, "weeks must be between 1 and 52", http.StatusBadRequest)
+			return
+		}
+		deadline = utils.ToNullTime(today.AddDate(0, 0, int(*request.Weeks)*7-1))
+	case request.Deadline != nil:
+		date, err := time.Parse("2006-01-02", *request.Deadline)
+		if err != nil {
+			response.SendError(w, "Invalid date format for deadline (use YYYY-MM-DD)", http.StatusBadRequest)
+			return
+		}
+		if date.Before(today) {
+			response.SendError(w, "deadline can't be in the past", http.StatusBadRequest)
+			return
+		}
+		deadline = utils.ToNullTime(date)
+	case goalType == sqlc.GoalTypeEnumWeeklySessions:
+		response.SendError(w, "weekly_sessions goals need a deadline or weeks", http.StatusBadRequest)
+		return
+	}
+
+	userID, err := middleware.GetUserIDFromContext(r.Context())
+	if err != nil {
+		response.SendError(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	if liftGoal {
+		exercise, err := h.queries.GetExerciseByIDForUser(r.Context(), sqlc.GetExerciseByIDForUserParams{
+			ExerciseID: *request.ExerciseID,
+			UserID:     utils.ToNullInt32(userID),
+		})
+		if err != nil {
+			if err == sql.ErrNoRows {
+				response.SendError(w, "Exercise not found", http.StatusNotFound)
+				return
+			}
+			response.SendError(w, "Internal server error", http.StatusInternalServerError)
+			return
+		}
+		if exercise.TrackingMode != sqlc.ExerciseTrackingModeEnumRepsLoad {
+			response.SendError(w, "Lift goals need an exercise tracked by reps & load", http.StatusBadRequest)
+			return
+		}
+	}
+
+	units, ok := resolveRequestUnits(w, r, h.queries)
+	if !ok {
+		return
+	}
+
+	target := strconv.FormatFloat(request.Target, 'f', 3, 64)
+	if goalType != sqlc.GoalTypeEnumWeeklySessions {
+		target = utils.ToKgFromFloatPtr(&request.Target, units).String
+	}
+
+	params := sqlc.CreateGoalParams{
+		UserID:      int32(userID),
+		GoalType:    goalType,
+		ExerciseID:  utils.ToNullInt32FromIntPtr(request.ExerciseID),
+		TargetValue: target,
+		StartDate:   today,
+		Deadline:    deadline,
+	}
+	if goalType != sqlc.GoalTypeEnumWeeklySessions {
+		current, _, err := goalReadings(r.Context(), h.queries, sqlc.Goal{
+			UserID:     params.UserID,
+			GoalType:   params.GoalType,
+			ExerciseID: params.ExerciseID,
+		}, today)
+		if err != nil {
+			response.SendError(w, "Failed to retrieve starting value", http.StatusInternalServerError)
+			return
+		}
+		if current != nil {
+			params.StartValue = utils.ToNullString(formatRecordValue(*current))
+		}
+	}
+
+	goal, err := h.queries.CreateGoal(r.Context(), params)
+	if err != nil {
+		response.SendError(w, "Failed to create goal", http.StatusInternalServerError)
+		return
+	}
+
+	h.sendGoal(w, r, goal.GoalID, today, units, http.StatusCreated)
+}
+
+// "/goals/{id}"
+func (h *GoalHandler) GetGoalByID(w http.ResponseWriter, r *http.Request, id int32) {
+	today, err := utils.ClientToday(r)
+	if err != nil {
+		response.SendError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	units, ok := resolveRequestUnits(w, r, h.queries)
+	if !ok {
+		return
+	}
+
+	h.sendGoal(w, r, id, today, units)
+}
+
+// "/goals/{id}" - fields left out keep their current value
+func (h *GoalHandler) UpdateGoal(w http.ResponseWriter, r *http.Request, id int32) {
+	var request UpdateGoalRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		response.SendError(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	var status sqlc.NullGoalStatusEnum
+	if request.Status != nil {
+		if *request.Status != string(sqlc.GoalStatusEnumActive) && *request.Status != string(sqlc.GoalStatusEnumAbandoned) {
+			response.SendError(w, "status can only be set to 'abandoned' or 'active'", http.StatusBadRequest)
+			return
+		}
+		status = sqlc.NullGoalStatusEnum{GoalStatusEnum: sqlc.GoalStatusEnum(*request.Status), Valid: true}
+	}
+
+	var deadline sql.NullTime
+	if request.Deadline != nil {
+		date, err := time.Parse("2006-01-02", *request.Deadline)
+		if err != nil {
+			response.SendError(w, "Invalid date format for deadline (use YYYY-MM-DD)", http.StatusBadRequest)
+			return
+		}
+		deadline = utils.ToNullTime(date)
+	}
+
+	userID, err := middleware.GetUserIDFromContext(r.Context())
+	if err != nil {
+		response.SendError(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	today, err := utils.ClientToday(r)
+	if err != nil {
+		response.SendError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	units, ok := resolveRequestUnits(w, r, h.queries)
+	if !ok {
+		return
+	}
+
+	var target sql.NullString
+	if request.Target != nil {
+		existing, err := h.queries.GetGoalByIDForUser(r.Context(), sqlc.GetGoalByIDForUserParams{
+			GoalID: id,
+			UserID: int32(userID),
+		})
+		if err != nil {
+			if err == sql.ErrNoRows {
+				response.SendError(w, "Goal not found", http.StatusNotFound)
+				return
+			}
+			response.SendError(w, "Internal server error", http.StatusInternalServerError)
+			return
+		}
+		if err := validateGoalTarget(existing.GoalType, *request.Target); err != nil {
+			response.SendError(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		target = utils.ToNullString(strconv.FormatFloat(*request.Target, 'f', 3, 64))
+		if existing.GoalType != sqlc.GoalTypeEnumWeeklySessions {
+			target = utils.ToKgFromFloatPtr(request.Target, units)
+		}
+	}
+
+	_, err = h.queries.UpdateGoal(r.Context(), sqlc.UpdateGoalParams{
+		GoalID:      id,
+		UserID:      int32(userID),
+		TargetValue: target,
+		Deadline:    deadline,
+		Status:      status,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			response.SendError(w, "Goal not found", http.StatusNotFound)
+			return
+		}
+		if strings.Contains(err.Error(), "goals_deadline_after_start") {
+			response.SendError(w, "deadline can't be before the goal's start date", http.StatusBadRequest)
+			return
+		}
+		response.SendError(w, "Failed to update goal", http.StatusInternalServerError)
+		return
+	}
+
+	h.sendGoal(w, r, id, today, units)
+}
+
+// "/goals/{id}"
+func (h *GoalHandler) DeleteGoal(w http.ResponseWriter, r *http.Request, id int32) {
+	userID, err := middleware.GetUserIDFromContext(r.Context())
+	if err != nil {
+		response.SendError(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	_, err = h.queries.DeleteGoal(r.Context(), sqlc.DeleteGoalParams{
+		GoalID: id,
+		UserID: int32(userID),
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			response.SendError(w, "Goal not found", http.StatusNotFound)
+			return
+		}
+		response.SendError(w, "Failed to delete goal", http.StatusInternalServerError)
+		return
+	}
+
+	response.SendSuccess(w, nil, http.StatusNoContent)
+}
+
+// Loads one goal, brings its status up to date & sends it with its progress
+func (h *GoalHandler) sendGoal(w http.ResponseWriter, r *http.Request, id int32, today time.Time, units sqlc.UnitSystemEnum, statusCode ...int) {
+	userID, err := middleware.GetUserIDFromContext(r.Context())
+	if err != nil {
+		response.SendError(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	row, err := h.queries.GetGoalByIDForUser(r.Context(), sqlc.GetGoalByIDForUserParams{
+		GoalID: id,
+		UserID: int32(userID),
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			response.SendError(w, "Goal not found", http.StatusNotFound)
+			return
+		}
+		response.SendError(w, "Failed to retrieve goal", http.StatusInternalServerError)
+		return
+	}
+
+	goal, err := h.evaluatedGoalResponse(r.Context(), row, today, units)
+	if err != nil {
+		response.SendError(w, "Failed to calculate goal progress", http.StatusInternalServerError)
+		return
+	}
+
+	response.SendSuccess(w, goal, statusCode...)
+}
+
+func (h *GoalHandler) evaluatedGoalResponse(ctx context.Context, row sqlc.GetGoalByIDForUserRow, today time.Time, units sqlc.UnitSystemEnum) (GoalResponse, error) {
+	goal := goalFromRow(row)
+	progress, err := evaluateGoal(ctx, h.queries, goal, today)
+	if err != nil {
+		return GoalResponse{}, err
+	}
+	goal, err = syncGoalStatus(ctx, h.queries, goal, progress)
+	if err != nil {
+		return GoalResponse{}, err
+	}
+	return toGoalResponse(goal, row.ExerciseName, progress, units), nil
+}
+
+func validateGoalTarget(goalType sqlc.GoalTypeEnum, target float64) error {
+	if goalType == sqlc.GoalTypeEnumWeeklySessions {
+		if target != math.Trunc(target) || !isValidWeeklySessionTarget(int32(target)) {
+			return fmt.Errorf("target must be a whole number of sessions between 1 and 14")
+		}
+		return nil
+	}
+	if target <= 0 {
+		return fmt.Errorf("target must be greater than 0")
+	}
+	return nil
+}
+
+// Checks every active goal against the data just saved, marking any that are now completed (or missed). Must run on the
+// same transaction as the save so the new sets or measurements are visible.
+func detectCompletedGoals(ctx context.Context, queries *sqlc.Queries, userID int32, today time.Time) error {
+	goals, err := queries.GetActiveGoalsForUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	for _, goal := range goals {
+		progress, err := evaluateGoal(ctx, queries, goal, today)
+		if err != nil {
+			return err
+		}
+		if _, err := syncGoalStatus(ctx, queries, goal, progress); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Moves an active goal to completed or missed once its progress says so; other statuses are left alone
+func syncGoalStatus(ctx context.Context, queries *sqlc.Queries, goal sqlc.Goal, progress goalProgress) (sqlc.Goal, error) {
+	if goal.Status != sqlc.GoalStatusEnumActive {
+		return goal, nil
+	}
+
+	switch {
+	case progress.reached:
+		goal.Status = sqlc.GoalStatusEnumCompleted
+		goal.CompletedAt = utils.ToNullTime(time.Now())
+	case progress.missed:
+		goal.Status = sqlc.GoalStatusEnumMissed
+	default:
+		return goal, nil
+	}
+
+	err := queries.SetGoalStatus(ctx, sqlc.SetGoalStatusParams{
+		GoalID: goal.GoalID,
+		Status: goal.Status,
+	})
+	return goal, err
+}
+
+func evaluateGoal(ctx context.Context, queries *sqlc.Queries, goal sqlc.Goal, today time.Time) (goalProgress, error) {
+	target, _ := strconv.ParseFloat(goal.TargetValue, 64)
+	if goal.GoalType == sqlc.GoalTypeEnumWeeklySessions {
+		return evaluateSessionGoal(ctx, queries, goal, int(target), today)
+	}
+
+	current, points, err := goalReadings(ctx, queries, goal, today)
+	if err != nil {
+		return goalProgress{}, err
+	}
+
+	progress := goalProgress{missed: pastDeadline(goal, today)}
+	if current == nil {
+		return progress, nil
+	}
+
+	start := *current
+	if goal.StartValue.Valid {
+		start, _ = strconv.ParseFloat(goal.StartValue.String, 64)
+	} else if goal.GoalType != sqlc.GoalTypeEnumBodyweight {
+		start = 0
+	}
+	if goal.GoalType != sqlc.GoalTypeEnumBodyweight {
+		start = math.Min(start, target) // lifts only ever go up, even if the target was set below the starting point
+	}
+
+	progress.current = current
+	progress.percent = training.GoalProgress(start, *current, target)
+	progress.reached = training.GoalReached(start, *current, target)
+	if progress.reached {
+		progress.missed = false
+		return progress, nil
+	}
+	if date, ok := training.ProjectGoalDate(points, *current, target, today); ok && !progress.missed {
+		progress.projected = &date
+	}
+	return progress, nil
+}
+
+// The goal's current value in kg (nil before anything's been logged) & its readings over the trend window, oldest first
+func goalReadings(ctx context.Context, queries *sqlc.Queries, goal sqlc.Goal, today time.Time) (*float64, []training.TrendPoint, error) {
+	from := today.AddDate(0, 0, -goalTrendDays)
+	var current *float64
+	var points []training.TrendPoint
+
+	switch goal.GoalType {
+	case sqlc.GoalTypeEnumEstimated1rm, sqlc.GoalTypeEnumMaxWeight:
+		// the current value is the exercise's personal record, which uses the same rep cap & set filters as the trend below
+		records, err := queries.GetCurrentPersonalRecords(ctx, sqlc.GetCurrentPersonalRecordsParams{
+			UserID:     goal.UserID,
+			ExerciseID: goal.ExerciseID.Int32,
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+		recordType := sqlc.RecordTypeEnumMaxWeight
+		if goal.GoalType == sqlc.GoalTypeEnumEstimated1rm {
+			recordType = sqlc.RecordTypeEnumEstimated1rm
+		}
+		if record := findPersonalRecord(records, recordType, sql.NullString{}); record != nil {
+			current = parseGoalValue(record.Value)
+		}
+
+		rows, err := queries.GetDailyBestsForExercise(ctx, sqlc.GetDailyBestsForExerciseParams{
+			UserID:     utils.ToNullInt32(goal.UserID),
+			ExerciseID: goal.ExerciseID.Int32,
+			FromDate:   from,
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, row := range rows {
+			value := row.MaxWeightKg
+			if goal.GoalType == sqlc.GoalTypeEnumEstimated1rm {
+				value = row.Estimated1rmKg
+			}
+			if kg := parseGoalValue(value); kg != nil {
+				points = append(points, training.TrendPoint{Date: row.WorkoutDate.UTC(), Value: *kg})
+			}
+		}
+
+	case sqlc.GoalTypeEnumBodyweight:
+		kg, err := queries.GetBodyweightOn(ctx, sqlc.GetBodyweightOnParams{
+			UserID: goal.UserID,
+			OnDate: today,
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+		current = parseGoalValue(kg)
+
+		measurements, err := queries.GetBodyMeasurementsForUser(ctx, sqlc.GetBodyMeasurementsForUserParams{
+			UserID:   goal.UserID,
+			FromDate: from,
+			ToDate:   today,
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, measurement := range measurements {
+			if kg := parseGoalValue(measurement.BodyweightKg.String); kg != nil {
+				points = append(points, training.TrendPoint{Date: measurement.MeasuredOn.UTC(), Value: *kg})
+			}
+		}
+	}
+
+	return current, points, nil
+}
+
+// A weekly_sessions goal is on track until a 7-day block ends short of the target, and done once every block has met it
+func evaluateSessionGoal(ctx context.Context, queries *sqlc.Queries, goal sqlc.Goal, target int, today time.Time) (goalProgress, error) {
+	rows, err := queries.GetTrainingDaysForUser(ctx, sqlc.GetTrainingDaysForUserParams{
+		UserID: utils.ToNullInt32(goal.UserID),
+		Today:  today,
+	})
+	if err != nil {
+		return goalProgress{}, err
+	}
+	days := make([]training.TrainingDay, len(rows))
+	for i, row := range rows {
+		days[i] = training.TrainingDay{Date: row.WorkoutDate.UTC(), Sessions: int(row.Sessions)}
+	}
+
+	deadline := goal.Deadline.Time.UTC()
+	blocks := training.SessionGoalBlocks(days, target, goal.StartDate.UTC(), deadline, today)
+	current := float64(blocks.CurrentSessions)
+	progress := goalProgress{
+		current: &current,
+		percent: roundTo2(float64(blocks.Met) / float64(blocks.Total) * 100),
+		reached: blocks.Met == blocks.Total,
+		missed:  blocks.Missed,
+		blocks:  &blocks,
+	}
+	if !progress.reached && !progress.missed {
+		progress.projected = &deadline
+	}
+	return progress, nil
+}
+
+func pastDeadline(goal sqlc.Goal, today time.Time) bool {
+	return goal.Deadline.Valid && goal.Deadline.Time.UTC().Before(today)
+}
+
+// nil for missing & zero readings - GetBodyweightOn returns 0 when there's no bodyweight at all
+func parseGoalValue(value string) *float64 {
+	parsed, err := strconv.ParseFloat(value, 64)
+	if err != nil || parsed <= 0 {
+		return nil
+	}
+	return &parsed
+}
+
+func goalFromRow(row sqlc.GetGoalByIDForUserRow) sqlc.Goal {
+	return sqlc.Goal{
+		GoalID:      row.GoalID,
+		UserID:      row.UserID,
+		GoalType:    row.GoalType,
+		ExerciseID:  row.ExerciseID,
+		TargetValue: row.TargetValue,
+		StartValue:  row.StartValue,
+		StartDate:   row.StartDate,
+		Deadline:    row.Deadline,
+		Status:      row.Status,
+		CompletedAt: row.CompletedAt,
+		CreatedAt:   row.CreatedAt,
+		UpdatedAt:   row.UpdatedAt,
+	}
+}
+
+func toGoalResponse(goal sqlc.Goal, exerciseName sql.NullString, progress goalProgress, units sqlc.UnitSystemEnum) GoalResponse {
+	target, _ := strconv.ParseFloat(goal.TargetValue, 64)
+	convert := func(kg float64) float64 { return utils.FromKgFloat(kg, units) }
+	unit := utils.WeightUnit(units)
+	if goal.GoalType == sqlc.GoalTypeEnumWeeklySessions {
+		convert = func(sessions float64) float64 { return sessions }
+		unit = "sessions"
+	}
+
+	resp := GoalResponse{
+		GoalID:          goal.GoalID,
+		GoalType:        goal.GoalType,
+		ExerciseID:      goal.ExerciseID,
+		ExerciseName:    exerciseName,
+		Target:          convert(target),
+		Unit:            unit,
+		StartDate:       goal.StartDate.UTC().Format("2006-01-02"),
+		Status:          goal.Status,
+		CompletedAt:     goal.CompletedAt,
+		ProgressPercent: progress.percent,
+	}
+	if goal.StartValue.Valid {
+		start, _ := strconv.ParseFloat(goal.StartValue.String, 64)
+		start = convert(start)
+		resp.StartValue = &start
+	}
+	if progress.current != nil {
+		current := convert(*progress.current)
+		resp.Current = &current
+	}
+	if goal.Deadline.Valid {
+		deadline := goal.Deadline.Time.UTC().Format("2006-01-02")
+		resp.Deadline = &deadline
+	}
+	if progress.projected != nil && goal.Status == sqlc.GoalStatusEnumActive {
+		projected := progress.projected.Format("2006-01-02")
+		resp.ProjectedCompletion = &projected
+	}
+	if progress.blocks != nil {
+		resp.WeeksMet = &progress.blocks.Met
+		resp.WeeksTotal = &progress.blocks.Total
+	}
+	return resp
+}
@@ -0,0 +1,557 @@
+// POST only - training history from Strong, Hevy & FitNotes CSV exports, with a dry run to review before anything is saved
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"go-reppy/backend/internal/api/middleware"
+	"go-reppy/backend/internal/api/response"
+	"go-reppy/backend/internal/api/utils"
+	"go-reppy/backend/internal/database/sqlc"
+	"go-reppy/backend/internal/importer"
+)
+
+type ImportHandler struct {
+	db      *sql.DB // for saving each batch of workouts, sets & personal records atomically
+	queries *sqlc.Queries
+}
+
+func NewImportHandler(db *sql.DB, q *sqlc.Queries) *ImportHandler {
+	return &ImportHandler{
+		db:      db,
+		queries: q,
+	}
+}
+
+const (
+	importMaxMemory         = 10 << 20 // multipart parts past this are spooled to disk
+	importBatchSize         = 50       // workouts per transaction; a failure only loses the batch it happened in
+	importSuggestionLimit   = 3
+	importDuplicatesSkip    = "skip"
+	importDuplicatesInclude = "import"
+)
+
+var validImportFormats = []string{string(importer.FormatStrong), string(importer.FormatHevy), string(importer.FormatFitNotes)}
+
+var validImportDuplicates = []string{importDuplicatesSkip, importDuplicatesInclude}
+
+var validImportWeightUnits = []string{"kg", "lb"}
+
+var validImportDistanceUnits = []string{"m", "km", "mi", "yd", "ft"}
+
+// "Bench Press (Barbell)" - how Strong & Hevy name equipment variations
+var equipmentSuffix = regexp.MustCompile(`^(.+?)\s*\(([^)]+)\)$`)
+
+// The equipment names Strong & Hevy put in brackets, for the ones the catalog has
+var importEquipment = map[string]sqlc.EquipmentEnum{
+	"barbell":         sqlc.EquipmentEnumBarbell,
+	"dumbbell":        sqlc.EquipmentEnumDumbbell,
+	"cable":           sqlc.EquipmentEnumCable,
+	"machine":         sqlc.EquipmentEnumMachine,
+	"smith machine":   sqlc.EquipmentEnumMachine,
+	"band":            sqlc.EquipmentEnumBand,
+	"resistance band": sqlc.EquipmentEnumBand,
+	"bodyweight":      sqlc.EquipmentEnumBodyweight,
+}
+
+type ImportReport struct {
+	Format            string                `json:"Format"`
+	DryRun            bool                  `json:"DryRun"`
+	WorkoutCount      int                   `json:"WorkoutCount"` // workouts that were (or would be) created
+	SetCount          int                   `json:"SetCount"`
+	FromDate          *string               `json:"FromDate"`
+	ToDate            *string               `json:"ToDate"`
+	Exercises         []ImportExerciseMatch `json:"Exercises"`
+	Unmatched         []string              `json:"Unmatched"`       // names that need an exercise_map entry before importing
+	DuplicateDates    []string              `json:"DuplicateDates"`  // dates in the file that already have a workout
+	SkippedWorkouts   int                   `json:"SkippedWorkouts"` // workouts left out for falling on one of those dates
+	Warnings          []string              `json:"Warnings"`
+	CreatedWorkoutIDs []int32               `json:"CreatedWorkoutIDs"`
+}
+
+type ImportExerciseMatch struct {
+	Name         string             `json:"Name"` // as it appears in the file
+	SetCount     int                `json:"SetCount"`
+	ExerciseID   *int32             `json:"ExerciseID"` // nil if unmatched or skipped
+	ExerciseName *string            `json:"ExerciseName"`
+	MatchedBy    string             `json:"MatchedBy"`   // 'exercise_map', 'name', 'equipment', 'base_name', 'skipped' or 'unmatched'
+	Suggestions  []ImportSuggestion `json:"Suggestions"` // closest catalog entries, for unmatched names only
+}
+
+type ImportSuggestion struct {
+	ExerciseID   int32  `json:"ExerciseID"`
+	ExerciseName string `json:"ExerciseName"`
+}
+
+// A parsed set that's been matched to an exercise & trimmed to its tracking mode
+type importSet struct {
+	exerciseID int32
+	set        importer.Set
+}
+
+type importWorkout struct {
+	workout importer.Workout
+	sets    []importSet
+}
+
+/*
+"/imports" - multipart/form-data:
+
+	file            the CSV export (required)
+	format          'strong', 'hevy' or 'fitnotes'; detected from the header row if absent
+	weight_unit     'kg' or 'lb', for Strong files without a "Weight Unit" column; defaults to the unit preference
+	distance_unit   'm', 'km', 'mi', 'yd' or 'ft', for files that don't say; defaults to 'km' (metric) or 'mi' (imperial)
+	exercise_map    JSON object of names from the file to exercise IDs, e.g. {"Bench Press (Barbell)": 1, "Stretching": 0};
+	                0 leaves that exercise out
+	duplicates      'skip' (default) leaves out workouts on dates that already have one; 'import' adds them alongside
+	dry_run         'true' to only report what would be created
+
+Names are matched against exercise names & aliases (ignoring case & punctuation), then "Name (Equipment)" is tried as
+"Equipment Name" & as plain "Name" on an exercise with that equipment. Anything still unmatched is listed with
+suggestions & has to be mapped (or skipped) before a real import will run - so the review step is a dry run, then the
+same upload again with an exercise_map for the leftovers.
+Start times in the file are read as local times in the "X-User-Timezone" zone.
+*/
+func (h *ImportHandler) HandleImports(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		response.SendError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if err := r.ParseMultipartForm(importMaxMemory); err != nil {
+		response.SendError(w, "Request must be multipart/form-data with a file (max 10MB)", http.StatusBadRequest)
+		return
+	}
+	defer r.MultipartForm.RemoveAll()
+
+	file, _, err := r.FormFile("file")
+	if err != nil {
+		response.SendError(w, "file is required", http.StatusBadRequest)
+		return
+	}
+	defer file.Close()
+
+	format := r.FormValue("format")
+	if format != "" && !slices.Contains(validImportFormats, format) {
+		response.SendError(w, "Invalid format. Must be one of: "+strings.Join(validImportFormats, ", "), http.StatusBadRequest)
+		return
+	}
+
+	duplicates := r.FormValue("duplicates")
+	if duplicates == "" {
+		duplicates = importDuplicatesSkip
+	}
+	if !slices.Contains(validImportDuplicates, duplicates) {
+		response.SendError(w, "Invalid duplicates. Must be one of: "+strings.Join(validImportDuplicates, ", "), http.StatusBadRequest)
+		return
+	}
+
+	dryRun := false
+	if value := r.FormValue("dry_run"); value != "" {
+		if dryRun, err = strconv.ParseBool(value); err != nil {
+			response.SendError(w, "dry_run must be true or false", http.StatusBadRequest)
+			return
+		}
+	}
+
+	exerciseMap := make(map[string]int32)
+	if value := r.FormValue("exercise_map"); value != "" {
+		var decoded map[string]int32
+		if err := json.Unmarshal([]byte(value), &decoded); err != nil {
+			response.SendError(w, "exercise_map must be a JSON object of exercise names to exercise IDs", http.StatusBadRequest)
+			return
+		}
+		for name, exerciseID := range decoded {
+			if exerciseID < 0 {
+				response.SendError(w, "exercise_map IDs must be exercise IDs, or 0 to skip", http.StatusBadRequest)
+				return
+			}
+			exerciseMap[strings.ToLower(strings.TrimSpace(name))] = exerciseID
+		}
+	}
+
+	userID, err := middleware.GetUserIDFromContext(r.Context())
+	if err != nil {
+		response.SendError(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	weightUnit, distanceUnit := r.FormValue("weight_unit"), r.FormValue("distance_unit")
+	if weightUnit != "" && !slices.Contains(validImportWeightUnits, weightUnit) {
+		response.SendError(w, "Invalid weight_unit. Must be one of: "+strings.Join(validImportWeightUnits, ", "), http.StatusBadRequest)
+		return
+	}
+	if distanceUnit != "" && !slices.Contains(validImportDistanceUnits, distanceUnit) {
+		response.SendError(w, "Invalid distance_unit. Must be one of: "+strings.Join(validImportDistanceUnits, ", "), http.StatusBadRequest)
+		return
+	}
+	if weightUnit == "" || distanceUnit == "" {
+		units, err := utils.ResolveUnitSystem(r, h.queries, int32(userID))
+		if err != nil {
+			response.SendError(w, "Failed to resolve unit preference", http.StatusInternalServerError)
+			return
+		}
+		if weightUnit == "" {
+			weightUnit = map[sqlc.UnitSystemEnum]string{sqlc.UnitSystemEnumMetric: "kg", sqlc.UnitSystemEnumImperial: "lb"}[units]
+		}
+		if distanceUnit == "" {
+			distanceUnit = map[sqlc.UnitSystemEnum]string{sqlc.UnitSystemEnumMetric: "km", sqlc.UnitSystemEnumImperial: "mi"}[units]
+		}
+	}
+
+	clientTZ := r.Header.Get("X-User-Timezone")
+	if clientTZ == "" {
+		clientTZ = "UTC"
+	}
+	location, err := time.LoadLocation(clientTZ)
+	if err != nil {
+		response.SendError(w, "invalid timezone: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	today, err := utils.ClientToday(r)
+	if err != nil {
+		response.SendError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	parsed, err := importer.Parse(file, importer.Options{
+		Format:       importer.Format(format),
+		WeightUnit:   weightUnit,
+		DistanceUnit: distanceUnit,
+		Location:     location,
+	})
+	if err != nil {
+		response.SendError(w, "Could not read file: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	if len(parsed.Workouts) == 0 {
+		response.SendError(w, "No workouts found in file", http.StatusBadRequest)
+		return
+	}
+
+	report := ImportReport{
+		Format:            string(parsed.Format),
+		DryRun:            dryRun,
+		Exercises:         []ImportExerciseMatch{},
+		Unmatched:         []string{},
+		DuplicateDates:    []string{},
+		Warnings:          parsed.Warnings,
+		CreatedWorkoutIDs: []int32{},
+	}
+	if report.Warnings == nil {
+		report.Warnings = []string{}
+	}
+
+	// every distinct name is matched once, in the order it first appears
+	matches := make(map[string]*sqlc.Exercise)
+	matchIndex := make(map[string]int)
+	for _, workout := range parsed.Workouts {
+		for _, set := range workout.Sets {
+			if i, ok := matchIndex[set.ExerciseName]; ok {
+				report.Exercises[i].SetCount++
+				continue
+			}
+			match, exercise, err := h.matchImportExercise(r.Context(), int32(userID), set.ExerciseName, exerciseMap)
+			if err == sql.ErrNoRows {
+				response.SendError(w, fmt.Sprintf("exercise_map: exercise %d for %q not found", exerciseMap[strings.ToLower(strings.TrimSpace(set.ExerciseName))], set.ExerciseName), http.StatusBadRequest)
+				return
+			}
+			if err != nil {
+				response.SendError(w, "Failed to match exercises", http.StatusInternalServerError)
+				return
+			}
+			match.SetCount = 1
+			matchIndex[set.ExerciseName] = len(report.Exercises)
+			matches[set.ExerciseName] = exercise
+			report.Exercises = append(report.Exercises, match)
+			if match.MatchedBy == "unmatched" {
+				report.Unmatched = append(report.Unmatched, set.ExerciseName)
+			}
+		}
+	}
+
+	first, last := parsed.Workouts[0].Date, parsed.Workouts[len(parsed.Workouts)-1].Date
+	existingDates, err := h.queries.GetWorkoutDatesForUser(r.Context(), sqlc.GetWorkoutDatesForUserParams{
+		UserID:   utils.ToNullInt32(userID),
+		FromDate: first,
+		ToDate:   last,
+	})
+	if err != nil {
+		response.SendError(w, "Failed to check for existing workouts", http.StatusInternalServerError)
+		return
+	}
+	existing := make(map[string]bool, len(existingDates))
+	for _, date := range existingDates {
+		existing[date.Format("2006-01-02")] = true
+	}
+
+	var workouts []importWorkout
+	for _, workout := range parsed.Workouts {
+		date := workout.Date.Format("2006-01-02")
+		if existing[date] {
+			if len(report.DuplicateDates) == 0 || report.DuplicateDates[len(report.DuplicateDates)-1] != date {
+				report.DuplicateDates = append(report.DuplicateDates, date)
+			}
+			if duplicates == importDuplicatesSkip {
+				report.SkippedWorkouts++
+				continue
+			}
+		}
+
+		prepared := importWorkout{workout: workout}
+		for _, set := range workout.Sets {
+			exercise := matches[set.ExerciseName]
+			if exercise == nil {
+				continue // unmatched or skipped
+			}
+			fitted, ok := fitImportSet(set, exercise.TrackingMode)
+			if !ok {
+				report.Warnings = append(report.Warnings, fmt.Sprintf("line %d: nothing a '%s' exercise tracks was logged for %s; skipped", set.Line, exercise.TrackingMode, exercise.ExerciseName))
+				continue
+			}
+			prepared.sets = append(prepared.sets, importSet{exerciseID: exercise.ExerciseID, set: fitted})
+		}
+		if len(prepared.sets) == 0 {
+			continue
+		}
+		workouts = append(workouts, prepared)
+		report.SetCount += len(prepared.sets)
+	}
+	report.WorkoutCount = len(workouts)
+	if len(workouts) > 0 {
+		from := workouts[0].workout.Date.Format("2006-01-02")
+		to := workouts[len(workouts)-1].workout.Date.Format("2006-01-02")
+		report.FromDate, report.ToDate = &from, &to
+	}
+
+	if dryRun {
+		response.SendSuccess(w, report)
+		return
+	}
+
+	if len(report.Unmatched) > 0 {
+		response.SendError(w, "Unmatched exercise(s): "+strings.Join(report.Unmatched, ", ")+
+			". Map each to an exercise ID (or 0 to skip it) in exercise_map; a dry run lists suggestions", http.StatusUnprocessableEntity)
+		return
+	}
+	if len(workouts) == 0 {
+		response.SendError(w, "Nothing to import; every workout in the file is already logged or was skipped", http.StatusBadRequest)
+		return
+	}
+
+	for start := 0; start < len(workouts); start += importBatchSize {
+		batch := workouts[start:min(start+importBatchSize, len(workouts))]
+		finalBatch := start+importBatchSize >= len(workouts)
+		ids, err := h.importBatch(r.Context(), int32(userID), batch, finalBatch, today)
+		if err != nil {
+			log.Printf("import for user %d failed after %d of %d workouts: %v", userID, start, len(workouts), err)
+			response.SendError(w, fmt.Sprintf("Import stopped after %d of %d workouts; importing the same file again with duplicates=skip picks up where it left off", start, len(workouts)), http.StatusInternalServerError)
+			return
+		}
+		report.CreatedWorkoutIDs = append(report.CreatedWorkoutIDs, ids...)
+	}
+
+	response.SendSuccess(w, report, http.StatusCreated)
+}
+
+// Saves one batch of workouts with their sets & the personal records they set, oldest first so records land on the
+// session that actually set them. Goals are checked once, with the last batch.
+func (h *ImportHandler) importBatch(ctx context.Context, userID int32, batch []importWorkout, checkGoals bool, today time.Time) ([]int32, error) {
+	tx, err := h.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+	qtx := h.queries.WithTx(tx)
+
+	ids := make([]int32, 0, len(batch))
+	for _, prepared := range batch {
+		workout, err := qtx.CreateWorkout(ctx, sqlc.CreateWorkoutParams{
+			UserID:      utils.ToNullInt32(userID),
+			WorkoutDate: prepared.workout.Date,
+			Title:       utils.ToNullString(prepared.workout.Title),
+			StartedAt:   utils.ToNullTimeFromTimePtr(prepared.workout.StartedAt),
+			EndedAt:     utils.ToNullTimeFromTimePtr(prepared.workout.EndedAt),
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		var created []sqlc.WorkoutSet
+		for _, params := range importSetParams(workout.WorkoutID, prepared.sets) {
+			sets, err := qtx.CreateWorkoutSets(ctx, params)
+			if err != nil {
+				return nil, err
+			}
+			created = append(created, sets...)
+		}
+
+		if _, err := detectPersonalRecords(ctx, qtx, userID, created); err != nil {
+			return nil, err
+		}
+		ids = append(ids, workout.WorkoutID)
+	}
+
+	if checkGoals {
+		if err := detectCompletedGoals(ctx, qtx, userID, today); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// One CreateWorkoutSets call per run of consecutive sets on the same exercise, so overall set numbers follow the file's
+// order. Sets in a new workout are numbered 1, 2, 3... in insert order, which is what drop sets' parents point at.
+func importSetParams(workoutID int32, sets []importSet) []sqlc.CreateWorkoutSetsParams {
+	var runs []sqlc.CreateWorkoutSetsParams
+	setNumbers := make(map[int32]int32) // per exercise
+	for overall, imported := range sets {
+		if overall == 0 || sets[overall-1].exerciseID != imported.exerciseID {
+			runs = append(runs, sqlc.CreateWorkoutSetsParams{Column1: workoutID, Column2: imported.exerciseID})
+		}
+		params := &runs[len(runs)-1]
+		set := imported.set
+		setNumbers[imported.exerciseID]++
+
+		// a drop set drops from the set logged just before it; one that doesn't follow a set of the same exercise can't
+		setType, parentSetNumber := set.SetType, int32(0)
+		if setType == "drop" {
+			if overall > 0 && sets[overall-1].exerciseID == imported.exerciseID {
+				parentSetNumber = int32(overall) // the previous set's overall number (1-based)
+			} else {
+				setType = "working"
+			}
+		}
+
+		params.Column3 = append(params.Column3, setNumbers[imported.exerciseID])
+		params.Column4 = append(params.Column4, derefInt32(set.Reps))
+		resistance, resistanceType := "", ""
+		if set.WeightKg != nil {
+			resistance, resistanceType = strconv.FormatFloat(*set.WeightKg, 'f', 3, 64), "weight"
+		}
+		params.Column5 = append(params.Column5, resistance)
+		params.Column6 = append(params.Column6, resistanceType)
+		params.Column7 = append(params.Column7, "")
+		rpe := ""
+		if set.RPE != nil {
+			rpe = strconv.FormatFloat(*set.RPE, 'f', 1, 64)
+		}
+		params.Column8 = append(params.Column8, rpe)
+		params.Column9 = append(params.Column9, set.Notes)
+		params.Column10 = append(params.Column10, setType)
+		params.Column11 = append(params.Column11, parentSetNumber)
+		params.Column12 = append(params.Column12, derefInt32(set.DurationSeconds))
+		distance, distanceUnit := "", ""
+		if set.DistanceMeters != nil {
+			distance, distanceUnit = strconv.FormatFloat(*set.DistanceMeters, 'f', 2, 64), set.DistanceUnit
+		}
+		params.Column13 = append(params.Column13, distance)
+		params.Column14 = append(params.Column14, distanceUnit)
+		params.Column15 = append(params.Column15, 0)
+	}
+	return runs
+}
+
+// Drops whatever the exercise's tracking mode doesn't record (e.g. the weight on a reps-only exercise). False if
+// nothing is left.
+func fitImportSet(set importer.Set, mode sqlc.ExerciseTrackingModeEnum) (importer.Set, bool) {
+	allowed := fieldsByTrackingMode[mode]
+	if !allowed["reps"] {
+		set.Reps = nil
+	}
+	if !allowed["resistance_value"] {
+		set.WeightKg = nil
+	}
+	if !allowed["duration_seconds"] {
+		set.DurationSeconds = nil
+	}
+	// distance_meters is DECIMAL(10,2) & must be positive
+	if !allowed["distance"] || (set.DistanceMeters != nil && *set.DistanceMeters < 0.005) {
+		set.DistanceMeters, set.DistanceUnit = nil, ""
+	}
+	return set, set.Reps != nil || set.WeightKg != nil || set.DurationSeconds != nil || set.DistanceMeters != nil
+}
+
+// Matches a name from the file to an exercise the user can see. The exercise is nil when the name is unmatched or
+// mapped to 0; sql.ErrNoRows means exercise_map pointed at an exercise that doesn't exist (or isn't theirs).
+func (h *ImportHandler) matchImportExercise(ctx context.Context, userID int32, name string, exerciseMap map[string]int32) (ImportExerciseMatch, *sqlc.Exercise, error) {
+	match := ImportExerciseMatch{Name: name, Suggestions: []ImportSuggestion{}}
+	matched := func(exercise sqlc.Exercise, by string) (ImportExerciseMatch, *sqlc.Exercise, error) {
+		match.ExerciseID, match.ExerciseName, match.MatchedBy = &exercise.ExerciseID, &exercise.ExerciseName, by
+		return match, &exercise, nil
+	}
+
+	if exerciseID, ok := exerciseMap[strings.ToLower(strings.TrimSpace(name))]; ok {
+		if exerciseID == 0 {
+			match.MatchedBy = "skipped"
+			return match, nil, nil
+		}
+		exercise, err := h.queries.GetExerciseByIDForUser(ctx, sqlc.GetExerciseByIDForUserParams{
+			ExerciseID: exerciseID,
+			UserID:     utils.ToNullInt32(userID),
+		})
+		if err != nil {
+			return match, nil, err
+		}
+		return matched(exercise, "exercise_map")
+	}
+
+	exercise, err := h.queries.MatchExerciseName(ctx, sqlc.MatchExerciseNameParams{Name: name, UserID: utils.ToNullInt32(userID)})
+	if err == nil {
+		return matched(exercise, "name")
+	}
+	if err != sql.ErrNoRows {
+		return match, nil, err
+	}
+
+	if parts := equipmentSuffix.FindStringSubmatch(name); parts != nil {
+		base := parts[1]
+		if equipment, ok := importEquipment[strings.ToLower(strings.TrimSpace(parts[2]))]; ok {
+			exercise, err := h.queries.MatchExerciseName(ctx, sqlc.MatchExerciseNameParams{Name: string(equipment) + " " + base, UserID: utils.ToNullInt32(userID)})
+			if err == nil {
+				return matched(exercise, "equipment")
+			}
+			if err != sql.ErrNoRows {
+				return match, nil, err
+			}
+
+			// the base name on its own only counts if it isn't a different piece of equipment
+			exercise, err = h.queries.MatchExerciseName(ctx, sqlc.MatchExerciseNameParams{Name: base, UserID: utils.ToNullInt32(userID)})
+			if err == nil && (!exercise.Equipment.Valid || exercise.Equipment.EquipmentEnum == equipment) {
+				return matched(exercise, "base_name")
+			}
+			if err != nil && err != sql.ErrNoRows {
+				return match, nil, err
+			}
+		}
+	}
+
+	match.MatchedBy = "unmatched"
+	suggestions, err := h.queries.SearchExercises(ctx, sqlc.SearchExercisesParams{
+		Query:  name,
+		UserID: utils.ToNullInt32(userID),
+		Limit:  importSuggestionLimit,
+	})
+	if err != nil {
+		return match, nil, err
+	}
+	for _, suggestion := range suggestions {
+		match.Suggestions = append(match.Suggestions, ImportSuggestion{ExerciseID: suggestion.ExerciseID, ExerciseName: suggestion.ExerciseName})
+	}
+	return match, nil, nil
+}
@@ -0,0 +1,51 @@
+package handlers
+
+import (
+	"fmt"
+	"testing"
+
+	"go-reppy/backend/internal/importer"
+)
+
+func TestImportSetParamsDropSetParents(t *testing.T) {
+	const squat, row = 1, 2
+	reps := int32(8)
+	set := func(exerciseID int32, setType string) importSet {
+		return importSet{exerciseID: exerciseID, set: importer.Set{SetType: setType, Reps: &reps}}
+	}
+
+	runs := importSetParams(10, []importSet{
+		set(squat, "warmup"),
+		set(squat, "working"),
+		set(squat, "drop"), // drops from overall set 2
+		set(row, "drop"),   // nothing of its own exercise to drop from
+		set(row, "working"),
+		set(squat, "drop"), // follows a row, so it can't be a drop either
+		set(squat, "drop"), // drops from overall set 6
+	})
+
+	want := []struct {
+		exerciseID int32
+		setNumbers []int32
+		setTypes   []string
+		parents    []int32
+	}{
+		{exerciseID: squat, setNumbers: []int32{1, 2, 3}, setTypes: []string{"warmup", "working", "drop"}, parents: []int32{0, 0, 2}},
+		{exerciseID: row, setNumbers: []int32{1, 2}, setTypes: []string{"working", "working"}, parents: []int32{0, 0}},
+		{exerciseID: squat, setNumbers: []int32{4, 5}, setTypes: []string{"working", "drop"}, parents: []int32{0, 6}},
+	}
+
+	if len(runs) != len(want) {
+		t.Fatalf("importSetParams() made %d runs, want %d", len(runs), len(want))
+	}
+	for i, run := range runs {
+		w := want[i]
+		if run.Column1 != 10 || run.Column2 != w.exerciseID {
+			t.Errorf("run %d is workout %d exercise %d, want workout 10 exercise %d", i, run.Column1, run.Column2, w.exerciseID)
+		}
+		got := fmt.Sprint(run.Column3, run.Column10, run.Column11)
+		if expected := fmt.Sprint(w.setNumbers, w.setTypes, w.parents); got != expected {
+			t.Errorf("run %d set numbers, types & parents = %s, want %s", i, got, expected)
+		}
+	}
+}
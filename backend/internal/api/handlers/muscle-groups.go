@@ -0,0 +1,283 @@
+// GET, POST, PATCH, DELETE - the muscle group hierarchy that muscles are filed under
+package handlers
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"go-reppy/backend/internal/api/response"
+	"go-reppy/backend/internal/api/utils"
+	"go-reppy/backend/internal/database/sqlc"
+)
+
+var validBodyRegions = []sqlc.BodyRegionEnum{sqlc.BodyRegionEnumUpperBody, sqlc.BodyRegionEnumLowerBody, sqlc.BodyRegionEnumCore}
+
+type MuscleGroupHandler struct {
+	queries *sqlc.Queries
+}
+
+func NewMuscleGroupHandler(q *sqlc.Queries) *MuscleGroupHandler {
+	return &MuscleGroupHandler{
+		queries: q,
+	}
+}
+
+type CreateMuscleGroupRequest struct {
+	GroupName     string  `json:"group_name"`
+	BodyRegion    *string `json:"body_region"`
+	ParentGroupID *int32  `json:"parent_group_id"`
+}
+
+// Sending "parent_group_id": null leaves the parent alone; use "clear_parent": true to make the group top-level
+type UpdateMuscleGroupRequest struct {
+	GroupName     *string `json:"group_name"`
+	BodyRegion    *string `json:"body_region"`
+	ParentGroupID *int32  `json:"parent_group_id"`
+	ClearParent   bool    `json:"clear_parent"`
+}
+
+// "/muscle-groups"
+func (h *MuscleGroupHandler) HandleMuscleGroups(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.GetAllMuscleGroups(w, r)
+	case http.MethodPost:
+		h.CreateMuscleGroup(w, r)
+	default:
+		response.SendError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+}
+
+// "/muscle-groups/{id}"
+func (h *MuscleGroupHandler) HandleMuscleGroupByID(w http.ResponseWriter, r *http.Request) {
+	groupID, err := utils.GetIDFromPath(r.URL.Path)
+	if err != nil {
+		response.SendError(w, "Invalid muscle group ID", http.StatusBadRequest)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		h.GetMuscleGroup(w, r, int32(groupID))
+	case http.MethodPatch:
+		h.UpdateMuscleGroup(w, r, int32(groupID))
+	case http.MethodDelete:
+		h.DeleteMuscleGroup(w, r, int32(groupID))
+	default:
+		response.SendError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+}
+
+// "/muscle-groups" - top-level groups first, then their children
+func (h *MuscleGroupHandler) GetAllMuscleGroups(w http.ResponseWriter, r *http.Request) {
+	groups, err := h.queries.GetAllMuscleGroups(r.Context())
+	if err != nil {
+		response.SendError(w, "Failed to retrieve muscle groups", http.StatusInternalServerError)
+		return
+	}
+
+	response.SendSuccess(w, groups)
+}
+
+// "/muscle-groups"
+func (h *MuscleGroupHandler) CreateMuscleGroup(w http.ResponseWriter, r *http.Request) {
+	var request CreateMuscleGroupRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		response.SendError(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	request.GroupName = strings.TrimSpace(request.GroupName)
+	if request.GroupName == "" {
+		response.SendError(w, "group_name is required", http.StatusBadRequest)
+		return
+	}
+
+	bodyRegion, err := parseBodyRegion(request.BodyRegion)
+	if err != nil {
+		response.SendError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if !requireAdmin(w, r, h.queries) {
+		return
+	}
+
+	if request.ParentGroupID != nil && !h.parentExists(w, r, *request.ParentGroupID) {
+		return
+	}
+
+	group, err := h.queries.CreateMuscleGroup(r.Context(), sqlc.CreateMuscleGroupParams{
+		GroupName:     request.GroupName,
+		BodyRegion:    bodyRegion,
+		ParentGroupID: utils.ToNullInt32FromIntPtr(request.ParentGroupID),
+	})
+	if err != nil {
+		if strings.Contains(err.Error(), "unique constraint") {
+			response.SendError(w, "A muscle group with that name already exists", http.StatusConflict)
+			return
+		}
+		response.SendError(w, "Failed to create muscle group", http.StatusInternalServerError)
+		return
+	}
+
+	response.SendSuccess(w, group, http.StatusCreated)
+}
+
+// "/muscle-groups/{id}"
+func (h *MuscleGroupHandler) GetMuscleGroup(w http.ResponseWriter, r *http.Request, groupID int32) {
+	group, err := h.queries.GetMuscleGroupByID(r.Context(), groupID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			response.SendError(w, "Muscle group not found", http.StatusNotFound)
+			return
+		}
+		response.SendError(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	response.SendSuccess(w, group)
+}
+
+// "/muscle-groups/{id}"
+func (h *MuscleGroupHandler) UpdateMuscleGroup(w http.ResponseWriter, r *http.Request, groupID int32) {
+	var request UpdateMuscleGroupRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		response.SendError(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if request.GroupName != nil {
+		trimmed := strings.TrimSpace(*request.GroupName)
+		if trimmed == "" {
+			response.SendError(w, "group_name cannot be empty", http.StatusBadRequest)
+			return
+		}
+		request.GroupName = &trimmed
+	}
+	if request.ClearParent && request.ParentGroupID != nil {
+		response.SendError(w, "Send either parent_group_id or clear_parent, not both", http.StatusBadRequest)
+		return
+	}
+
+	bodyRegion, err := parseBodyRegion(request.BodyRegion)
+	if err != nil {
+		response.SendError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if !requireAdmin(w, r, h.queries) {
+		return
+	}
+
+	if request.ParentGroupID != nil {
+		if !h.parentExists(w, r, *request.ParentGroupID) {
+			return
+		}
+		createsCycle, err := h.isDescendant(r, *request.ParentGroupID, groupID)
+		if err != nil {
+			response.SendError(w, "Internal server error", http.StatusInternalServerError)
+			return
+		}
+		if createsCycle {
+			response.SendError(w, "A muscle group can't be nested under itself or one of its own subgroups", http.StatusBadRequest)
+			return
+		}
+	}
+
+	group, err := h.queries.UpdateMuscleGroup(r.Context(), sqlc.UpdateMuscleGroupParams{
+		GroupName:     utils.ToNullStringFromStringPtr(request.GroupName),
+		BodyRegion:    bodyRegion,
+		ClearParent:   request.ClearParent,
+		ParentGroupID: utils.ToNullInt32FromIntPtr(request.ParentGroupID),
+		MuscleGroupID: groupID,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			response.SendError(w, "Muscle group not found", http.StatusNotFound)
+			return
+		}
+		if strings.Contains(err.Error(), "unique constraint") {
+			response.SendError(w, "A muscle group with that name already exists", http.StatusConflict)
+			return
+		}
+		response.SendError(w, "Failed to update muscle group", http.StatusInternalServerError)
+		return
+	}
+
+	response.SendSuccess(w, group)
+}
+
+// "/muscle-groups/{id}" - refused while any muscle or subgroup still points at it
+func (h *MuscleGroupHandler) DeleteMuscleGroup(w http.ResponseWriter, r *http.Request, groupID int32) {
+	if !requireAdmin(w, r, h.queries) {
+		return
+	}
+
+	_, err := h.queries.DeleteMuscleGroup(r.Context(), groupID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			response.SendError(w, "Muscle group not found", http.StatusNotFound)
+			return
+		}
+		if strings.Contains(err.Error(), "foreign key constraint") {
+			response.SendError(w, "Muscle group still has muscles or subgroups - move them first", http.StatusConflict)
+			return
+		}
+		response.SendError(w, "Failed to delete muscle group", http.StatusInternalServerError)
+		return
+	}
+
+	response.SendSuccess(w, nil, http.StatusNoContent)
+}
+
+// Sends a 400 & returns false if the would-be parent doesn't exist
+func (h *MuscleGroupHandler) parentExists(w http.ResponseWriter, r *http.Request, parentID int32) bool {
+	if _, err := h.queries.GetMuscleGroupByID(r.Context(), parentID); err != nil {
+		if err == sql.ErrNoRows {
+			response.SendError(w, fmt.Sprintf("Parent muscle group %d not found", parentID), http.StatusBadRequest)
+			return false
+		}
+		response.SendError(w, "Internal server error", http.StatusInternalServerError)
+		return false
+	}
+	return true
+}
+
+// Walks up from groupID to the top of its tree, reporting whether ancestorID is on the way (or is groupID itself)
+func (h *MuscleGroupHandler) isDescendant(r *http.Request, groupID, ancestorID int32) (bool, error) {
+	seen := map[int32]bool{}
+	for current := groupID; !seen[current]; {
+		if current == ancestorID {
+			return true, nil
+		}
+		seen[current] = true
+
+		group, err := h.queries.GetMuscleGroupByID(r.Context(), current)
+		if err != nil {
+			return false, err
+		}
+		if !group.ParentGroupID.Valid {
+			return false, nil
+		}
+		current = group.ParentGroupID.Int32
+	}
+	return false, nil
+}
+
+// nil is left NULL; anything else has to be one of the enum's values
+func parseBodyRegion(region *string) (sqlc.NullBodyRegionEnum, error) {
+	if region == nil {
+		return sqlc.NullBodyRegionEnum{}, nil
+	}
+	if !slices.Contains(validBodyRegions, sqlc.BodyRegionEnum(*region)) {
+		return sqlc.NullBodyRegionEnum{}, fmt.Errorf("body_region must be one of 'upper_body', 'lower_body', 'core'")
+	}
+	return sqlc.NullBodyRegionEnum{BodyRegionEnum: sqlc.BodyRegionEnum(*region), Valid: true}, nil
+}
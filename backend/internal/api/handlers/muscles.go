@@ -1,10 +1,15 @@
 package handlers
 
 import (
+	"database/sql"
 	"encoding/json"
+	"fmt"
 	"net/http"
+	"strconv"
+	"strings"
 
 	"go-reppy/backend/internal/api/response"
+	"go-reppy/backend/internal/api/utils"
 	"go-reppy/backend/internal/database/sqlc"
 )
 
@@ -18,9 +23,17 @@ func NewMuscleHandler(q *sqlc.Queries) *MuscleHandler {
 	}
 }
 
+// Either muscle_group (matched by name, ignoring case) or muscle_group_id picks the group
 type CreateMuscleRequest struct {
-	MuscleName  string `json:"muscle_name"`
-	MuscleGroup string `json:"muscle_group"`
+	MuscleName    string `json:"muscle_name"`
+	MuscleGroup   string `json:"muscle_group"`
+	MuscleGroupID *int32 `json:"muscle_group_id"`
+}
+
+type UpdateMuscleRequest struct {
+	MuscleName    *string `json:"muscle_name"`
+	MuscleGroup   *string `json:"muscle_group"`
+	MuscleGroupID *int32  `json:"muscle_group_id"`
 }
 
 func (h *MuscleHandler) HandleMuscles(w http.ResponseWriter, r *http.Request) {
@@ -28,6 +41,10 @@ func (h *MuscleHandler) HandleMuscles(w http.ResponseWriter, r *http.Request) {
 	case http.MethodPost:
 		h.CreateMuscle(w, r)
 	case http.MethodGet:
+		if r.URL.Query().Get("name") == "" {
+			h.GetAllMuscles(w, r)
+			return
+		}
 		h.GetMuscle(w, r)
 	case http.MethodDelete:
 		h.DeleteMuscle(w, r)
@@ -37,6 +54,33 @@ func (h *MuscleHandler) HandleMuscles(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// "/muscles/{id}"
+func (h *MuscleHandler) HandleMuscleByID(w http.ResponseWriter, r *http.Request) {
+	pathParts := strings.Split(r.URL.Path, "/")
+	if len(pathParts) < 3 {
+		response.SendError(w, "Invalid path URL", http.StatusBadRequest)
+		return
+	}
+
+	muscleID, err := strconv.ParseInt(pathParts[2], 10, 32)
+	if err != nil {
+		response.SendError(w, "Invalid muscle ID", http.StatusBadRequest)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		h.GetMuscleByID(w, r, int32(muscleID))
+	case http.MethodPatch:
+		h.UpdateMuscle(w, r, int32(muscleID))
+	case http.MethodDelete:
+		h.DeleteMuscleByID(w, r, int32(muscleID))
+	default:
+		response.SendError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+}
+
 // "/muscles"
 func (h *MuscleHandler) CreateMuscle(w http.ResponseWriter, r *http.Request) {
 	var request CreateMuscleRequest
@@ -45,11 +89,34 @@ func (h *MuscleHandler) CreateMuscle(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	request.MuscleName = strings.TrimSpace(request.MuscleName)
+	if request.MuscleName == "" {
+		response.SendError(w, "muscle_name is required", http.StatusBadRequest)
+		return
+	}
+	if request.MuscleGroup == "" && request.MuscleGroupID == nil {
+		response.SendError(w, "muscle_group or muscle_group_id is required", http.StatusBadRequest)
+		return
+	}
+
+	if !requireAdmin(w, r, h.queries) {
+		return
+	}
+
+	groupID, ok := h.resolveMuscleGroup(w, r, &request.MuscleGroup, request.MuscleGroupID)
+	if !ok {
+		return
+	}
+
 	muscle, err := h.queries.CreateMuscle(r.Context(), sqlc.CreateMuscleParams{
-		MuscleName:  request.MuscleName,
-		MuscleGroup: request.MuscleGroup,
+		MuscleName:    request.MuscleName,
+		MuscleGroupID: groupID,
 	})
 	if err != nil {
+		if strings.Contains(err.Error(), "unique constraint") {
+			response.SendError(w, "A muscle with that name already exists", http.StatusConflict)
+			return
+		}
 		response.SendError(w, "Failed to create muscle", http.StatusInternalServerError)
 		return
 	}
@@ -57,6 +124,17 @@ func (h *MuscleHandler) CreateMuscle(w http.ResponseWriter, r *http.Request) {
 	response.SendSuccess(w, muscle, http.StatusCreated)
 }
 
+// "/muscles" - ordered by group, then name
+func (h *MuscleHandler) GetAllMuscles(w http.ResponseWriter, r *http.Request) {
+	muscles, err := h.queries.GetAllMuscles(r.Context())
+	if err != nil {
+		response.SendError(w, "Failed to retrieve muscles", http.StatusInternalServerError)
+		return
+	}
+
+	response.SendSuccess(w, muscles)
+}
+
 // "/muscles?name=Biceps%20Brachii"
 func (h *MuscleHandler) GetMuscle(w http.ResponseWriter, r *http.Request) {
 	muscleName := r.URL.Query().Get("name")
@@ -74,6 +152,71 @@ func (h *MuscleHandler) GetMuscle(w http.ResponseWriter, r *http.Request) {
 	response.SendSuccess(w, muscle)
 }
 
+// "/muscles/{id}"
+func (h *MuscleHandler) GetMuscleByID(w http.ResponseWriter, r *http.Request, muscleID int32) {
+	muscle, err := h.queries.GetMuscleByID(r.Context(), muscleID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			response.SendError(w, "Muscle not found", http.StatusNotFound)
+			return
+		}
+		response.SendError(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	response.SendSuccess(w, muscle)
+}
+
+// "/muscles/{id}"
+func (h *MuscleHandler) UpdateMuscle(w http.ResponseWriter, r *http.Request, muscleID int32) {
+	var request UpdateMuscleRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		response.SendError(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if request.MuscleName != nil {
+		trimmed := strings.TrimSpace(*request.MuscleName)
+		if trimmed == "" {
+			response.SendError(w, "muscle_name cannot be empty", http.StatusBadRequest)
+			return
+		}
+		request.MuscleName = &trimmed
+	}
+
+	if !requireAdmin(w, r, h.queries) {
+		return
+	}
+
+	params := sqlc.UpdateMuscleParams{
+		MuscleName: utils.ToNullStringFromStringPtr(request.MuscleName),
+		MuscleID:   muscleID,
+	}
+	if request.MuscleGroup != nil || request.MuscleGroupID != nil {
+		groupID, ok := h.resolveMuscleGroup(w, r, request.MuscleGroup, request.MuscleGroupID)
+		if !ok {
+			return
+		}
+		params.MuscleGroupID = utils.ToNullInt32(groupID)
+	}
+
+	muscle, err := h.queries.UpdateMuscle(r.Context(), params)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			response.SendError(w, "Muscle not found", http.StatusNotFound)
+			return
+		}
+		if strings.Contains(err.Error(), "unique constraint") {
+			response.SendError(w, "A muscle with that name already exists", http.StatusConflict)
+			return
+		}
+		response.SendError(w, "Failed to update muscle", http.StatusInternalServerError)
+		return
+	}
+
+	response.SendSuccess(w, muscle)
+}
+
 // "/muscles?name=Biceps%20Brachii"
 func (h *MuscleHandler) DeleteMuscle(w http.ResponseWriter, r *http.Request) {
 	muscleName := r.URL.Query().Get("name")
@@ -82,6 +225,10 @@ func (h *MuscleHandler) DeleteMuscle(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if !requireAdmin(w, r, h.queries) {
+		return
+	}
+
 	deletedMuscle, err := h.queries.DeleteMuscle(r.Context(), muscleName)
 	if err != nil {
 		response.SendError(w, "Failed to delete muscle", http.StatusInternalServerError)
@@ -93,3 +240,46 @@ func (h *MuscleHandler) DeleteMuscle(w http.ResponseWriter, r *http.Request) {
 		"id":      deletedMuscle,
 	}, http.StatusOK) // Not StatusNoContent bc this is a soft delete))
 }
+
+// "/muscles/{id}"
+func (h *MuscleHandler) DeleteMuscleByID(w http.ResponseWriter, r *http.Request, muscleID int32) {
+	if !requireAdmin(w, r, h.queries) {
+		return
+	}
+
+	_, err := h.queries.DeleteMuscleByID(r.Context(), muscleID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			response.SendError(w, "Muscle not found", http.StatusNotFound)
+			return
+		}
+		response.SendError(w, "Failed to delete muscle", http.StatusInternalServerError)
+		return
+	}
+
+	response.SendSuccess(w, nil, http.StatusNoContent)
+}
+
+// Turns a group given by name or by ID into its ID, preferring the ID when both are sent.
+// Sends the error response & returns false if the group doesn't exist.
+func (h *MuscleHandler) resolveMuscleGroup(w http.ResponseWriter, r *http.Request, name *string, id *int32) (int32, bool) {
+	var group sqlc.MuscleGroup
+	var err error
+	var label string
+	if id != nil {
+		group, err = h.queries.GetMuscleGroupByID(r.Context(), *id)
+		label = strconv.Itoa(int(*id))
+	} else {
+		group, err = h.queries.GetMuscleGroupByName(r.Context(), derefString(name))
+		label = fmt.Sprintf("'%s'", derefString(name))
+	}
+	if err != nil {
+		if err == sql.ErrNoRows {
+			response.SendError(w, fmt.Sprintf("Muscle group %s not found", label), http.StatusBadRequest)
+			return 0, false
+		}
+		response.SendError(w, "Internal server error", http.StatusInternalServerError)
+		return 0, false
+	}
+	return group.MuscleGroupID, true
+}
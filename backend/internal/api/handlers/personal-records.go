@@ -0,0 +1,485 @@
+// GET only - records are created as a side effect of saving workout sets
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"math"
+	"net/http"
+	"strconv"
+
+	"go-reppy/backend/internal/api/middleware"
+	"go-reppy/backend/internal/api/response"
+	"go-reppy/backend/internal/api/utils"
+	"go-reppy/backend/internal/database/sqlc"
+	"go-reppy/backend/internal/training"
+)
+
+type PersonalRecordHandler struct {
+	queries *sqlc.Queries
+}
+
+func NewPersonalRecordHandler(q *sqlc.Queries) *PersonalRecordHandler {
+	return &PersonalRecordHandler{
+		queries: q,
+	}
+}
+
+type PersonalRecordResponse struct {
+	RecordID                int32               `json:"RecordID"`
+	ExerciseID              int32               `json:"ExerciseID"`
+	ExerciseName            string              `json:"ExerciseName"`
+	RecordType              sqlc.RecordTypeEnum `json:"RecordType"`
+	Value                   float64             `json:"Value"`
+	Unit                    string              `json:"Unit"`   // "reps" for max_reps_at_weight, else the weight unit
+	Weight                  *float64            `json:"Weight"` // max_reps_at_weight only
+	WorkoutID               int32               `json:"WorkoutID"`
+	WorkoutDate             string              `json:"WorkoutDate"`
+	OverallWorkoutSetNumber sql.NullInt32       `json:"OverallWorkoutSetNumber"` // null for session_volume
+	AchievedAt              sql.NullTime        `json:"AchievedAt"`
+	SupersededAt            sql.NullTime        `json:"SupersededAt"` // null while it's still the current record
+	Current                 bool                `json:"Current"`
+}
+
+// A saved set plus its record types: the ones it broke when created, or the ones it holds after an update
+type WorkoutSetWithRecords struct {
+	sqlc.WorkoutSet
+	PersonalRecords []sqlc.RecordTypeEnum `json:"PersonalRecords"`
+}
+
+// "/me/records" (?current=true for only the records that still stand)
+func (h *PersonalRecordHandler) HandlePersonalRecords(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		response.SendError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	userID, err := middleware.GetUserIDFromContext(r.Context())
+	if err != nil {
+		response.SendError(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	currentOnly := false
+	if current := r.URL.Query().Get("current"); current != "" {
+		currentOnly, err = strconv.ParseBool(current)
+		if err != nil {
+			response.SendError(w, "current must be true or false", http.StatusBadRequest)
+			return
+		}
+	}
+
+	units, ok := resolveRequestUnits(w, r, h.queries)
+	if !ok {
+		return
+	}
+
+	rows, err := h.queries.GetPersonalRecordsForUser(r.Context(), sqlc.GetPersonalRecordsForUserParams{
+		UserID:      int32(userID),
+		CurrentOnly: currentOnly,
+	})
+	if err != nil {
+		response.SendError(w, "Failed to retrieve personal records", http.StatusInternalServerError)
+		return
+	}
+
+	records := make([]PersonalRecordResponse, len(rows))
+	for i, row := range rows {
+		value, _ := strconv.ParseFloat(row.Value, 64)
+		record := PersonalRecordResponse{
+			RecordID:                row.RecordID,
+			ExerciseID:              row.ExerciseID,
+			ExerciseName:            row.ExerciseName,
+			RecordType:              row.RecordType,
+			Value:                   utils.FromKgFloat(value, units),
+			Unit:                    utils.WeightUnit(units),
+			WorkoutID:               row.WorkoutID,
+			WorkoutDate:             row.WorkoutDate.Format("2006-01-02"),
+			OverallWorkoutSetNumber: row.OverallWorkoutSetNumber,
+			AchievedAt:              row.AchievedAt,
+			SupersededAt:            row.SupersededAt,
+			Current:                 !row.SupersededAt.Valid,
+		}
+		if row.RecordType == sqlc.RecordTypeEnumMaxRepsAtWeight {
+			weightKg, _ := strconv.ParseFloat(row.WeightKg.String, 64)
+			weight := utils.FromKgFloat(weightKg, units)
+			record.Value = value
+			record.Unit = "reps"
+			record.Weight = &weight
+		}
+		records[i] = record
+	}
+
+	response.SendSuccess(w, records)
+}
+
+// Checks freshly saved sets against the user's current records, storing any that were beaten (the old record is kept but
+// superseded). Must run on the same transaction as the save. Returns the record types each set broke, by overall set number.
+func detectPersonalRecords(ctx context.Context, queries *sqlc.Queries, userID int32, sets []sqlc.WorkoutSet) (map[int32][]sqlc.RecordTypeEnum, error) {
+	broken := make(map[int32][]sqlc.RecordTypeEnum)
+	currentByExercise := make(map[int32][]sqlc.PersonalRecord)
+	lastSetByExercise := make(map[int32]sqlc.WorkoutSet) // session volume is credited to the exercise's last saved set
+
+	for _, set := range sets {
+		weightKg, ok := recordEligibleWeightKg(set)
+		if !ok {
+			continue
+		}
+
+		current, err := currentPersonalRecords(ctx, queries, currentByExercise, userID, set.ExerciseID)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, candidate := range training.SetRecordCandidates(weightKg, set.Reps.Int32) {
+			recordType := sqlc.RecordTypeEnum(candidate.Type)
+			weight := sql.NullString{}
+			if candidate.Type == training.RecordMaxRepsAtWeight {
+				weight = sql.NullString{String: set.ResistanceValue.String, Valid: true}
+			}
+
+			existing := findPersonalRecord(current, recordType, weight)
+			if existing != nil && !beatsRecord(candidate.Value, existing.Value) {
+				continue
+			}
+
+			record, err := replacePersonalRecord(ctx, queries, existing, sqlc.CreatePersonalRecordParams{
+				UserID:                  userID,
+				ExerciseID:              set.ExerciseID,
+				RecordType:              recordType,
+				Value:                   formatRecordValue(candidate.Value),
+				WeightKg:                weight,
+				WorkoutID:               set.WorkoutID,
+				OverallWorkoutSetNumber: sql.NullInt32{Int32: set.OverallWorkoutSetNumber, Valid: true},
+			})
+			if err != nil {
+				return nil, err
+			}
+			current = upsertPersonalRecord(current, existing, record)
+			broken[set.OverallWorkoutSetNumber] = append(broken[set.OverallWorkoutSetNumber], recordType)
+		}
+
+		currentByExercise[set.ExerciseID] = current
+		lastSetByExercise[set.ExerciseID] = set
+	}
+
+	for exerciseID, set := range lastSetByExercise {
+		volumeKg, err := queries.GetSessionVolumeForExercise(ctx, sqlc.GetSessionVolumeForExerciseParams{
+			WorkoutID:  set.WorkoutID,
+			ExerciseID: exerciseID,
+		})
+		if err != nil {
+			return nil, err
+		}
+		volume, _ := strconv.ParseFloat(volumeKg, 64)
+
+		existing := findPersonalRecord(currentByExercise[exerciseID], sqlc.RecordTypeEnumSessionVolume, sql.NullString{})
+		if existing != nil && !beatsRecord(volume, existing.Value) {
+			continue
+		}
+
+		// this workout already holds the record - it just grew
+		if existing != nil && existing.WorkoutID == set.WorkoutID {
+			err = queries.UpdatePersonalRecordValue(ctx, sqlc.UpdatePersonalRecordValueParams{
+				RecordID: existing.RecordID,
+				Value:    formatRecordValue(volume),
+			})
+		} else {
+			_, err = replacePersonalRecord(ctx, queries, existing, sqlc.CreatePersonalRecordParams{
+				UserID:     userID,
+				ExerciseID: exerciseID,
+				RecordType: sqlc.RecordTypeEnumSessionVolume,
+				Value:      formatRecordValue(volume),
+				WorkoutID:  set.WorkoutID,
+			})
+		}
+		if err != nil {
+			return nil, err
+		}
+		broken[set.OverallWorkoutSetNumber] = append(broken[set.OverallWorkoutSetNumber], sqlc.RecordTypeEnumSessionVolume)
+	}
+
+	return broken, nil
+}
+
+// A record the remaining sets earn, from the set (or workout, for session volume) that first reached it
+type earnedRecord struct {
+	recordType sqlc.RecordTypeEnum
+	value      float64
+	weightKg   sql.NullString
+	workoutID  int32
+	setNumber  sql.NullInt32
+}
+
+// Rebuilds an exercise's current records from the sets left after some were edited, deleted or moved to another exercise,
+// so a corrected typo or a deleted set can't leave behind a record no set backs up. The records held by changedSets & the
+// workout's session volume record are dropped first since their values may no longer be true. Superseded records that are
+// the best again are restored rather than re-created, keeping their dates. Must run on the same transaction as the change.
+func rebuildPersonalRecords(ctx context.Context, queries *sqlc.Queries, userID, workoutID, exerciseID int32, changedSets []int32) error {
+	if len(changedSets) > 0 {
+		if err := queries.DeletePersonalRecordsForWorkoutSets(ctx, sqlc.DeletePersonalRecordsForWorkoutSetsParams{
+			WorkoutID:                workoutID,
+			OverallWorkoutSetNumbers: changedSets,
+		}); err != nil {
+			return err
+		}
+	}
+	if err := queries.DeleteSessionVolumeRecordsForWorkout(ctx, sqlc.DeleteSessionVolumeRecordsForWorkoutParams{
+		WorkoutID:  workoutID,
+		ExerciseID: exerciseID,
+	}); err != nil {
+		return err
+	}
+
+	earned, err := earnedPersonalRecords(ctx, queries, userID, exerciseID)
+	if err != nil {
+		return err
+	}
+
+	records, err := queries.GetPersonalRecordsForExercise(ctx, sqlc.GetPersonalRecordsForExerciseParams{
+		UserID:     userID,
+		ExerciseID: exerciseID,
+	})
+	if err != nil {
+		return err
+	}
+
+	// current records that are still the best stay as they are; the rest make way
+	for _, record := range records {
+		if record.SupersededAt.Valid {
+			continue
+		}
+		key := personalRecordKey(record.RecordType, record.WeightKg)
+		if best, ok := earned[key]; ok && sameDecimal(record.Value, formatRecordValue(best.value)) {
+			delete(earned, key)
+			continue
+		}
+		if err := queries.SupersedePersonalRecord(ctx, record.RecordID); err != nil {
+			return err
+		}
+	}
+
+	for key, best := range earned {
+		if restored := findSupersededRecord(records, key, best); restored != nil {
+			if err := queries.RestorePersonalRecord(ctx, restored.RecordID); err != nil {
+				return err
+			}
+			continue
+		}
+		if _, err := queries.CreatePersonalRecord(ctx, sqlc.CreatePersonalRecordParams{
+			UserID:                  userID,
+			ExerciseID:              exerciseID,
+			RecordType:              best.recordType,
+			Value:                   formatRecordValue(best.value),
+			WeightKg:                best.weightKg,
+			WorkoutID:               best.workoutID,
+			OverallWorkoutSetNumber: best.setNumber,
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// The best of each record type (& weight, for reps-at-weight) across all of an exercise's sets, keyed by personalRecordKey
+func earnedPersonalRecords(ctx context.Context, queries *sqlc.Queries, userID, exerciseID int32) (map[string]earnedRecord, error) {
+	earned := make(map[string]earnedRecord)
+	earn := func(record earnedRecord) {
+		key := personalRecordKey(record.recordType, record.weightKg)
+		// sets come oldest first, so only a strictly better value takes a record over
+		if best, ok := earned[key]; ok && roundRecordValue(record.value) <= roundRecordValue(best.value) {
+			return
+		}
+		earned[key] = record
+	}
+
+	sets, err := queries.GetRecordEligibleSetsForExercise(ctx, sqlc.GetRecordEligibleSetsForExerciseParams{
+		UserID:     utils.ToNullInt32(userID),
+		ExerciseID: exerciseID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, set := range sets {
+		weightKg, err := strconv.ParseFloat(set.ResistanceValue.String, 64)
+		if err != nil {
+			continue
+		}
+		for _, candidate := range training.SetRecordCandidates(weightKg, set.Reps.Int32) {
+			weight := sql.NullString{}
+			if candidate.Type == training.RecordMaxRepsAtWeight {
+				weight = sql.NullString{String: set.ResistanceValue.String, Valid: true}
+			}
+			earn(earnedRecord{
+				recordType: sqlc.RecordTypeEnum(candidate.Type),
+				value:      candidate.Value,
+				weightKg:   weight,
+				workoutID:  set.WorkoutID,
+				setNumber:  sql.NullInt32{Int32: set.OverallWorkoutSetNumber, Valid: true},
+			})
+		}
+	}
+
+	volumes, err := queries.GetSessionVolumesForExercise(ctx, sqlc.GetSessionVolumesForExerciseParams{
+		UserID:     utils.ToNullInt32(userID),
+		ExerciseID: exerciseID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, volume := range volumes {
+		value, err := strconv.ParseFloat(volume.VolumeKg, 64)
+		if err != nil {
+			continue
+		}
+		earn(earnedRecord{recordType: sqlc.RecordTypeEnumSessionVolume, value: value, workoutID: volume.WorkoutID})
+	}
+
+	return earned, nil
+}
+
+func personalRecordKey(recordType sqlc.RecordTypeEnum, weightKg sql.NullString) string {
+	if !weightKg.Valid {
+		return string(recordType)
+	}
+	weight, _ := strconv.ParseFloat(weightKg.String, 64)
+	return string(recordType) + "@" + formatRecordValue(weight)
+}
+
+// A superseded record from the same set (or workout) with the same value, which can simply be made current again
+func findSupersededRecord(records []sqlc.PersonalRecord, key string, best earnedRecord) *sqlc.PersonalRecord {
+	for i := range records {
+		record := &records[i]
+		if !record.SupersededAt.Valid || personalRecordKey(record.RecordType, record.WeightKg) != key {
+			continue
+		}
+		if record.WorkoutID == best.workoutID && record.OverallWorkoutSetNumber == best.setNumber &&
+			sameDecimal(record.Value, formatRecordValue(best.value)) {
+			return record
+		}
+	}
+	return nil
+}
+
+// The record types a set holds right now (session volume counts if its workout holds it), for update responses
+func heldPersonalRecords(ctx context.Context, queries *sqlc.Queries, userID int32, set sqlc.WorkoutSet) (map[int32][]sqlc.RecordTypeEnum, error) {
+	current, err := queries.GetCurrentPersonalRecords(ctx, sqlc.GetCurrentPersonalRecordsParams{
+		UserID:     userID,
+		ExerciseID: set.ExerciseID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	held := make(map[int32][]sqlc.RecordTypeEnum)
+	for _, record := range current {
+		if record.WorkoutID != set.WorkoutID {
+			continue
+		}
+		if !record.OverallWorkoutSetNumber.Valid || record.OverallWorkoutSetNumber.Int32 == set.OverallWorkoutSetNumber {
+			held[set.OverallWorkoutSetNumber] = append(held[set.OverallWorkoutSetNumber], record.RecordType)
+		}
+	}
+	return held, nil
+}
+
+// Only working sets with a logged weight & reps count towards records
+func recordEligibleWeightKg(set sqlc.WorkoutSet) (float64, bool) {
+	if set.SetType == sqlc.SetTypeEnumWarmup || !set.Reps.Valid || !set.ResistanceValue.Valid {
+		return 0, false
+	}
+	if !set.ResistanceType.Valid || set.ResistanceType.ResistanceTypeEnum != sqlc.ResistanceTypeEnumWeight {
+		return 0, false
+	}
+
+	weightKg, err := strconv.ParseFloat(set.ResistanceValue.String, 64)
+	if err != nil || weightKg <= 0 || set.Reps.Int32 <= 0 {
+		return 0, false
+	}
+	return weightKg, true
+}
+
+// Loads an exercise's current records once per detection run
+func currentPersonalRecords(ctx context.Context, queries *sqlc.Queries, cache map[int32][]sqlc.PersonalRecord, userID, exerciseID int32) ([]sqlc.PersonalRecord, error) {
+	if current, ok := cache[exerciseID]; ok {
+		return current, nil
+	}
+
+	current, err := queries.GetCurrentPersonalRecords(ctx, sqlc.GetCurrentPersonalRecordsParams{
+		UserID:     userID,
+		ExerciseID: exerciseID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	cache[exerciseID] = current
+	return current, nil
+}
+
+func findPersonalRecord(records []sqlc.PersonalRecord, recordType sqlc.RecordTypeEnum, weightKg sql.NullString) *sqlc.PersonalRecord {
+	for i := range records {
+		if records[i].RecordType != recordType {
+			continue
+		}
+		if weightKg.Valid && !sameDecimal(records[i].WeightKg.String, weightKg.String) {
+			continue
+		}
+		return &records[i]
+	}
+	return nil
+}
+
+// Supersedes the record being beaten (if any) & stores the new one
+func replacePersonalRecord(ctx context.Context, queries *sqlc.Queries, existing *sqlc.PersonalRecord, params sqlc.CreatePersonalRecordParams) (sqlc.PersonalRecord, error) {
+	if existing != nil {
+		if err := queries.SupersedePersonalRecord(ctx, existing.RecordID); err != nil {
+			return sqlc.PersonalRecord{}, err
+		}
+	}
+	return queries.CreatePersonalRecord(ctx, params)
+}
+
+func upsertPersonalRecord(records []sqlc.PersonalRecord, existing *sqlc.PersonalRecord, record sqlc.PersonalRecord) []sqlc.PersonalRecord {
+	if existing != nil {
+		*existing = record
+		return records
+	}
+	return append(records, record)
+}
+
+// Records are stored to 3 decimal places, so compare at that precision
+func beatsRecord(value float64, current string) bool {
+	currentValue, err := strconv.ParseFloat(current, 64)
+	if err != nil {
+		return true
+	}
+	return roundRecordValue(value) > currentValue
+}
+
+func sameDecimal(a, b string) bool {
+	x, errA := strconv.ParseFloat(a, 64)
+	y, errB := strconv.ParseFloat(b, 64)
+	return errA == nil && errB == nil && roundRecordValue(x) == roundRecordValue(y)
+}
+
+func roundRecordValue(value float64) float64 {
+	return math.Round(value*1000) / 1000
+}
+
+func formatRecordValue(value float64) string {
+	return strconv.FormatFloat(value, 'f', 3, 64)
+}
+
+// Pairs each saved set with the records it set & converts weights to the request's units
+func workoutSetsWithRecords(sets []sqlc.WorkoutSet, broken map[int32][]sqlc.RecordTypeEnum, units sqlc.UnitSystemEnum) []WorkoutSetWithRecords {
+	sets = workoutSetsInUnits(sets, units)
+	withRecords := make([]WorkoutSetWithRecords, len(sets))
+	for i, set := range sets {
+		records := broken[set.OverallWorkoutSetNumber]
+		if records == nil {
+			records = []sqlc.RecordTypeEnum{}
+		}
+		withRecords[i] = WorkoutSetWithRecords{WorkoutSet: set, PersonalRecords: records}
+	}
+	return withRecords
+}
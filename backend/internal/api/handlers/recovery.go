@@ -0,0 +1,204 @@
+// GET only - recovery is computed from recent sets
+package handlers
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"go-reppy/backend/internal/api/middleware"
+	"go-reppy/backend/internal/api/response"
+	"go-reppy/backend/internal/api/utils"
+	"go-reppy/backend/internal/database/sqlc"
+	"go-reppy/backend/internal/training"
+)
+
+type RecoveryHandler struct {
+	queries *sqlc.Queries
+}
+
+func NewRecoveryHandler(q *sqlc.Queries) *RecoveryHandler {
+	return &RecoveryHandler{
+		queries: q,
+	}
+}
+
+const (
+	recoveryLookbackHalfLives = 5 // fatigue older than this many half-lives is ~3% of what it was, so it's not loaded
+	maxRecoveryLookback       = 30 * 24 * time.Hour
+)
+
+// IDs & names match the muscles table so the frontend can map them onto its body heatmap
+type MuscleRecovery struct {
+	MuscleID      int32      `json:"MuscleID"`
+	MuscleName    string     `json:"MuscleName"`
+	MuscleGroup   string     `json:"MuscleGroup"`
+	Recovery      float64    `json:"Recovery"` // 0-100%
+	HalfLifeHours float64    `json:"HalfLifeHours"`
+	LastTrainedAt *time.Time `json:"LastTrainedAt"` // nil if not trained within the lookback window
+}
+
+type MuscleGroupRecovery struct {
+	MuscleGroup string  `json:"MuscleGroup"`
+	Recovery    float64 `json:"Recovery"` // average of its muscles
+	MuscleIDs   []int32 `json:"MuscleIDs"`
+}
+
+type RecoveryResponse struct {
+	AsOf            time.Time             `json:"AsOf"`
+	SecondaryWeight float64               `json:"SecondaryWeight"`
+	Muscles         []MuscleRecovery      `json:"Muscles"`
+	MuscleGroups    []MuscleGroupRecovery `json:"MuscleGroups"`
+}
+
+/*
+"/me/recovery?half_life_hours=Legs:48,Arms:18&secondary_weight=0.3"
+optional params: half_life_hours (one number for every muscle group, or "Group:hours" pairs overriding the defaults),
+secondary_weight (0-1, default 0.5)
+*/
+func (h *RecoveryHandler) HandleRecovery(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		response.SendError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	userID, err := middleware.GetUserIDFromContext(r.Context())
+	if err != nil {
+		response.SendError(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	query := r.URL.Query()
+	halfLives, defaultHalfLife, err := parseHalfLives(query.Get("half_life_hours"))
+	if err != nil {
+		response.SendError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	secondaryWeight := training.DefaultSecondaryRecoveryShare
+	if weightStr := query.Get("secondary_weight"); weightStr != "" {
+		secondaryWeight, err = strconv.ParseFloat(weightStr, 64)
+		if err != nil || secondaryWeight < 0 || secondaryWeight > 1 {
+			response.SendError(w, "secondary_weight must be a number between 0 and 1", http.StatusBadRequest)
+			return
+		}
+	}
+
+	muscles, err := h.queries.GetAllMuscles(r.Context())
+	if err != nil {
+		response.SendError(w, "Failed to retrieve muscles", http.StatusInternalServerError)
+		return
+	}
+
+	longestHalfLife := defaultHalfLife
+	for _, muscle := range muscles {
+		longestHalfLife = max(longestHalfLife, halfLifeFor(muscle.MuscleGroup, halfLives, defaultHalfLife))
+	}
+	now := time.Now()
+	lookback := min(recoveryLookbackHalfLives*longestHalfLife, maxRecoveryLookback)
+
+	rows, err := h.queries.GetRecentMuscleWorkForUser(r.Context(), sqlc.GetRecentMuscleWorkForUserParams{
+		UserID: utils.ToNullInt32(userID),
+		Since:  now.Add(-lookback),
+		Until:  now,
+	})
+	if err != nil {
+		response.SendError(w, "Failed to retrieve recent sets", http.StatusInternalServerError)
+		return
+	}
+
+	workByMuscle := make(map[int32][]training.MuscleWork)
+	for _, row := range rows {
+		work := training.MuscleWork{
+			Primary:     row.InvolvementLevel == sqlc.InvolvementLevelEnumPrimary,
+			PerformedAt: row.PerformedAt,
+			Reps:        row.Reps.Int32,
+		}
+		if row.Rpe.Valid {
+			if rpe, err := strconv.ParseFloat(row.Rpe.String, 64); err == nil {
+				work.RPE = &rpe
+			}
+		}
+		workByMuscle[row.MuscleID] = append(workByMuscle[row.MuscleID], work)
+	}
+
+	// muscles come ordered by group, so each group's muscles are contiguous
+	result := RecoveryResponse{
+		AsOf:            now,
+		SecondaryWeight: secondaryWeight,
+		Muscles:         make([]MuscleRecovery, 0, len(muscles)),
+		MuscleGroups:    []MuscleGroupRecovery{},
+	}
+	groupTotal := 0.0
+	for _, muscle := range muscles {
+		halfLife := halfLifeFor(muscle.MuscleGroup, halfLives, defaultHalfLife)
+		work := workByMuscle[muscle.MuscleID]
+
+		recovery := MuscleRecovery{
+			MuscleID:      muscle.MuscleID,
+			MuscleName:    muscle.MuscleName,
+			MuscleGroup:   muscle.MuscleGroup,
+			Recovery:      training.RecoveryPercent(work, now, training.RecoverySettings{HalfLife: halfLife, SecondaryWeight: secondaryWeight}),
+			HalfLifeHours: halfLife.Hours(),
+		}
+		if len(work) > 0 {
+			last := work[len(work)-1].PerformedAt
+			recovery.LastTrainedAt = &last
+		}
+		result.Muscles = append(result.Muscles, recovery)
+
+		groups := result.MuscleGroups
+		if len(groups) == 0 || groups[len(groups)-1].MuscleGroup != muscle.MuscleGroup {
+			groupTotal = 0
+			result.MuscleGroups = append(result.MuscleGroups, MuscleGroupRecovery{MuscleGroup: muscle.MuscleGroup})
+		}
+		group := &result.MuscleGroups[len(result.MuscleGroups)-1]
+		group.MuscleIDs = append(group.MuscleIDs, muscle.MuscleID)
+		groupTotal += recovery.Recovery
+		group.Recovery = math.Round(groupTotal/float64(len(group.MuscleIDs))*10) / 10
+	}
+
+	response.SendSuccess(w, result)
+}
+
+// Reads "?half_life_hours=" - either one number for every group, or "Group:hours" pairs that override the per-group defaults
+func parseHalfLives(param string) (map[string]time.Duration, time.Duration, error) {
+	halfLives := make(map[string]time.Duration, len(training.DefaultRecoveryHalfLives))
+	for group, halfLife := range training.DefaultRecoveryHalfLives {
+		halfLives[group] = halfLife
+	}
+	if param == "" {
+		return halfLives, training.DefaultRecoveryHalfLife, nil
+	}
+
+	if hours, err := strconv.ParseFloat(param, 64); err == nil {
+		if hours <= 0 {
+			return nil, 0, fmt.Errorf("half_life_hours must be greater than 0")
+		}
+		return map[string]time.Duration{}, hoursToDuration(hours), nil
+	}
+
+	for _, pair := range strings.Split(param, ",") {
+		group, hoursStr, found := strings.Cut(pair, ":")
+		hours, err := strconv.ParseFloat(strings.TrimSpace(hoursStr), 64)
+		if !found || err != nil || hours <= 0 {
+			return nil, 0, fmt.Errorf("half_life_hours must be a number of hours or comma separated 'Group:hours' pairs")
+		}
+		halfLives[strings.TrimSpace(group)] = hoursToDuration(hours)
+	}
+	return halfLives, training.DefaultRecoveryHalfLife, nil
+}
+
+func halfLifeFor(muscleGroup string, halfLives map[string]time.Duration, defaultHalfLife time.Duration) time.Duration {
+	if halfLife, ok := halfLives[muscleGroup]; ok {
+		return halfLife
+	}
+	return defaultHalfLife
+}
+
+func hoursToDuration(hours float64) time.Duration {
+	return time.Duration(hours * float64(time.Hour))
+}
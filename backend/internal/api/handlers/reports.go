@@ -0,0 +1,380 @@
+// GET only - reports are computed from logged sets
+package handlers
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"go-reppy/backend/internal/api/middleware"
+	"go-reppy/backend/internal/api/response"
+	"go-reppy/backend/internal/api/utils"
+	"go-reppy/backend/internal/database/sqlc"
+	"go-reppy/backend/internal/training"
+)
+
+type ReportHandler struct {
+	queries *sqlc.Queries
+}
+
+func NewReportHandler(q *sqlc.Queries) *ReportHandler {
+	return &ReportHandler{
+		queries: q,
+	}
+}
+
+const (
+	defaultVolumeGranularity       = "week"
+	defaultVolumeReportWeeks       = 12
+	defaultSecondaryMuscleFraction = 0.5 // a set counts as half a set for the muscles it only works secondarily
+	defaultLoadReportWeeks         = 8
+	defaultLoadMethod              = "volume_rpe"
+	maxLoadReportDays              = 731 // the series has a point per day
+)
+
+var volumeGranularities = map[string]bool{"day": true, "week": true, "month": true}
+
+// Totals for a muscle or muscle group; secondary muscle work is scaled by the report's SecondaryFraction
+type VolumeTotals struct {
+	HardSets float64 `json:"HardSets"` // non warm-up sets
+	Reps     float64 `json:"Reps"`
+	Tonnage  float64 `json:"Tonnage"` // weight x reps of weighted & bodyweight sets, in the report's unit
+}
+
+type MuscleVolume struct {
+	MuscleID    int32  `json:"MuscleID"`
+	MuscleName  string `json:"MuscleName"`
+	MuscleGroup string `json:"MuscleGroup"`
+	VolumeTotals
+}
+
+type MuscleGroupVolume struct {
+	MuscleGroup string `json:"MuscleGroup"`
+	VolumeTotals
+}
+
+type VolumePeriod struct {
+	PeriodStart  string              `json:"PeriodStart"` // YYYY-MM-DD; Monday for weeks, the 1st for months
+	Muscles      []MuscleVolume      `json:"Muscles"`
+	MuscleGroups []MuscleGroupVolume `json:"MuscleGroups"`
+}
+
+type VolumeReportResponse struct {
+	From              string         `json:"From"`
+	To                string         `json:"To"`
+	Granularity       string         `json:"Granularity"`
+	SecondaryFraction float64        `json:"SecondaryFraction"`
+	Unit              string         `json:"Unit"`
+	Periods           []VolumePeriod `json:"Periods"` // oldest first; periods with no sets are left out
+}
+
+/*
+"/reports/volume?from=2024-01-01&to=2024-03-31&granularity=week"
+optional params: from (default 12 weeks before to), to (default today), granularity ('day', 'week' (default), 'month'),
+secondary_fraction (0-1, default 0.5), units
+*/
+func (h *ReportHandler) HandleVolumeReport(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		response.SendError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	userID, err := middleware.GetUserIDFromContext(r.Context())
+	if err != nil {
+		response.SendError(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	from, to, ok := parseReportRange(w, r, defaultVolumeReportWeeks)
+	if !ok {
+		return
+	}
+
+	query := r.URL.Query()
+	granularity := defaultVolumeGranularity
+	if g := query.Get("granularity"); g != "" {
+		if !volumeGranularities[g] {
+			response.SendError(w, "granularity must be one of 'day', 'week', 'month'", http.StatusBadRequest)
+			return
+		}
+		granularity = g
+	}
+
+	secondaryFraction := defaultSecondaryMuscleFraction
+	if fractionStr := query.Get("secondary_fraction"); fractionStr != "" {
+		secondaryFraction, err = strconv.ParseFloat(fractionStr, 64)
+		if err != nil || secondaryFraction < 0 || secondaryFraction > 1 {
+			response.SendError(w, "secondary_fraction must be a number between 0 and 1", http.StatusBadRequest)
+			return
+		}
+	}
+
+	units, ok := resolveRequestUnits(w, r, h.queries)
+	if !ok {
+		return
+	}
+
+	muscleRows, err := h.queries.GetMuscleVolumeForUser(r.Context(), sqlc.GetMuscleVolumeForUserParams{
+		Granularity: granularity,
+		UserID:      utils.ToNullInt32(userID),
+		FromDate:    from,
+		ToDate:      to,
+	})
+	if err != nil {
+		response.SendError(w, "Failed to generate volume report", http.StatusInternalServerError)
+		return
+	}
+
+	groupRows, err := h.queries.GetMuscleGroupVolumeForUser(r.Context(), sqlc.GetMuscleGroupVolumeForUserParams{
+		Granularity: granularity,
+		UserID:      utils.ToNullInt32(userID),
+		FromDate:    from,
+		ToDate:      to,
+	})
+	if err != nil {
+		response.SendError(w, "Failed to generate volume report", http.StatusInternalServerError)
+		return
+	}
+
+	response.SendSuccess(w, VolumeReportResponse{
+		From:              from.Format("2006-01-02"),
+		To:                to.Format("2006-01-02"),
+		Granularity:       granularity,
+		SecondaryFraction: secondaryFraction,
+		Unit:              utils.WeightUnit(units),
+		Periods:           buildVolumePeriods(muscleRows, groupRows, secondaryFraction, units),
+	})
+}
+
+// Folds the per-muscle & per-group rows (each split by involvement & ordered by period, then muscle group) into weighted
+// totals. Group totals come from their own rows rather than the muscles' since a set can work several muscles in a group.
+func buildVolumePeriods(muscleRows []sqlc.GetMuscleVolumeForUserRow, groupRows []sqlc.GetMuscleGroupVolumeForUserRow, secondaryFraction float64, units sqlc.UnitSystemEnum) []VolumePeriod {
+	periods := []VolumePeriod{}
+	periodFor := func(start time.Time) *VolumePeriod {
+		periodStart := start.Format("2006-01-02")
+		for i := range periods {
+			if periods[i].PeriodStart == periodStart {
+				return &periods[i]
+			}
+		}
+		periods = append(periods, VolumePeriod{PeriodStart: periodStart, Muscles: []MuscleVolume{}, MuscleGroups: []MuscleGroupVolume{}})
+		return &periods[len(periods)-1]
+	}
+
+	for _, row := range muscleRows {
+		period := periodFor(row.PeriodStart)
+		totals := weightedVolumeTotals(row.InvolvementLevel, row.HardSets, row.Reps, row.TonnageKg, secondaryFraction, units)
+
+		// a muscle can show up twice in a period if it's primary for some exercises & secondary for others
+		if n := len(period.Muscles); n > 0 && period.Muscles[n-1].MuscleID == row.MuscleID {
+			period.Muscles[n-1].add(totals)
+		} else {
+			period.Muscles = append(period.Muscles, MuscleVolume{
+				MuscleID:     row.MuscleID,
+				MuscleName:   row.MuscleName,
+				MuscleGroup:  row.MuscleGroup,
+				VolumeTotals: totals,
+			})
+		}
+	}
+
+	for _, row := range groupRows {
+		period := periodFor(row.PeriodStart)
+		totals := weightedVolumeTotals(row.InvolvementLevel, row.HardSets, row.Reps, row.TonnageKg, secondaryFraction, units)
+
+		// likewise a group shows up twice if it's primary for some sets & only secondary for others
+		if n := len(period.MuscleGroups); n > 0 && period.MuscleGroups[n-1].MuscleGroup == row.MuscleGroup {
+			period.MuscleGroups[n-1].add(totals)
+		} else {
+			period.MuscleGroups = append(period.MuscleGroups, MuscleGroupVolume{
+				MuscleGroup:  row.MuscleGroup,
+				VolumeTotals: totals,
+			})
+		}
+	}
+	return periods
+}
+
+func weightedVolumeTotals(involvement sqlc.InvolvementLevelEnum, hardSets, reps int64, tonnageKg string, secondaryFraction float64, units sqlc.UnitSystemEnum) VolumeTotals {
+	weight := 1.0
+	if involvement == sqlc.InvolvementLevelEnumSecondary {
+		weight = secondaryFraction
+	}
+	tonnage, _ := strconv.ParseFloat(tonnageKg, 64)
+	return VolumeTotals{
+		HardSets: float64(hardSets) * weight,
+		Reps:     float64(reps) * weight,
+		Tonnage:  utils.FromKgFloat(tonnage*weight, units),
+	}
+}
+
+func (t *VolumeTotals) add(other VolumeTotals) {
+	t.HardSets = roundTo2(t.HardSets + other.HardSets)
+	t.Reps = roundTo2(t.Reps + other.Reps)
+	t.Tonnage = roundTo2(t.Tonnage + other.Tonnage)
+}
+
+func roundTo2(value float64) float64 {
+	return math.Round(value*100) / 100
+}
+
+// Reads "?from=&to=" (YYYY-MM-DD); to defaults to the client's today & from to defaultWeeks before to.
+// Sends the error response itself & returns false if they're invalid.
+func parseReportRange(w http.ResponseWriter, r *http.Request, defaultWeeks int) (time.Time, time.Time, bool) {
+	query := r.URL.Query()
+	to, err := utils.ClientToday(r)
+	if err != nil {
+		response.SendError(w, err.Error(), http.StatusBadRequest)
+		return time.Time{}, time.Time{}, false
+	}
+	if toStr := query.Get("to"); toStr != "" {
+		parsed, err := time.Parse("2006-01-02", toStr)
+		if err != nil {
+			response.SendError(w, "Invalid to date format - use YYYY-MM-DD", http.StatusBadRequest)
+			return time.Time{}, time.Time{}, false
+		}
+		to = parsed
+	}
+
+	from := to.AddDate(0, 0, -7*defaultWeeks)
+	if fromStr := query.Get("from"); fromStr != "" {
+		parsed, err := time.Parse("2006-01-02", fromStr)
+		if err != nil {
+			response.SendError(w, "Invalid from date format - use YYYY-MM-DD", http.StatusBadRequest)
+			return time.Time{}, time.Time{}, false
+		}
+		from = parsed
+	}
+
+	if to.Before(from) {
+		response.SendError(w, "to must not be before from", http.StatusBadRequest)
+		return time.Time{}, time.Time{}, false
+	}
+	return from, to, true
+}
+
+// Daily load measures; volume based ones are in the report's weight unit, srpe is in arbitrary units (minutes x RPE)
+var loadMethods = map[string]bool{"volume_rpe": true, "srpe": true, "volume": true}
+
+type LoadPointResponse struct {
+	Date     string   `json:"Date"`
+	Sessions int32    `json:"Sessions"`
+	HardSets int32    `json:"HardSets"`
+	Load     float64  `json:"Load"`
+	Acute    float64  `json:"Acute"`   // 7-day total
+	Chronic  float64  `json:"Chronic"` // 28-day average weekly load
+	ACWR     *float64 `json:"ACWR"`    // null until there's chronic load
+	Monotony *float64 `json:"Monotony"`
+	Strain   *float64 `json:"Strain"`
+}
+
+type LoadReportResponse struct {
+	From   string              `json:"From"`
+	To     string              `json:"To"`
+	Method string              `json:"Method"`
+	Unit   string              `json:"Unit"` // weight unit for volume methods, "AU" for srpe
+	Series []LoadPointResponse `json:"Series"`
+}
+
+/*
+"/reports/load?from=2024-01-01&to=2024-03-31&method=srpe"
+optional params: from (default 8 weeks before to), to (default today), method ('volume_rpe' (default), 'srpe', 'volume'), units
+*/
+func (h *ReportHandler) HandleLoadReport(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		response.SendError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	userID, err := middleware.GetUserIDFromContext(r.Context())
+	if err != nil {
+		response.SendError(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	from, to, ok := parseReportRange(w, r, defaultLoadReportWeeks)
+	if !ok {
+		return
+	}
+	if to.Sub(from) > maxLoadReportDays*24*time.Hour {
+		response.SendError(w, "Load reports cover at most 2 years", http.StatusBadRequest)
+		return
+	}
+
+	method := defaultLoadMethod
+	if m := r.URL.Query().Get("method"); m != "" {
+		if !loadMethods[m] {
+			response.SendError(w, "method must be one of 'volume_rpe', 'srpe', 'volume'", http.StatusBadRequest)
+			return
+		}
+		method = m
+	}
+
+	units, ok := resolveRequestUnits(w, r, h.queries)
+	if !ok {
+		return
+	}
+
+	// the first day's chronic window reaches back 27 days before "from"
+	rows, err := h.queries.GetDailyTrainingLoads(r.Context(), sqlc.GetDailyTrainingLoadsParams{
+		UserID:   int32(userID),
+		FromDate: from.AddDate(0, 0, -(training.ChronicWindowDays - 1)),
+		ToDate:   to,
+	})
+	if err != nil {
+		response.SendError(w, "Failed to generate load report", http.StatusInternalServerError)
+		return
+	}
+
+	unit := utils.WeightUnit(units)
+	if method == "srpe" {
+		unit = "AU"
+	}
+
+	loads := make([]training.DailyLoad, len(rows))
+	rowsByDate := make(map[string]sqlc.DailyTrainingLoad, len(rows))
+	for i, row := range rows {
+		loads[i] = training.DailyLoad{Date: row.LoadDate, Load: dailyLoadValue(row, method, units)}
+		rowsByDate[row.LoadDate.Format("2006-01-02")] = row
+	}
+
+	points := training.LoadSeries(loads, from, to)
+	series := make([]LoadPointResponse, len(points))
+	for i, point := range points {
+		date := point.Date.Format("2006-01-02")
+		series[i] = LoadPointResponse{
+			Date:     date,
+			Sessions: rowsByDate[date].Sessions,
+			HardSets: rowsByDate[date].HardSets,
+			Load:     roundTo2(point.Load),
+			Acute:    point.Acute,
+			Chronic:  point.Chronic,
+			ACWR:     point.ACWR,
+			Monotony: point.Monotony,
+			Strain:   point.Strain,
+		}
+	}
+
+	response.SendSuccess(w, LoadReportResponse{
+		From:   from.Format("2006-01-02"),
+		To:     to.Format("2006-01-02"),
+		Method: method,
+		Unit:   unit,
+		Series: series,
+	})
+}
+
+func dailyLoadValue(row sqlc.DailyTrainingLoad, method string, units sqlc.UnitSystemEnum) float64 {
+	switch method {
+	case "srpe":
+		load, _ := strconv.ParseFloat(row.SrpeLoad, 64)
+		return load
+	case "volume":
+		load, _ := strconv.ParseFloat(row.VolumeKg, 64)
+		return utils.FromKgFloat(load, units)
+	default:
+		load, _ := strconv.ParseFloat(row.VolumeRpeLoad, 64)
+		return utils.FromKgFloat(load, units)
+	}
+}
@@ -24,12 +24,16 @@ func NewUserProfileByIDHandler(q *sqlc.Queries) *UserProfileByIDHandler {
 }
 
 type UpdateUserProfileRequest struct {
-	FirstName    string `json:"first_name,omitempty"`
-	LastName     string `json:"last_name,omitempty"`
-	HeightInches int32  `json:"height_inches,omitempty"`
-	WeightPounds int32  `json:"weight_pounds,omitempty"`
-	Gender       string `json:"gender,omitempty"`
-	DateOfBirth  string `json:"date_of_birth,omitempty"`
+	FirstName      string   `json:"first_name,omitempty"`
+	LastName       string   `json:"last_name,omitempty"`
+	Height         *float64 `json:"height,omitempty"` // inches or cm, per unit preference (or "?units=")
+	Weight         *float64 `json:"weight,omitempty"` // lbs or kg, per unit preference (or "?units=")
+	Gender         string   `json:"gender,omitempty"`
+	DateOfBirth    string   `json:"date_of_birth,omitempty"`
+	UnitPreference *string  `json:"unit_preference,omitempty"` // 'metric' or 'imperial'; height & weight in the same request use the new preference
+	// 1-14 sessions a week; 0 removes the target & leaving it out keeps the current one
+	WeeklySessionTarget *int32  `json:"weekly_session_target,omitempty"`
+	Timezone            *string `json:"timezone,omitempty"` // IANA name, e.g. "Europe/London"
 }
 
 func (h *UserProfileByIDHandler) HandleUserProfilesByID(w http.ResponseWriter, r *http.Request) {
@@ -64,7 +68,13 @@ func (h *UserProfileByIDHandler) GetUserProfile(w http.ResponseWriter, r *http.R
 		return
 	}
 
-	response.SendSuccess(w, userProfile)
+	units, err := unitsForProfile(r, userProfile.UnitPreference)
+	if err != nil {
+		response.SendError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	response.SendSuccess(w, toUserProfileResponse(userProfile, units, nil))
 }
 
 // "/user-profiles/{id}"
@@ -75,13 +85,52 @@ func (h *UserProfileByIDHandler) UpdateUserProfile(w http.ResponseWriter, r *htt
 		return
 	}
 
+	// the incoming height & weight are in the (possibly new) preferred units, so the current profile is needed first
+	currentProfile, err := h.queries.GetUserProfile(r.Context(), utils.ToNullInt32(id))
+	if errors.Is(err, sql.ErrNoRows) {
+		response.SendError(w, "User profile not found", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		response.SendError(w, "Failed to retrieve user profile", http.StatusInternalServerError)
+		return
+	}
+
+	preference := currentProfile.UnitPreference
+	if request.UnitPreference != nil {
+		preference = sqlc.UnitSystemEnum(*request.UnitPreference)
+		if !isValidUnitSystem(preference) {
+			response.SendError(w, "unit_preference must be 'metric' or 'imperial'", http.StatusBadRequest)
+			return
+		}
+	}
+
+	if target := request.WeeklySessionTarget; target != nil && *target != 0 && !isValidWeeklySessionTarget(*target) {
+		response.SendError(w, "weekly_session_target must be between 1 and 14 (or 0 to remove it)", http.StatusBadRequest)
+		return
+	}
+
+	if request.Timezone != nil && !isValidTimezone(*request.Timezone) {
+		response.SendError(w, "timezone must be an IANA name like 'Europe/London'", http.StatusBadRequest)
+		return
+	}
+
+	units, err := unitsForProfile(r, preference)
+	if err != nil {
+		response.SendError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
 	params := sqlc.UpdateUserProfileParams{
-		UserID:       utils.ToNullInt32(id),
-		FirstName:    utils.ToNullString(request.FirstName),
-		LastName:     utils.ToNullString(request.LastName),
-		HeightInches: utils.ToNullInt32(request.HeightInches),
-		WeightPounds: utils.ToNullInt32(request.WeightPounds),
-		Gender:       utils.ToNullString(request.Gender),
+		UserID:              utils.ToNullInt32(id),
+		FirstName:           utils.ToNullString(request.FirstName),
+		LastName:            utils.ToNullString(request.LastName),
+		HeightCm:            utils.ToCmFromFloatPtr(request.Height, units),
+		WeightKg:            utils.ToKgFromFloatPtr(request.Weight, units),
+		Gender:              utils.ToNullString(request.Gender),
+		UnitPreference:      sqlc.NullUnitSystemEnum{UnitSystemEnum: preference, Valid: true},
+		WeeklySessionTarget: utils.ToNullInt32FromIntPtr(request.WeeklySessionTarget),
+		Timezone:            utils.ToNullStringFromStringPtr(request.Timezone),
 	}
 
 	if request.DateOfBirth != "" {
@@ -103,7 +152,7 @@ func (h *UserProfileByIDHandler) UpdateUserProfile(w http.ResponseWriter, r *htt
 		return
 	}
 
-	response.SendSuccess(w, userProfile)
+	response.SendSuccess(w, toUserProfileResponse(userProfile, units, nil))
 }
 
 // "/user-profiles/{id}"
@@ -118,8 +167,13 @@ func (h *UserProfileByIDHandler) DeleteUserProfile(w http.ResponseWriter, r *htt
 		return
 	}
 
+	units, err := unitsForProfile(r, deletedUserProfile.UnitPreference)
+	if err != nil {
+		units = deletedUserProfile.UnitPreference // already deleted, so don't fail over a bad "?units="
+	}
+
 	response.SendSuccess(w, map[string]interface{}{
 		"message": "User profile (soft-) deleted successfully",
-		"id":      deletedUserProfile,
+		"id":      toUserProfileResponse(deletedUserProfile, units, nil),
 	}, http.StatusOK) // Not StatusNoContent bc this is a soft delete)
 }
@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"database/sql"
 	"encoding/json"
 	"net/http"
 	"time"
@@ -21,12 +22,39 @@ func NewUserProfileHandler(q *sqlc.Queries) *UserProfileHandler {
 }
 
 type CreateUserProfileRequest struct {
-	UserID       int32  `json:"user_id"`
-	FirstName    string `json:"first_name"`
-	LastName     string `json:"last_name"`
-	DateOfBirth  string `json:"date_of_birth"`
-	Gender       string `json:"gender"`
-	HeightInches int32  `json:"height_inches"`
+	UserID         int32    `json:"user_id"`
+	FirstName      string   `json:"first_name"`
+	LastName       string   `json:"last_name"`
+	DateOfBirth    string   `json:"date_of_birth"`
+	Gender         string   `json:"gender"`
+	UnitPreference *string  `json:"unit_preference"` // 'metric' or 'imperial' (default)
+	Height         *float64 `json:"height"`          // inches or cm, per unit_preference (or "?units=")
+	Weight         *float64 `json:"weight"`          // lbs or kg, per unit_preference (or "?units=")
+	// optional; sessions per week the calendar's adherence is measured against (1-14)
+	WeeklySessionTarget *int32 `json:"weekly_session_target"`
+	// optional IANA name, e.g. "Europe/London"; the calendar feed uses it & it defaults to UTC
+	Timezone *string `json:"timezone"`
+}
+
+// Height & weight are stored in cm & kg but sent back in the profile's preferred units (or "?units=")
+type UserProfileResponse struct {
+	ProfileID           int32               `json:"ProfileID"`
+	UserID              sql.NullInt32       `json:"UserID"`
+	FirstName           sql.NullString      `json:"FirstName"`
+	LastName            sql.NullString      `json:"LastName"`
+	DateOfBirth         sql.NullTime        `json:"DateOfBirth"`
+	Gender              sql.NullString      `json:"Gender"`
+	ProfilePictureUrl   sql.NullString      `json:"ProfilePictureUrl"`
+	UnitPreference      sqlc.UnitSystemEnum `json:"UnitPreference"`
+	Height              sql.NullString      `json:"Height"`
+	HeightUnit          string              `json:"HeightUnit"`
+	Weight              sql.NullString      `json:"Weight"`
+	WeightUnit          string              `json:"WeightUnit"`
+	WeeklySessionTarget sql.NullInt32       `json:"WeeklySessionTarget"`
+	Timezone            sql.NullString      `json:"Timezone"`
+	Active              *bool               `json:"Active,omitempty"` // only for the active/inactive listings
+	CreatedAt           sql.NullTime        `json:"CreatedAt"`
+	UpdatedAt           sql.NullTime        `json:"UpdatedAt"`
 }
 
 func (h *UserProfileHandler) HandleUserProfiles(w http.ResponseWriter, r *http.Request) {
@@ -66,20 +94,49 @@ func (h *UserProfileHandler) CreateUserProfile(w http.ResponseWriter, r *http.Re
 		return
 	}
 
+	preference := utils.DefaultUnitSystem
+	if request.UnitPreference != nil {
+		preference = sqlc.UnitSystemEnum(*request.UnitPreference)
+		if !isValidUnitSystem(preference) {
+			response.SendError(w, "unit_preference must be 'metric' or 'imperial'", http.StatusBadRequest)
+			return
+		}
+	}
+
+	if request.WeeklySessionTarget != nil && !isValidWeeklySessionTarget(*request.WeeklySessionTarget) {
+		response.SendError(w, "weekly_session_target must be between 1 and 14", http.StatusBadRequest)
+		return
+	}
+
+	if request.Timezone != nil && !isValidTimezone(*request.Timezone) {
+		response.SendError(w, "timezone must be an IANA name like 'Europe/London'", http.StatusBadRequest)
+		return
+	}
+
+	units, err := unitsForProfile(r, preference)
+	if err != nil {
+		response.SendError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
 	profile, err := h.queries.CreateUserProfile(r.Context(), sqlc.CreateUserProfileParams{
-		UserID:       utils.ToNullInt32(request.UserID),
-		FirstName:    utils.ToNullString(request.FirstName),
-		LastName:     utils.ToNullString(request.LastName),
-		DateOfBirth:  utils.ToNullTime(dob),
-		Gender:       utils.ToNullString(request.Gender),
-		HeightInches: utils.ToNullInt32(request.HeightInches),
+		UserID:              utils.ToNullInt32(request.UserID),
+		FirstName:           utils.ToNullString(request.FirstName),
+		LastName:            utils.ToNullString(request.LastName),
+		DateOfBirth:         utils.ToNullTime(dob),
+		Gender:              utils.ToNullString(request.Gender),
+		HeightCm:            utils.ToCmFromFloatPtr(request.Height, units),
+		WeightKg:            utils.ToKgFromFloatPtr(request.Weight, units),
+		UnitPreference:      preference,
+		WeeklySessionTarget: utils.ToNullInt32FromIntPtr(request.WeeklySessionTarget),
+		Timezone:            utils.ToNullStringFromStringPtr(request.Timezone),
 	})
 	if err != nil {
 		response.SendError(w, "Failed to create user profile", http.StatusInternalServerError)
 		return
 	}
 
-	response.SendSuccess(w, profile, http.StatusCreated)
+	response.SendSuccess(w, toUserProfileResponse(profile, units, nil), http.StatusCreated)
 }
 
 // "/user-profiles"
@@ -90,7 +147,18 @@ func (h *UserProfileHandler) GetAllUserProfiles(w http.ResponseWriter, r *http.R
 		return
 	}
 
-	response.SendSuccess(w, userProfiles)
+	override, err := utils.ParseUnitsOverride(r)
+	if err != nil {
+		response.SendError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	profiles := make([]UserProfileResponse, len(userProfiles))
+	for i, profile := range userProfiles {
+		profiles[i] = toUserProfileResponse(profile, unitsOrPreference(override, profile.UnitPreference), nil)
+	}
+
+	response.SendSuccess(w, profiles)
 }
 
 // "/user-profiles?active=true"
@@ -101,7 +169,33 @@ func (h *UserProfileHandler) GetAllActiveUserProfiles(w http.ResponseWriter, r *
 		return
 	}
 
-	response.SendSuccess(w, activeUserProfiles)
+	override, err := utils.ParseUnitsOverride(r)
+	if err != nil {
+		response.SendError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	profiles := make([]UserProfileResponse, len(activeUserProfiles))
+	for i, row := range activeUserProfiles {
+		profiles[i] = toUserProfileResponse(sqlc.UserProfile{
+			ProfileID:           row.ProfileID,
+			UserID:              row.UserID,
+			FirstName:           row.FirstName,
+			LastName:            row.LastName,
+			DateOfBirth:         row.DateOfBirth,
+			Gender:              row.Gender,
+			ProfilePictureUrl:   row.ProfilePictureUrl,
+			CreatedAt:           row.CreatedAt,
+			UpdatedAt:           row.UpdatedAt,
+			UnitPreference:      row.UnitPreference,
+			HeightCm:            row.HeightCm,
+			WeightKg:            row.WeightKg,
+			WeeklySessionTarget: row.WeeklySessionTarget,
+			Timezone:            row.Timezone,
+		}, unitsOrPreference(override, row.UnitPreference), &row.Active.Bool)
+	}
+
+	response.SendSuccess(w, profiles)
 }
 
 // "/user-profiles?active=false"
@@ -112,5 +206,86 @@ func (h *UserProfileHandler) GetAllInactiveUserProfiles(w http.ResponseWriter, r
 		return
 	}
 
-	response.SendSuccess(w, inactiveUserProfiles)
+	override, err := utils.ParseUnitsOverride(r)
+	if err != nil {
+		response.SendError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	profiles := make([]UserProfileResponse, len(inactiveUserProfiles))
+	for i, row := range inactiveUserProfiles {
+		profiles[i] = toUserProfileResponse(sqlc.UserProfile{
+			ProfileID:           row.ProfileID,
+			UserID:              row.UserID,
+			FirstName:           row.FirstName,
+			LastName:            row.LastName,
+			DateOfBirth:         row.DateOfBirth,
+			Gender:              row.Gender,
+			ProfilePictureUrl:   row.ProfilePictureUrl,
+			CreatedAt:           row.CreatedAt,
+			UpdatedAt:           row.UpdatedAt,
+			UnitPreference:      row.UnitPreference,
+			HeightCm:            row.HeightCm,
+			WeightKg:            row.WeightKg,
+			WeeklySessionTarget: row.WeeklySessionTarget,
+			Timezone:            row.Timezone,
+		}, unitsOrPreference(override, row.UnitPreference), &row.Active.Bool)
+	}
+
+	response.SendSuccess(w, profiles)
+}
+
+func toUserProfileResponse(profile sqlc.UserProfile, units sqlc.UnitSystemEnum, active *bool) UserProfileResponse {
+	return UserProfileResponse{
+		ProfileID:           profile.ProfileID,
+		UserID:              profile.UserID,
+		FirstName:           profile.FirstName,
+		LastName:            profile.LastName,
+		DateOfBirth:         profile.DateOfBirth,
+		Gender:              profile.Gender,
+		ProfilePictureUrl:   profile.ProfilePictureUrl,
+		UnitPreference:      profile.UnitPreference,
+		Height:              utils.FromCm(profile.HeightCm, units),
+		HeightUnit:          utils.HeightUnit(units),
+		Weight:              utils.FromKg(profile.WeightKg, units),
+		WeightUnit:          utils.WeightUnit(units),
+		WeeklySessionTarget: profile.WeeklySessionTarget,
+		Timezone:            profile.Timezone,
+		Active:              active,
+		CreatedAt:           profile.CreatedAt,
+		UpdatedAt:           profile.UpdatedAt,
+	}
+}
+
+// "?units=" overrides the profile's own preference
+func unitsForProfile(r *http.Request, preference sqlc.UnitSystemEnum) (sqlc.UnitSystemEnum, error) {
+	override, err := utils.ParseUnitsOverride(r)
+	if err != nil {
+		return "", err
+	}
+	return unitsOrPreference(override, preference), nil
+}
+
+func unitsOrPreference(override sqlc.NullUnitSystemEnum, preference sqlc.UnitSystemEnum) sqlc.UnitSystemEnum {
+	if override.Valid {
+		return override.UnitSystemEnum
+	}
+	return preference
+}
+
+func isValidUnitSystem(units sqlc.UnitSystemEnum) bool {
+	return units == sqlc.UnitSystemEnumMetric || units == sqlc.UnitSystemEnumImperial
+}
+
+func isValidWeeklySessionTarget(target int32) bool {
+	return target >= 1 && target <= 14
+}
+
+// "" & "Local" load fine but mean nothing outside the server, so they're rejected
+func isValidTimezone(name string) bool {
+	if name == "" || name == "Local" {
+		return false
+	}
+	_, err := time.LoadLocation(name)
+	return err == nil
 }
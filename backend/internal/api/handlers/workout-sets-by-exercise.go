@@ -1,26 +1,37 @@
 package handlers
 
 import (
+	"database/sql"
+	"encoding/json"
 	"net/http"
+	"sort"
 	"strconv"
 	"strings"
 
+	"go-reppy/backend/internal/api/middleware"
 	"go-reppy/backend/internal/api/response"
+	"go-reppy/backend/internal/api/utils"
 	"go-reppy/backend/internal/database/sqlc"
 )
 
 type WorkoutSetByExerciseHandler struct {
+	db        *sql.DB // for swapping sets & re-checking their personal records atomically
 	queries   *sqlc.Queries
 	jwtSecret []byte
 }
 
-func NewWorkoutSetByExerciseHandler(q *sqlc.Queries, jwtSecret []byte) *WorkoutSetByExerciseHandler {
+func NewWorkoutSetByExerciseHandler(db *sql.DB, q *sqlc.Queries, jwtSecret []byte) *WorkoutSetByExerciseHandler {
 	return &WorkoutSetByExerciseHandler{
+		db:        db,
 		queries:   q,
 		jwtSecret: jwtSecret,
 	}
 }
 
+type SwapExerciseRequest struct {
+	ExerciseID int32 `json:"exercise_id"` // the alternative to swap in
+}
+
 func (h *WorkoutSetByExerciseHandler) HandleWorkoutSetsByExercise(w http.ResponseWriter, r *http.Request) {
 	pathParts := strings.Split(r.URL.Path, "/")
 	if len(pathParts) < 5 {
@@ -62,3 +73,193 @@ func (h *WorkoutSetByExerciseHandler) DeleteWorkoutSetsByExercise(w http.Respons
 
 	response.SendSuccess(w, nil, http.StatusNoContent)
 }
+
+/*
+"/workouts/3/exercises/1/swap"
+sample req body (see GET /exercises/{id}/alternatives for candidates):
+
+	{
+	  "exercise_id": 7
+	}
+
+Only sets that haven't been completed are moved, so this works mid-session too.
+*/
+func (h *WorkoutSetByExerciseHandler) HandleSwapExercise(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		response.SendError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	pathParts := strings.Split(r.URL.Path, "/")
+	if len(pathParts) < 6 {
+		response.SendError(w, "Invalid path URL", http.StatusBadRequest)
+		return
+	}
+
+	workoutID, err := strconv.ParseInt(pathParts[2], 10, 32)
+	if err != nil {
+		response.SendError(w, "Invalid workout ID", http.StatusBadRequest)
+		return
+	}
+
+	fromExerciseID, err := strconv.ParseInt(pathParts[4], 10, 32)
+	if err != nil {
+		response.SendError(w, "Invalid exercise ID", http.StatusBadRequest)
+		return
+	}
+
+	var request SwapExerciseRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		response.SendError(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	if request.ExerciseID <= 0 {
+		response.SendError(w, "Exercise ID must be provided", http.StatusBadRequest)
+		return
+	}
+	if request.ExerciseID == int32(fromExerciseID) {
+		response.SendError(w, "Can't swap an exercise for itself", http.StatusBadRequest)
+		return
+	}
+
+	userID, err := middleware.GetUserIDFromContext(r.Context())
+	if err != nil {
+		response.SendError(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	workout, err := h.queries.GetWorkoutByIDForUser(r.Context(), sqlc.GetWorkoutByIDForUserParams{
+		WorkoutID: int32(workoutID),
+		UserID:    utils.ToNullInt32(userID),
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			response.SendError(w, "Workout not found", http.StatusNotFound)
+			return
+		}
+		response.SendError(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+	if workout.EndedAt.Valid {
+		response.SendError(w, "Workout has already been finished", http.StatusConflict)
+		return
+	}
+
+	fromExercise, err := h.queries.GetExerciseById(r.Context(), int32(fromExerciseID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			response.SendError(w, "Exercise not found", http.StatusNotFound)
+			return
+		}
+		response.SendError(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	toExercise, err := h.queries.GetExerciseByIDForUser(r.Context(), sqlc.GetExerciseByIDForUserParams{
+		ExerciseID: request.ExerciseID,
+		UserID:     utils.ToNullInt32(userID),
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			response.SendError(w, "Exercise not found", http.StatusBadRequest)
+			return
+		}
+		response.SendError(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	// the planned targets only carry over if both exercises are logged the same way
+	if toExercise.TrackingMode != fromExercise.TrackingMode {
+		response.SendError(w, "Can only swap in an exercise with the same tracking_mode ('"+string(fromExercise.TrackingMode)+"')", http.StatusBadRequest)
+		return
+	}
+
+	// set numbers are per exercise, so merging into sets that already exist would duplicate them
+	alreadyPlanned, err := h.queries.WorkoutHasExercise(r.Context(), sqlc.WorkoutHasExerciseParams{
+		WorkoutID:  int32(workoutID),
+		ExerciseID: request.ExerciseID,
+	})
+	if err != nil {
+		response.SendError(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+	if alreadyPlanned {
+		response.SendError(w, "That exercise is already in this workout", http.StatusConflict)
+		return
+	}
+
+	units, ok := resolveRequestUnits(w, r, h.queries)
+	if !ok {
+		return
+	}
+
+	today, err := utils.ClientToday(r)
+	if err != nil {
+		response.SendError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	tx, err := h.db.BeginTx(r.Context(), nil)
+	if err != nil {
+		response.SendError(w, "Failed to swap exercise", http.StatusInternalServerError)
+		return
+	}
+	defer tx.Rollback()
+	qtx := h.queries.WithTx(tx)
+
+	sets, err := qtx.SwapWorkoutSetsExercise(r.Context(), sqlc.SwapWorkoutSetsExerciseParams{
+		ToExerciseID:   request.ExerciseID,
+		WorkoutID:      int32(workoutID),
+		FromExerciseID: int32(fromExerciseID),
+	})
+	if err != nil {
+		response.SendError(w, "Failed to swap exercise", http.StatusInternalServerError)
+		return
+	}
+	if len(sets) == 0 {
+		hasSets, err := qtx.WorkoutHasExercise(r.Context(), sqlc.WorkoutHasExerciseParams{
+			WorkoutID:  int32(workoutID),
+			ExerciseID: int32(fromExerciseID),
+		})
+		if err != nil {
+			response.SendError(w, "Internal server error", http.StatusInternalServerError)
+			return
+		}
+		if hasSets {
+			response.SendError(w, "Every set of this exercise has already been completed", http.StatusConflict)
+			return
+		}
+		response.SendError(w, "Exercise has no sets in this workout", http.StatusNotFound)
+		return
+	}
+	sort.Slice(sets, func(i, j int) bool { return sets[i].OverallWorkoutSetNumber < sets[j].OverallWorkoutSetNumber })
+
+	// records the moved sets held belong to the old exercise, which gets back whatever they had beaten; the sets are
+	// then checked again against the new exercise's records
+	setNumbers := make([]int32, len(sets))
+	for i, set := range sets {
+		setNumbers[i] = set.OverallWorkoutSetNumber
+	}
+	if err := rebuildPersonalRecords(r.Context(), qtx, int32(userID), int32(workoutID), int32(fromExerciseID), setNumbers); err != nil {
+		response.SendError(w, "Failed to swap exercise", http.StatusInternalServerError)
+		return
+	}
+
+	records, err := detectPersonalRecords(r.Context(), qtx, int32(userID), sets)
+	if err != nil {
+		response.SendError(w, "Failed to check personal records", http.StatusInternalServerError)
+		return
+	}
+
+	if err := detectCompletedGoals(r.Context(), qtx, int32(userID), today); err != nil {
+		response.SendError(w, "Failed to check goals", http.StatusInternalServerError)
+		return
+	}
+
+	if err := tx.Commit(); err != nil {
+		response.SendError(w, "Failed to swap exercise", http.StatusInternalServerError)
+		return
+	}
+
+	response.SendSuccess(w, workoutSetsWithRecords(sets, records, units))
+}
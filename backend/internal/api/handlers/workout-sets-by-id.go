@@ -1,23 +1,27 @@
 package handlers
 
 import (
+	"database/sql"
 	"encoding/json"
 	"net/http"
 	"strconv"
 	"strings"
 
+	"go-reppy/backend/internal/api/middleware"
 	"go-reppy/backend/internal/api/response"
 	"go-reppy/backend/internal/api/utils"
 	"go-reppy/backend/internal/database/sqlc"
 )
 
 type WorkoutSetByIDHandler struct {
+	db        *sql.DB // for saving or deleting a set & its personal records atomically
 	queries   *sqlc.Queries
 	jwtSecret []byte
 }
 
-func NewWorkoutSetByIDHandler(q *sqlc.Queries, jwtSecret []byte) *WorkoutSetByIDHandler {
+func NewWorkoutSetByIDHandler(db *sql.DB, q *sqlc.Queries, jwtSecret []byte) *WorkoutSetByIDHandler {
 	return &WorkoutSetByIDHandler{
+		db:        db,
 		queries:   q,
 		jwtSecret: jwtSecret,
 	}
@@ -31,6 +35,12 @@ type UpdateWorkoutSetByIDRequest struct {
 	ResistanceDetail *string `json:"resistance_detail"`
 	RPE              *string `json:"rpe"`
 	Notes            *string `json:"notes"`
+	SetType          *string `json:"set_type"`          // omit to keep the current type (and drop set link)
+	ParentSetNumber  *int32  `json:"parent_set_number"` // required when changing set_type to 'drop'
+	DurationSeconds  *int32  `json:"duration_seconds"`
+	Distance         *string `json:"distance"`
+	DistanceUnit     *string `json:"distance_unit"` // 'm' (default), 'km', 'mi', 'yd', 'ft'
+	Calories         *int32  `json:"calories"`
 }
 
 func (h *WorkoutSetByIDHandler) HandleWorkoutSetByID(w http.ResponseWriter, r *http.Request) {
@@ -71,37 +81,282 @@ func (h *WorkoutSetByIDHandler) UpdateWorkoutSetByID(w http.ResponseWriter, r *h
 		return
 	}
 
-	set, err := h.queries.UpdateWorkoutSetByID(r.Context(), sqlc.UpdateWorkoutSetByIDParams{
+	if err := validateSetType(request.SetType, request.ParentSetNumber); err != nil {
+		response.SendError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	userID, err := middleware.GetUserIDFromContext(r.Context())
+	if err != nil {
+		response.SendError(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	// records & goal progress are saved for the caller, so the set has to be in one of their own workouts
+	if _, err := h.queries.GetWorkoutByIDForUser(r.Context(), sqlc.GetWorkoutByIDForUserParams{
+		WorkoutID: workoutID,
+		UserID:    utils.ToNullInt32(userID),
+	}); err != nil {
+		if err == sql.ErrNoRows {
+			response.SendError(w, "Workout not found", http.StatusNotFound)
+			return
+		}
+		response.SendError(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	trackingMode, err := h.queries.GetExerciseTrackingModeForSet(r.Context(), sqlc.GetExerciseTrackingModeForSetParams{
+		WorkoutID:               workoutID,
+		OverallWorkoutSetNumber: overallSetNumber,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			response.SendError(w, "Workout set not found", http.StatusNotFound)
+			return
+		}
+		response.SendError(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	metrics := setMetricFields{
+		Reps:             request.Reps,
+		ResistanceValue:  request.ResistanceValue,
+		ResistanceType:   request.ResistanceType,
+		ResistanceDetail: request.ResistanceDetail,
+		DurationSeconds:  request.DurationSeconds,
+		Distance:         request.Distance,
+		DistanceUnit:     request.DistanceUnit,
+		Calories:         request.Calories,
+	}
+	distanceMeters, err := metrics.validate(trackingMode)
+	if err != nil {
+		response.SendError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	var distanceUnit sqlc.NullDistanceUnitEnum
+	if request.Distance != nil {
+		distanceUnit = sqlc.NullDistanceUnitEnum{DistanceUnitEnum: sqlc.DistanceUnitEnum(metrics.distanceUnit()), Valid: true}
+	}
+
+	units, ok := resolveRequestUnits(w, r, h.queries)
+	if !ok {
+		return
+	}
+	resistanceKg := sql.NullString{}
+	if request.ResistanceValue != nil {
+		kg, err := utils.ToKgString(*request.ResistanceValue, units)
+		if err != nil {
+			response.SendError(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		resistanceKg = sql.NullString{String: kg, Valid: kg != ""}
+	}
+
+	today, err := utils.ClientToday(r)
+	if err != nil {
+		response.SendError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	tx, err := h.db.BeginTx(r.Context(), nil)
+	if err != nil {
+		response.SendError(w, "Failed to update workout set", http.StatusInternalServerError)
+		return
+	}
+	defer tx.Rollback()
+	qtx := h.queries.WithTx(tx)
+
+	set, err := qtx.UpdateWorkoutSetByID(r.Context(), sqlc.UpdateWorkoutSetByIDParams{
 		WorkoutID:               workoutID,
 		OverallWorkoutSetNumber: overallSetNumber,
 		Reps:                    utils.ToNullInt32FromIntPtr(request.Reps),
-		ResistanceValue:         utils.ToNullStringFromStringPtr(request.ResistanceValue),
+		ResistanceValue:         resistanceKg,
 		ResistanceType:          utils.ToNullResistanceTypeEnumFromStringPtr(request.ResistanceType),
 		ResistanceDetail:        utils.ToNullStringFromStringPtr(request.ResistanceDetail),
 		Rpe:                     utils.ToNullStringFromStringPtr(request.RPE),
 		Notes:                   utils.ToNullStringFromStringPtr(request.Notes),
+		DurationSeconds:         utils.ToNullInt32FromIntPtr(request.DurationSeconds),
+		DistanceMeters:          sql.NullString{String: distanceMeters, Valid: request.Distance != nil},
+		DistanceUnit:            distanceUnit,
+		Calories:                utils.ToNullInt32FromIntPtr(request.Calories),
+		SetType:                 utils.ToNullSetTypeEnumFromStringPtr(request.SetType),
+		ParentSetNumber:         utils.ToNullInt32FromIntPtr(request.ParentSetNumber),
 	})
 	if err != nil {
+		if err == sql.ErrNoRows {
+			response.SendError(w, "Workout set not found", http.StatusNotFound)
+			return
+		}
+		if strings.Contains(err.Error(), "workout_sets_parent_set_fkey") {
+			response.SendError(w, "Parent set not found in this workout", http.StatusBadRequest)
+			return
+		}
+		if strings.Contains(err.Error(), "workout_sets_parent_not_self") {
+			response.SendError(w, "A drop set can't be its own parent", http.StatusBadRequest)
+			return
+		}
 		response.SendError(w, "Failed to update workout set", http.StatusInternalServerError)
 		return
 	}
 
-	response.SendSuccess(w, set)
+	// the old values may have held records (or a typo may have), so the exercise's records are rebuilt rather than only moved forward
+	if err := rebuildPersonalRecords(r.Context(), qtx, int32(userID), workoutID, set.ExerciseID, []int32{set.OverallWorkoutSetNumber}); err != nil {
+		response.SendError(w, "Failed to check personal records", http.StatusInternalServerError)
+		return
+	}
+
+	records, err := heldPersonalRecords(r.Context(), qtx, int32(userID), set)
+	if err != nil {
+		response.SendError(w, "Failed to check personal records", http.StatusInternalServerError)
+		return
+	}
+
+	if err := detectCompletedGoals(r.Context(), qtx, int32(userID), today); err != nil {
+		response.SendError(w, "Failed to check goals", http.StatusInternalServerError)
+		return
+	}
+
+	if err := tx.Commit(); err != nil {
+		response.SendError(w, "Failed to update workout set", http.StatusInternalServerError)
+		return
+	}
+
+	response.SendSuccess(w, workoutSetsWithRecords([]sqlc.WorkoutSet{set}, records, units)[0])
 }
 
 // "/workouts/3/workout-sets/7"
 func (h *WorkoutSetByIDHandler) DeleteWorkoutSetByID(w http.ResponseWriter, r *http.Request, workoutID, overallSetNumber int32) {
-	deletedWorkoutSet, err := h.queries.DeleteWorkoutSetByID(r.Context(), sqlc.DeleteWorkoutSetByIDParams{
+	userID, err := middleware.GetUserIDFromContext(r.Context())
+	if err != nil {
+		response.SendError(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	// the caller's records are rebuilt afterwards, so the set has to be in one of their own workouts
+	if _, err := h.queries.GetWorkoutByIDForUser(r.Context(), sqlc.GetWorkoutByIDForUserParams{
+		WorkoutID: workoutID,
+		UserID:    utils.ToNullInt32(userID),
+	}); err != nil {
+		if err == sql.ErrNoRows {
+			response.SendError(w, "Workout not found", http.StatusNotFound)
+			return
+		}
+		response.SendError(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	units, ok := resolveRequestUnits(w, r, h.queries)
+	if !ok {
+		return
+	}
+
+	// the set & the rebuilt records it leaves behind are saved together
+	tx, err := h.db.BeginTx(r.Context(), nil)
+	if err != nil {
+		response.SendError(w, "Failed to delete workout set", http.StatusInternalServerError)
+		return
+	}
+	defer tx.Rollback()
+	qtx := h.queries.WithTx(tx)
+
+	deletedWorkoutSet, err := qtx.DeleteWorkoutSetByID(r.Context(), sqlc.DeleteWorkoutSetByIDParams{
 		WorkoutID:               workoutID,
 		OverallWorkoutSetNumber: overallSetNumber,
 	})
 	if err != nil {
+		if err == sql.ErrNoRows {
+			response.SendError(w, "Workout set not found", http.StatusNotFound)
+			return
+		}
+		response.SendError(w, "Failed to delete workout set", http.StatusInternalServerError)
+		return
+	}
+
+	// the set's own records went with it; whatever it had beaten (& the workout's session volume) needs putting back
+	if err := rebuildPersonalRecords(r.Context(), qtx, int32(userID), workoutID, deletedWorkoutSet.ExerciseID, nil); err != nil {
+		response.SendError(w, "Failed to update personal records", http.StatusInternalServerError)
+		return
+	}
+
+	if err := tx.Commit(); err != nil {
 		response.SendError(w, "Failed to delete workout set", http.StatusInternalServerError)
 		return
 	}
+	deletedWorkoutSet.ResistanceValue = utils.FromKg(deletedWorkoutSet.ResistanceValue, units)
 
 	response.SendSuccess(w, map[string]interface{}{
 		"message": "Muscle deleted successfully",
 		"id":      deletedWorkoutSet,
 	}, http.StatusOK)
 }
+
+// Marks a set as done during a live session; the workout must have been started and not yet finished.
+// "/workouts/3/workout-sets/7/complete"
+func (h *WorkoutSetByIDHandler) HandleCompleteWorkoutSet(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		response.SendError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	pathParts := strings.Split(r.URL.Path, "/")
+	if len(pathParts) < 6 {
+		response.SendError(w, "Invalid path URL", http.StatusBadRequest)
+		return
+	}
+
+	workoutID, err := strconv.ParseInt(pathParts[2], 10, 32)
+	if err != nil {
+		response.SendError(w, "Invalid workout ID", http.StatusBadRequest)
+		return
+	}
+
+	overallSetNumber, err := strconv.ParseInt(pathParts[4], 10, 32)
+	if err != nil {
+		response.SendError(w, "Invalid set number", http.StatusBadRequest)
+		return
+	}
+
+	userID, err := middleware.GetUserIDFromContext(r.Context())
+	if err != nil {
+		response.SendError(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	workout, err := h.queries.GetWorkoutByIDForUser(r.Context(), sqlc.GetWorkoutByIDForUserParams{
+		WorkoutID: int32(workoutID),
+		UserID:    utils.ToNullInt32(userID),
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			response.SendError(w, "Workout not found", http.StatusNotFound)
+			return
+		}
+		response.SendError(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+	if !workout.StartedAt.Valid || workout.EndedAt.Valid {
+		response.SendError(w, "Workout is not in progress", http.StatusConflict)
+		return
+	}
+
+	units, ok := resolveRequestUnits(w, r, h.queries)
+	if !ok {
+		return
+	}
+
+	set, err := h.queries.CompleteWorkoutSet(r.Context(), sqlc.CompleteWorkoutSetParams{
+		WorkoutID:               int32(workoutID),
+		OverallWorkoutSetNumber: int32(overallSetNumber),
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			response.SendError(w, "Workout set not found", http.StatusNotFound)
+			return
+		}
+		response.SendError(w, "Failed to complete workout set", http.StatusInternalServerError)
+		return
+	}
+
+	set.ResistanceValue = utils.FromKg(set.ResistanceValue, units)
+	response.SendSuccess(w, set)
+}
@@ -1,22 +1,30 @@
 package handlers
 
 import (
+	"bytes"
+	"database/sql"
 	"encoding/json"
+	"fmt"
+	"io"
 	"net/http"
 	"strconv"
 	"strings"
 
+	"go-reppy/backend/internal/api/middleware"
 	"go-reppy/backend/internal/api/response"
+	"go-reppy/backend/internal/api/utils"
 	"go-reppy/backend/internal/database/sqlc"
 )
 
 type WorkoutSetHandler struct {
+	db        *sql.DB // for saving sets, set groups & personal records atomically
 	queries   *sqlc.Queries
 	jwtSecret []byte
 }
 
-func NewWorkoutSetHandler(q *sqlc.Queries, jwtSecret []byte) *WorkoutSetHandler {
+func NewWorkoutSetHandler(db *sql.DB, q *sqlc.Queries, jwtSecret []byte) *WorkoutSetHandler {
 	return &WorkoutSetHandler{
+		db:        db,
 		queries:   q,
 		jwtSecret: jwtSecret,
 	}
@@ -33,6 +41,46 @@ type CreateWorkoutSetsRequest struct {
 	ResistanceDetail *string `json:"resistance_detail"`
 	RPE              *string `json:"rpe"`
 	Notes            *string `json:"notes"`
+	SetType          *string `json:"set_type"`          // 'warmup', 'working' (default), 'drop', 'amrap', 'failure', 'rest_pause'
+	ParentSetNumber  *int32  `json:"parent_set_number"` // drop sets only; the overall set number they drop from
+	DurationSeconds  *int32  `json:"duration_seconds"`
+	Distance         *string `json:"distance"`
+	DistanceUnit     *string `json:"distance_unit"` // 'm' (default), 'km', 'mi', 'yd', 'ft'
+	Calories         *int32  `json:"calories"`
+}
+
+// Grouped variant of the request above: every exercise in the group gets one set per round, and the
+// sets are interleaved round by round (A1, B1, A2, B2, ...) so overall set numbers follow the order they're performed.
+type CreateGroupedWorkoutSetsRequest struct {
+	GroupType                   string                 `json:"group_type"` // 'superset', 'giant_set', 'circuit', 'emom'
+	Rounds                      int32                  `json:"rounds"`
+	RestBetweenExercisesSeconds *int32                 `json:"rest_between_exercises_seconds"`
+	RestBetweenRoundsSeconds    *int32                 `json:"rest_between_rounds_seconds"`
+	IntervalSeconds             *int32                 `json:"interval_seconds"` // EMOM only, defaults to 60
+	Notes                       *string                `json:"notes"`
+	Exercises                   []GroupedExerciseInput `json:"exercises"`
+}
+
+type GroupedExerciseInput struct {
+	ExerciseID int32 `json:"exercise_id"`
+	// optional fields (nil if absent), applied to every round:
+	Reps             *int32  `json:"reps"`
+	ResistanceValue  *string `json:"resistance_value"`
+	ResistanceType   *string `json:"resistance_type"`
+	ResistanceDetail *string `json:"resistance_detail"`
+	RPE              *string `json:"rpe"`
+	Notes            *string `json:"notes"`
+	SetType          *string `json:"set_type"` // as for straight sets, except 'drop'
+	DurationSeconds  *int32  `json:"duration_seconds"`
+	Distance         *string `json:"distance"`
+	DistanceUnit     *string `json:"distance_unit"`
+	Calories         *int32  `json:"calories"`
+}
+
+// One entry per group (or run of straight sets) in the order they're performed
+type WorkoutSetBlock struct {
+	Group *sqlc.WorkoutSetGroup       `json:"Group"` // nil for straight sets
+	Sets  []sqlc.GetAllWorkoutSetsRow `json:"Sets"`
 }
 
 /*
@@ -52,6 +100,54 @@ sample minimal request:
   "exercise_id": 1,
   "number_of_sets": 3
 }
+
+sample drop set request (two drops off of set #4):
+{
+  "exercise_id": 1,
+  "number_of_sets": 2,
+  "reps": 8,
+  "resistance_value": "95",
+  "resistance_type": "weight",
+  "set_type": "drop",
+  "parent_set_number": 4
+}
+
+sample timed/distance requests (for a 'time' exercise like planks, then a 'time_distance' one like rowing):
+{
+  "exercise_id": 17,
+  "number_of_sets": 3,
+  "duration_seconds": 60
+}
+{
+  "exercise_id": 20,
+  "number_of_sets": 1,
+  "duration_seconds": 1200,
+  "distance": "5",
+  "distance_unit": "km",
+  "calories": 310
+}
+
+sample superset request (bench/row, 3 rounds, 90s between rounds):
+{
+  "group_type": "superset",
+  "rounds": 3,
+  "rest_between_exercises_seconds": 0,
+  "rest_between_rounds_seconds": 90,
+  "exercises": [
+    { "exercise_id": 1, "reps": 8, "resistance_value": "135", "resistance_type": "weight" },
+    { "exercise_id": 5, "reps": 10, "resistance_value": "115", "resistance_type": "weight" }
+  ]
+}
+
+sample warm-up round for a circuit (set_type applies to every round of that exercise):
+{
+  "group_type": "circuit",
+  "rounds": 1,
+  "exercises": [
+    { "exercise_id": 1, "reps": 10, "resistance_value": "45", "resistance_type": "weight", "set_type": "warmup" },
+    { "exercise_id": 5, "reps": 10, "resistance_value": "45", "resistance_type": "weight", "set_type": "warmup" }
+  ]
+}
 */
 
 // "/workouts/{workout_id}/workout-sets"
@@ -83,8 +179,46 @@ func (h *WorkoutSetHandler) HandleWorkoutSets(w http.ResponseWriter, r *http.Req
 
 // "/workouts/3/workout-sets"
 func (h *WorkoutSetHandler) CreateWorkoutSets(w http.ResponseWriter, r *http.Request, workoutID int32) {
+	userID, err := middleware.GetUserIDFromContext(r.Context())
+	if err != nil {
+		response.SendError(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	// records & goal progress are saved for the caller, so the sets have to go into one of their own workouts
+	if _, err := h.queries.GetWorkoutByIDForUser(r.Context(), sqlc.GetWorkoutByIDForUserParams{
+		WorkoutID: workoutID,
+		UserID:    utils.ToNullInt32(userID),
+	}); err != nil {
+		if err == sql.ErrNoRows {
+			response.SendError(w, "Workout not found", http.StatusNotFound)
+			return
+		}
+		response.SendError(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		response.SendError(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	// grouped requests are told apart by the presence of "group_type"
+	var probe struct {
+		GroupType *string `json:"group_type"`
+	}
+	if err := json.Unmarshal(body, &probe); err != nil {
+		response.SendError(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	if probe.GroupType != nil {
+		h.createGroupedWorkoutSets(w, r, workoutID, int32(userID), body)
+		return
+	}
+
 	var request CreateWorkoutSetsRequest
-	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+	if err := json.NewDecoder(bytes.NewReader(body)).Decode(&request); err != nil {
 		response.SendError(w, "Invalid request body", http.StatusBadRequest)
 		return
 	}
@@ -99,16 +233,66 @@ func (h *WorkoutSetHandler) CreateWorkoutSets(w http.ResponseWriter, r *http.Req
 		return
 	}
 
+	if err := validateSetType(request.SetType, request.ParentSetNumber); err != nil {
+		response.SendError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	exercise, err := h.queries.GetExerciseByIDForUser(r.Context(), sqlc.GetExerciseByIDForUserParams{
+		ExerciseID: request.ExerciseID,
+		UserID:     utils.ToNullInt32(userID),
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			response.SendError(w, "Exercise not found", http.StatusBadRequest)
+			return
+		}
+		response.SendError(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	metrics := setMetricFields{
+		Reps:             request.Reps,
+		ResistanceValue:  request.ResistanceValue,
+		ResistanceType:   request.ResistanceType,
+		ResistanceDetail: request.ResistanceDetail,
+		DurationSeconds:  request.DurationSeconds,
+		Distance:         request.Distance,
+		DistanceUnit:     request.DistanceUnit,
+		Calories:         request.Calories,
+	}
+	distanceMeters, err := metrics.validate(exercise.TrackingMode)
+	if err != nil {
+		response.SendError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	units, ok := resolveRequestUnits(w, r, h.queries)
+	if !ok {
+		return
+	}
+	resistanceKg, err := utils.ToKgString(derefString(request.ResistanceValue), units)
+	if err != nil {
+		response.SendError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
 	params := sqlc.CreateWorkoutSetsParams{
-		Column1: workoutID,                            // workout_id
-		Column2: request.ExerciseID,                   // exercise_id
-		Column3: make([]int32, request.NumberOfSets),  // set_number
-		Column4: make([]int32, request.NumberOfSets),  // reps
-		Column5: make([]string, request.NumberOfSets), // resistance_value
-		Column6: make([]string, request.NumberOfSets), // resistance_type
-		Column7: make([]string, request.NumberOfSets), // resistance_detail
-		Column8: make([]string, request.NumberOfSets), // rpe
-		Column9: make([]string, request.NumberOfSets), // notes
+		Column1:  workoutID,                            // workout_id
+		Column2:  request.ExerciseID,                   // exercise_id
+		Column3:  make([]int32, request.NumberOfSets),  // set_number
+		Column4:  make([]int32, request.NumberOfSets),  // reps
+		Column5:  make([]string, request.NumberOfSets), // resistance_value
+		Column6:  make([]string, request.NumberOfSets), // resistance_type
+		Column7:  make([]string, request.NumberOfSets), // resistance_detail
+		Column8:  make([]string, request.NumberOfSets), // rpe
+		Column9:  make([]string, request.NumberOfSets), // notes
+		Column10: make([]string, request.NumberOfSets), // set_type
+		Column11: make([]int32, request.NumberOfSets),  // parent_set_number
+		Column12: make([]int32, request.NumberOfSets),  // duration_seconds
+		Column13: make([]string, request.NumberOfSets), // distance_meters
+		Column14: make([]string, request.NumberOfSets), // distance_unit
+		Column15: make([]int32, request.NumberOfSets),  // calories
 	}
 
 	for i := int32(0); i < request.NumberOfSets; i++ {
@@ -117,9 +301,7 @@ func (h *WorkoutSetHandler) CreateWorkoutSets(w http.ResponseWriter, r *http.Req
 		if request.Reps != nil {
 			params.Column4[i] = *request.Reps
 		}
-		if request.ResistanceValue != nil {
-			params.Column5[i] = *request.ResistanceValue
-		}
+		params.Column5[i] = resistanceKg
 		if request.ResistanceType != nil {
 			params.Column6[i] = *request.ResistanceType
 		}
@@ -132,15 +314,259 @@ func (h *WorkoutSetHandler) CreateWorkoutSets(w http.ResponseWriter, r *http.Req
 		if request.Notes != nil {
 			params.Column9[i] = *request.Notes
 		}
+		if request.SetType != nil {
+			params.Column10[i] = *request.SetType
+		}
+		if request.ParentSetNumber != nil {
+			params.Column11[i] = *request.ParentSetNumber
+		}
+		if request.DurationSeconds != nil {
+			params.Column12[i] = *request.DurationSeconds
+		}
+		if request.Distance != nil {
+			params.Column13[i] = distanceMeters
+			params.Column14[i] = metrics.distanceUnit()
+		}
+		if request.Calories != nil {
+			params.Column15[i] = *request.Calories
+		}
+	}
+
+	today, err := utils.ClientToday(r)
+	if err != nil {
+		response.SendError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// sets, the records they break & the goals they complete are saved together
+	tx, err := h.db.BeginTx(r.Context(), nil)
+	if err != nil {
+		response.SendError(w, "Failed to create workout set(s)", http.StatusInternalServerError)
+		return
+	}
+	defer tx.Rollback()
+	qtx := h.queries.WithTx(tx)
+
+	sets, err := qtx.CreateWorkoutSets(r.Context(), params)
+	if err != nil {
+		if strings.Contains(err.Error(), "workout_sets_parent_set_fkey") {
+			response.SendError(w, "Parent set not found in this workout", http.StatusBadRequest)
+			return
+		}
+		response.SendError(w, "Failed to create workout set(s)", http.StatusInternalServerError)
+		return
+	}
+
+	records, err := detectPersonalRecords(r.Context(), qtx, int32(userID), sets)
+	if err != nil {
+		response.SendError(w, "Failed to check personal records", http.StatusInternalServerError)
+		return
+	}
+
+	if err := detectCompletedGoals(r.Context(), qtx, int32(userID), today); err != nil {
+		response.SendError(w, "Failed to check goals", http.StatusInternalServerError)
+		return
+	}
+
+	if err := tx.Commit(); err != nil {
+		response.SendError(w, "Failed to create workout set(s)", http.StatusInternalServerError)
+		return
+	}
+
+	response.SendSuccess(w, workoutSetsWithRecords(sets, records, units), http.StatusCreated)
+}
+
+// The caller has already checked the workout is the user's own
+func (h *WorkoutSetHandler) createGroupedWorkoutSets(w http.ResponseWriter, r *http.Request, workoutID, userID int32, body []byte) {
+	var request CreateGroupedWorkoutSetsRequest
+	if err := json.Unmarshal(body, &request); err != nil {
+		response.SendError(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if err := validateGroupedWorkoutSetsRequest(&request); err != nil {
+		response.SendError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	units, ok := resolveRequestUnits(w, r, h.queries)
+	if !ok {
+		return
+	}
+
+	// each exercise's fields are checked against its own tracking mode; weights & distances are converted to kg & meters up front
+	resistancesKg := make([]string, len(request.Exercises))
+	distancesMeters := make([]string, len(request.Exercises))
+	distanceUnits := make([]string, len(request.Exercises))
+	for i, input := range request.Exercises {
+		exercise, err := h.queries.GetExerciseByIDForUser(r.Context(), sqlc.GetExerciseByIDForUserParams{
+			ExerciseID: input.ExerciseID,
+			UserID:     utils.ToNullInt32(userID),
+		})
+		if err != nil {
+			if err == sql.ErrNoRows {
+				response.SendError(w, fmt.Sprintf("Exercise %d not found", input.ExerciseID), http.StatusBadRequest)
+				return
+			}
+			response.SendError(w, "Internal server error", http.StatusInternalServerError)
+			return
+		}
+
+		metrics := setMetricFields{
+			Reps:             input.Reps,
+			ResistanceValue:  input.ResistanceValue,
+			ResistanceType:   input.ResistanceType,
+			ResistanceDetail: input.ResistanceDetail,
+			DurationSeconds:  input.DurationSeconds,
+			Distance:         input.Distance,
+			DistanceUnit:     input.DistanceUnit,
+			Calories:         input.Calories,
+		}
+		distancesMeters[i], err = metrics.validate(exercise.TrackingMode)
+		if err != nil {
+			response.SendError(w, fmt.Sprintf("%s: %s", exercise.ExerciseName, err.Error()), http.StatusBadRequest)
+			return
+		}
+		if input.Distance != nil {
+			distanceUnits[i] = metrics.distanceUnit()
+		}
+		resistancesKg[i], err = utils.ToKgString(derefString(input.ResistanceValue), units)
+		if err != nil {
+			response.SendError(w, fmt.Sprintf("%s: %s", exercise.ExerciseName, err.Error()), http.StatusBadRequest)
+			return
+		}
+	}
+
+	totalSets := request.Rounds * int32(len(request.Exercises))
+	params := sqlc.CreateGroupedWorkoutSetsParams{
+		Column1:  workoutID,                    // workout_id
+		Column3:  make([]int32, 0, totalSets),  // exercise_id
+		Column4:  make([]int32, 0, totalSets),  // set_number
+		Column5:  make([]int32, 0, totalSets),  // reps
+		Column6:  make([]string, 0, totalSets), // resistance_value
+		Column7:  make([]string, 0, totalSets), // resistance_type
+		Column8:  make([]string, 0, totalSets), // resistance_detail
+		Column9:  make([]string, 0, totalSets), // rpe
+		Column10: make([]string, 0, totalSets), // notes
+		Column11: make([]int32, 0, totalSets),  // duration_seconds
+		Column12: make([]string, 0, totalSets), // distance_meters
+		Column13: make([]string, 0, totalSets), // distance_unit
+		Column14: make([]int32, 0, totalSets),  // calories
+		Column15: make([]string, 0, totalSets), // set_type
+	}
+
+	for round := int32(1); round <= request.Rounds; round++ {
+		for i, exercise := range request.Exercises {
+			params.Column3 = append(params.Column3, exercise.ExerciseID)
+			params.Column4 = append(params.Column4, round) // nth set of this exercise within the group, offset by the query
+			params.Column5 = append(params.Column5, derefInt32(exercise.Reps))
+			params.Column6 = append(params.Column6, resistancesKg[i])
+			params.Column7 = append(params.Column7, derefString(exercise.ResistanceType))
+			params.Column8 = append(params.Column8, derefString(exercise.ResistanceDetail))
+			params.Column9 = append(params.Column9, derefString(exercise.RPE))
+			params.Column10 = append(params.Column10, derefString(exercise.Notes))
+			params.Column11 = append(params.Column11, derefInt32(exercise.DurationSeconds))
+			params.Column12 = append(params.Column12, distancesMeters[i])
+			params.Column13 = append(params.Column13, distanceUnits[i])
+			params.Column14 = append(params.Column14, derefInt32(exercise.Calories))
+			params.Column15 = append(params.Column15, derefString(exercise.SetType))
+		}
+	}
+
+	today, err := utils.ClientToday(r)
+	if err != nil {
+		response.SendError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	tx, err := h.db.BeginTx(r.Context(), nil)
+	if err != nil {
+		response.SendError(w, "Failed to create workout set group", http.StatusInternalServerError)
+		return
+	}
+	defer tx.Rollback()
+	qtx := h.queries.WithTx(tx)
+
+	group, err := qtx.CreateWorkoutSetGroup(r.Context(), sqlc.CreateWorkoutSetGroupParams{
+		WorkoutID:                   workoutID,
+		GroupType:                   sqlc.SetGroupTypeEnum(request.GroupType),
+		Rounds:                      request.Rounds,
+		RestBetweenExercisesSeconds: utils.ToNullInt32FromIntPtr(request.RestBetweenExercisesSeconds),
+		RestBetweenRoundsSeconds:    utils.ToNullInt32FromIntPtr(request.RestBetweenRoundsSeconds),
+		IntervalSeconds:             utils.ToNullInt32FromIntPtr(request.IntervalSeconds),
+		Notes:                       utils.ToNullStringFromStringPtr(request.Notes),
+	})
+	if err != nil {
+		response.SendError(w, "Failed to create workout set group", http.StatusInternalServerError)
+		return
+	}
+
+	params.Column2 = group.GroupID // group_id
+	sets, err := qtx.CreateGroupedWorkoutSets(r.Context(), params)
+	if err != nil {
+		response.SendError(w, "Failed to create workout set(s)", http.StatusInternalServerError)
+		return
 	}
 
-	sets, err := h.queries.CreateWorkoutSets(r.Context(), params)
+	records, err := detectPersonalRecords(r.Context(), qtx, userID, sets)
 	if err != nil {
-		response.SendError(w, "Failed to create workout set(s)"+err.Error(), http.StatusInternalServerError)
+		response.SendError(w, "Failed to check personal records", http.StatusInternalServerError)
 		return
 	}
 
-	response.SendSuccess(w, sets, http.StatusCreated)
+	if err := detectCompletedGoals(r.Context(), qtx, userID, today); err != nil {
+		response.SendError(w, "Failed to check goals", http.StatusInternalServerError)
+		return
+	}
+
+	if err := tx.Commit(); err != nil {
+		response.SendError(w, "Failed to create workout set group", http.StatusInternalServerError)
+		return
+	}
+
+	response.SendSuccess(w, map[string]interface{}{
+		"group": group,
+		"sets":  workoutSetsWithRecords(sets, records, units),
+	}, http.StatusCreated)
+}
+
+func validateGroupedWorkoutSetsRequest(request *CreateGroupedWorkoutSetsRequest) error {
+	minExercises := map[sqlc.SetGroupTypeEnum]int{
+		sqlc.SetGroupTypeEnumSuperset: 2,
+		sqlc.SetGroupTypeEnumGiantSet: 3,
+		sqlc.SetGroupTypeEnumCircuit:  2,
+		sqlc.SetGroupTypeEnumEmom:     1,
+	}
+
+	groupType := sqlc.SetGroupTypeEnum(request.GroupType)
+	min, ok := minExercises[groupType]
+	if !ok {
+		return fmt.Errorf("group_type must be one of 'superset', 'giant_set', 'circuit', 'emom'")
+	}
+	if len(request.Exercises) < min {
+		return fmt.Errorf("a %s needs at least %d exercises", request.GroupType, min)
+	}
+	if groupType == sqlc.SetGroupTypeEnumSuperset && len(request.Exercises) > 2 {
+		return fmt.Errorf("a superset pairs exactly 2 exercises - use 'giant_set' or 'circuit' for more")
+	}
+	if request.Rounds <= 0 {
+		return fmt.Errorf("rounds must be greater than 0")
+	}
+	for _, exercise := range request.Exercises {
+		if exercise.ExerciseID <= 0 {
+			return fmt.Errorf("exercise_id must be provided for every exercise in the group")
+		}
+		if exercise.SetType != nil && sqlc.SetTypeEnum(*exercise.SetType) == sqlc.SetTypeEnumDrop {
+			return fmt.Errorf("drop sets can't be part of a group")
+		}
+		if err := validateSetType(exercise.SetType, nil); err != nil {
+			return err
+		}
+	}
+	if groupType == sqlc.SetGroupTypeEnumEmom && request.IntervalSeconds == nil {
+		request.IntervalSeconds = utils.IntPtr(60)
+	}
+	return nil
 }
 
 // "/workouts/{workout_id}/sets"
@@ -151,16 +577,257 @@ func (h *WorkoutSetHandler) GetAllWorkoutSets(w http.ResponseWriter, r *http.Req
 		return
 	}
 
-	response.SendSuccess(w, allWorkoutSets)
+	groups, err := h.queries.GetWorkoutSetGroups(r.Context(), workoutID)
+	if err != nil {
+		response.SendError(w, "Failed to retrieve workout set groups", http.StatusInternalServerError)
+		return
+	}
+
+	units, ok := resolveRequestUnits(w, r, h.queries)
+	if !ok {
+		return
+	}
+	for i := range allWorkoutSets {
+		allWorkoutSets[i].ResistanceValue = utils.FromKg(allWorkoutSets[i].ResistanceValue, units)
+	}
+
+	response.SendSuccess(w, nestWorkoutSetsByGroup(allWorkoutSets, groups))
+}
+
+// Sets come in ordered by overall set number; each group becomes one block positioned at its first set,
+// and consecutive straight sets are collected into blocks of their own.
+func nestWorkoutSetsByGroup(sets []sqlc.GetAllWorkoutSetsRow, groups []sqlc.WorkoutSetGroup) []WorkoutSetBlock {
+	groupsByID := make(map[int32]*sqlc.WorkoutSetGroup, len(groups))
+	for i := range groups {
+		groupsByID[groups[i].GroupID] = &groups[i]
+	}
+
+	blocks := []WorkoutSetBlock{}
+	blockIndexByGroup := make(map[int32]int)
+	for _, set := range sets {
+		if !set.GroupID.Valid {
+			last := len(blocks) - 1
+			if last >= 0 && blocks[last].Group == nil {
+				blocks[last].Sets = append(blocks[last].Sets, set)
+			} else {
+				blocks = append(blocks, WorkoutSetBlock{Sets: []sqlc.GetAllWorkoutSetsRow{set}})
+			}
+			continue
+		}
+
+		if i, ok := blockIndexByGroup[set.GroupID.Int32]; ok {
+			blocks[i].Sets = append(blocks[i].Sets, set)
+			continue
+		}
+		blockIndexByGroup[set.GroupID.Int32] = len(blocks)
+		blocks = append(blocks, WorkoutSetBlock{
+			Group: groupsByID[set.GroupID.Int32],
+			Sets:  []sqlc.GetAllWorkoutSetsRow{set},
+		})
+	}
+	return blocks
 }
 
 // "/workouts/{workout_id}/sets"
 func (h *WorkoutSetHandler) DeleteAllWorkoutSets(w http.ResponseWriter, r *http.Request, workoutID int32) {
-	err := h.queries.DeleteAllWorkoutSets(r.Context(), workoutID)
+	userID, err := middleware.GetUserIDFromContext(r.Context())
+	if err != nil {
+		response.SendError(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	// the caller's records are rebuilt afterwards, so the sets have to be in one of their own workouts
+	if _, err := h.queries.GetWorkoutByIDForUser(r.Context(), sqlc.GetWorkoutByIDForUserParams{
+		WorkoutID: workoutID,
+		UserID:    utils.ToNullInt32(userID),
+	}); err != nil {
+		if err == sql.ErrNoRows {
+			response.SendError(w, "Workout not found", http.StatusNotFound)
+			return
+		}
+		response.SendError(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	tx, err := h.db.BeginTx(r.Context(), nil)
 	if err != nil {
 		response.SendError(w, "Failed to delete all workout sets", http.StatusInternalServerError)
 		return
 	}
+	defer tx.Rollback()
+	qtx := h.queries.WithTx(tx)
+
+	sets, err := qtx.GetAllWorkoutSets(r.Context(), workoutID)
+	if err != nil {
+		response.SendError(w, "Failed to delete all workout sets", http.StatusInternalServerError)
+		return
+	}
+
+	if err := qtx.DeleteAllWorkoutSets(r.Context(), workoutID); err != nil {
+		response.SendError(w, "Failed to delete all workout sets", http.StatusInternalServerError)
+		return
+	}
+
+	// the sets' records went with them; whatever they had beaten needs putting back
+	rebuilt := make(map[int32]bool)
+	for _, set := range sets {
+		if rebuilt[set.ExerciseID] {
+			continue
+		}
+		rebuilt[set.ExerciseID] = true
+		if err := rebuildPersonalRecords(r.Context(), qtx, int32(userID), workoutID, set.ExerciseID, nil); err != nil {
+			response.SendError(w, "Failed to update personal records", http.StatusInternalServerError)
+			return
+		}
+	}
+
+	// groups are meaningless without their sets
+	if err := qtx.DeleteAllWorkoutSetGroups(r.Context(), workoutID); err != nil {
+		response.SendError(w, "Failed to delete workout set groups", http.StatusInternalServerError)
+		return
+	}
+
+	if err := tx.Commit(); err != nil {
+		response.SendError(w, "Failed to delete all workout sets", http.StatusInternalServerError)
+		return
+	}
 
 	response.SendSuccess(w, nil, http.StatusNoContent)
 }
+
+// Drop sets must point at the set they drop from; every other set type stands on its own.
+func validateSetType(setType *string, parentSetNumber *int32) error {
+	if setType == nil {
+		if parentSetNumber != nil {
+			return fmt.Errorf("parent_set_number is only allowed for drop sets")
+		}
+		return nil
+	}
+
+	switch sqlc.SetTypeEnum(*setType) {
+	case sqlc.SetTypeEnumWarmup, sqlc.SetTypeEnumWorking, sqlc.SetTypeEnumAmrap,
+		sqlc.SetTypeEnumFailure, sqlc.SetTypeEnumRestPause:
+		if parentSetNumber != nil {
+			return fmt.Errorf("parent_set_number is only allowed for drop sets")
+		}
+	case sqlc.SetTypeEnumDrop:
+		if parentSetNumber == nil || *parentSetNumber <= 0 {
+			return fmt.Errorf("drop sets must provide the parent_set_number they drop from")
+		}
+	default:
+		return fmt.Errorf("set_type must be one of 'warmup', 'working', 'drop', 'amrap', 'failure', 'rest_pause'")
+	}
+	return nil
+}
+
+// The optional set fields whose validity depends on the exercise's tracking mode; rpe & notes fit every mode.
+type setMetricFields struct {
+	Reps             *int32
+	ResistanceValue  *string
+	ResistanceType   *string
+	ResistanceDetail *string
+	DurationSeconds  *int32
+	Distance         *string
+	DistanceUnit     *string
+	Calories         *int32
+}
+
+// Fields each tracking mode accepts, keyed by their JSON names so errors can point at the offending field
+var fieldsByTrackingMode = map[sqlc.ExerciseTrackingModeEnum]map[string]bool{
+	sqlc.ExerciseTrackingModeEnumRepsLoad: {"reps": true, "resistance_value": true, "resistance_type": true, "resistance_detail": true},
+	sqlc.ExerciseTrackingModeEnumRepsOnly: {"reps": true},
+	// timed & distance work can still be loaded (weighted planks, farmer's carries, sled pushes)
+	sqlc.ExerciseTrackingModeEnumTime:         {"duration_seconds": true, "calories": true, "resistance_value": true, "resistance_type": true, "resistance_detail": true},
+	sqlc.ExerciseTrackingModeEnumDistance:     {"distance": true, "distance_unit": true, "calories": true, "resistance_value": true, "resistance_type": true, "resistance_detail": true},
+	sqlc.ExerciseTrackingModeEnumTimeDistance: {"duration_seconds": true, "distance": true, "distance_unit": true, "calories": true, "resistance_value": true, "resistance_type": true, "resistance_detail": true},
+}
+
+// Checks every provided field against the tracking mode & returns the distance converted to meters ("" if none was sent).
+func (f setMetricFields) validate(mode sqlc.ExerciseTrackingModeEnum) (string, error) {
+	allowed := fieldsByTrackingMode[mode]
+	provided := []struct {
+		field   string
+		present bool
+	}{
+		{"reps", f.Reps != nil},
+		{"resistance_value", f.ResistanceValue != nil},
+		{"resistance_type", f.ResistanceType != nil},
+		{"resistance_detail", f.ResistanceDetail != nil},
+		{"duration_seconds", f.DurationSeconds != nil},
+		{"distance", f.Distance != nil},
+		{"distance_unit", f.DistanceUnit != nil},
+		{"calories", f.Calories != nil},
+	}
+	for _, p := range provided {
+		if p.present && !allowed[p.field] {
+			return "", fmt.Errorf("%s is not valid for exercises with tracking mode '%s'", p.field, mode)
+		}
+	}
+
+	if f.DurationSeconds != nil && *f.DurationSeconds <= 0 {
+		return "", fmt.Errorf("duration_seconds must be greater than 0")
+	}
+	if f.Calories != nil && *f.Calories <= 0 {
+		return "", fmt.Errorf("calories must be greater than 0")
+	}
+	if f.DistanceUnit != nil && f.Distance == nil {
+		return "", fmt.Errorf("distance_unit requires a distance")
+	}
+	if f.Distance == nil {
+		return "", nil
+	}
+	return utils.ToMetersString(*f.Distance, f.distanceUnit())
+}
+
+func (f setMetricFields) distanceUnit() string {
+	if f.DistanceUnit == nil {
+		return "m"
+	}
+	return *f.DistanceUnit
+}
+
+// Works out which units weights are sent & received in for this request ("?units=", then the user's profile preference)
+// and labels the response with them. Sends the error response itself & returns false if it couldn't.
+func resolveRequestUnits(w http.ResponseWriter, r *http.Request, queries *sqlc.Queries) (sqlc.UnitSystemEnum, bool) {
+	if _, err := utils.ParseUnitsOverride(r); err != nil {
+		response.SendError(w, err.Error(), http.StatusBadRequest)
+		return "", false
+	}
+
+	userID, err := middleware.GetUserIDFromContext(r.Context())
+	if err != nil {
+		response.SendError(w, "Unauthorized", http.StatusUnauthorized)
+		return "", false
+	}
+
+	units, err := utils.ResolveUnitSystem(r, queries, int32(userID))
+	if err != nil {
+		response.SendError(w, "Failed to resolve unit preference", http.StatusInternalServerError)
+		return "", false
+	}
+
+	utils.SetUnitsHeader(w, units)
+	return units, true
+}
+
+// Converts stored kg weights to the request's units for the response
+func workoutSetsInUnits(sets []sqlc.WorkoutSet, units sqlc.UnitSystemEnum) []sqlc.WorkoutSet {
+	for i := range sets {
+		sets[i].ResistanceValue = utils.FromKg(sets[i].ResistanceValue, units)
+	}
+	return sets
+}
+
+func derefInt32(i *int32) int32 {
+	if i == nil {
+		return 0
+	}
+	return *i
+}
+
+func derefString(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
@@ -5,7 +5,12 @@ package handlers
 import (
 	"database/sql"
 	"encoding/json"
+	"fmt"
 	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
 
 	"go-reppy/backend/internal/api/middleware"
 	"go-reppy/backend/internal/api/response"
@@ -23,8 +28,27 @@ func NewWorkoutByIDHandler(q *sqlc.Queries) *WorkoutByIDHandler {
 	}
 }
 
+// Live-session view of a workout, returned by /start, /finish and /workouts/active
+type WorkoutSessionResponse struct {
+	WorkoutID       int32               `json:"WorkoutID"`
+	WorkoutDate     string              `json:"WorkoutDate"`
+	Title           sql.NullString      `json:"Title"`
+	StartedAt       sql.NullTime        `json:"StartedAt"`
+	EndedAt         sql.NullTime        `json:"EndedAt"`
+	InProgress      bool                `json:"InProgress"`
+	DurationSeconds *int64              `json:"DurationSeconds"` // total session length, or time elapsed so far while in progress
+	Sets            []WorkoutSessionSet `json:"Sets"`
+}
+
+type WorkoutSessionSet struct {
+	sqlc.GetAllWorkoutSetsRow
+	RestSeconds *int64 `json:"RestSeconds"` // time since the previously completed set; nil for the first/uncompleted sets
+}
+
 type UpdateWorkoutByIDRequest struct {
-	Title string `json:"workouttitle"`
+	Title     string     `json:"workouttitle"`
+	StartedAt *time.Time `json:"started_at"` // optional - left as-is when absent
+	EndedAt   *time.Time `json:"ended_at"`   // optional - left as-is when absent
 }
 
 func (h *WorkoutByIDHandler) HandleWorkoutsByID(w http.ResponseWriter, r *http.Request) {
@@ -91,8 +115,15 @@ func (h *WorkoutByIDHandler) UpdateWorkoutByID(w http.ResponseWriter, r *http.Re
 		return
 	}
 
+	if request.StartedAt != nil && request.EndedAt != nil && request.EndedAt.Before(*request.StartedAt) {
+		response.SendError(w, "ended_at must not be before started_at", http.StatusBadRequest)
+		return
+	}
+
 	workout, err := h.queries.UpdateWorkout(r.Context(), sqlc.UpdateWorkoutParams{
 		Title:     utils.ToNullString(request.Title),
+		StartedAt: utils.ToNullTimeFromTimePtr(request.StartedAt),
+		EndedAt:   utils.ToNullTimeFromTimePtr(request.EndedAt),
 		WorkoutID: workoutID,
 		UserID:    utils.ToNullInt32(userID),
 	})
@@ -101,6 +132,10 @@ func (h *WorkoutByIDHandler) UpdateWorkoutByID(w http.ResponseWriter, r *http.Re
 			response.SendError(w, "Workout not found", http.StatusNotFound)
 			return
 		}
+		if strings.Contains(err.Error(), "workouts_ended_after_started") {
+			response.SendError(w, "ended_at must not be before started_at", http.StatusBadRequest)
+			return
+		}
 		response.SendError(w, "Internal server error", http.StatusInternalServerError)
 		return
 	}
@@ -140,3 +175,204 @@ func (h *WorkoutByIDHandler) DeleteWorkoutByID(w http.ResponseWriter, r *http.Re
 		"id":      int(deletedWorkoutID),
 	})
 }
+
+// "/workouts/2/start"
+func (h *WorkoutByIDHandler) HandleStartWorkout(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		response.SendError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	userID, err := middleware.GetUserIDFromContext(r.Context())
+	if err != nil {
+		response.SendError(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	workoutID, err := parseWorkoutIDFromSessionPath(r.URL.Path)
+	if err != nil {
+		response.SendError(w, "Invalid workout ID", http.StatusBadRequest)
+		return
+	}
+
+	workout, err := h.queries.GetWorkoutByIDForUser(r.Context(), sqlc.GetWorkoutByIDForUserParams{
+		WorkoutID: workoutID,
+		UserID:    utils.ToNullInt32(userID),
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			response.SendError(w, "Workout not found", http.StatusNotFound)
+			return
+		}
+		response.SendError(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+	if workout.StartedAt.Valid {
+		response.SendError(w, "Workout has already been started", http.StatusConflict)
+		return
+	}
+
+	// only one live session at a time
+	active, err := h.queries.GetActiveWorkoutForUser(r.Context(), utils.ToNullInt32(userID))
+	if err == nil {
+		response.SendError(w, fmt.Sprintf("Workout %d is already in progress - finish it first", active.WorkoutID), http.StatusConflict)
+		return
+	}
+	if err != sql.ErrNoRows {
+		response.SendError(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	started, err := h.queries.StartWorkout(r.Context(), sqlc.StartWorkoutParams{
+		WorkoutID: workoutID,
+		UserID:    utils.ToNullInt32(userID),
+	})
+	if err != nil {
+		if err == sql.ErrNoRows { // started by a concurrent request
+			response.SendError(w, "Workout has already been started", http.StatusConflict)
+			return
+		}
+		response.SendError(w, "Failed to start workout", http.StatusInternalServerError)
+		return
+	}
+
+	h.sendWorkoutSession(w, r, started)
+}
+
+// "/workouts/2/finish"
+func (h *WorkoutByIDHandler) HandleFinishWorkout(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		response.SendError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	userID, err := middleware.GetUserIDFromContext(r.Context())
+	if err != nil {
+		response.SendError(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	workoutID, err := parseWorkoutIDFromSessionPath(r.URL.Path)
+	if err != nil {
+		response.SendError(w, "Invalid workout ID", http.StatusBadRequest)
+		return
+	}
+
+	workout, err := h.queries.GetWorkoutByIDForUser(r.Context(), sqlc.GetWorkoutByIDForUserParams{
+		WorkoutID: workoutID,
+		UserID:    utils.ToNullInt32(userID),
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			response.SendError(w, "Workout not found", http.StatusNotFound)
+			return
+		}
+		response.SendError(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+	if !workout.StartedAt.Valid {
+		response.SendError(w, "Workout has not been started", http.StatusConflict)
+		return
+	}
+	if workout.EndedAt.Valid {
+		response.SendError(w, "Workout has already been finished", http.StatusConflict)
+		return
+	}
+
+	finished, err := h.queries.FinishWorkout(r.Context(), sqlc.FinishWorkoutParams{
+		WorkoutID: workoutID,
+		UserID:    utils.ToNullInt32(userID),
+	})
+	if err != nil {
+		if err == sql.ErrNoRows { // finished by a concurrent request
+			response.SendError(w, "Workout has already been finished", http.StatusConflict)
+			return
+		}
+		response.SendError(w, "Failed to finish workout", http.StatusInternalServerError)
+		return
+	}
+
+	h.sendWorkoutSession(w, r, finished)
+}
+
+func (h *WorkoutByIDHandler) sendWorkoutSession(w http.ResponseWriter, r *http.Request, workout sqlc.Workout) {
+	units, ok := resolveRequestUnits(w, r, h.queries)
+	if !ok {
+		return
+	}
+
+	session, err := buildWorkoutSession(h.queries, r, workout, units)
+	if err != nil {
+		response.SendError(w, "Failed to load workout session", http.StatusInternalServerError)
+		return
+	}
+
+	response.SendSuccess(w, session)
+}
+
+// Builds the live-session view of a workout: total (or elapsed) duration plus rest between consecutively completed sets.
+// Rest is measured completion-to-completion, so it includes the time spent performing the later set. Weights are sent in the given units.
+func buildWorkoutSession(queries *sqlc.Queries, r *http.Request, workout sqlc.Workout, units sqlc.UnitSystemEnum) (WorkoutSessionResponse, error) {
+	clientDate, err := utils.FromUTCToClientTimezone(workout.WorkoutDate, r)
+	if err != nil {
+		return WorkoutSessionResponse{}, err
+	}
+
+	sets, err := queries.GetAllWorkoutSets(r.Context(), workout.WorkoutID)
+	if err != nil {
+		return WorkoutSessionResponse{}, err
+	}
+
+	session := WorkoutSessionResponse{
+		WorkoutID:   workout.WorkoutID,
+		WorkoutDate: clientDate.Format("2006-01-02"),
+		Title:       workout.Title,
+		StartedAt:   workout.StartedAt,
+		EndedAt:     workout.EndedAt,
+		InProgress:  workout.StartedAt.Valid && !workout.EndedAt.Valid,
+		Sets:        make([]WorkoutSessionSet, len(sets)),
+	}
+
+	if workout.StartedAt.Valid {
+		end := time.Now()
+		if workout.EndedAt.Valid {
+			end = workout.EndedAt.Time
+		}
+		duration := int64(end.Sub(workout.StartedAt.Time).Seconds())
+		session.DurationSeconds = &duration
+	}
+
+	// walk completed sets in the order they were finished, regardless of their planned set numbers
+	completed := make([]int, 0, len(sets))
+	for i, set := range sets {
+		set.ResistanceValue = utils.FromKg(set.ResistanceValue, units)
+		session.Sets[i] = WorkoutSessionSet{GetAllWorkoutSetsRow: set}
+		if set.CompletedAt.Valid {
+			completed = append(completed, i)
+		}
+	}
+	sort.SliceStable(completed, func(a, b int) bool {
+		return sets[completed[a]].CompletedAt.Time.Before(sets[completed[b]].CompletedAt.Time)
+	})
+	for n := 1; n < len(completed); n++ {
+		prev, curr := sets[completed[n-1]], sets[completed[n]]
+		rest := int64(curr.CompletedAt.Time.Sub(prev.CompletedAt.Time).Seconds())
+		session.Sets[completed[n]].RestSeconds = &rest
+	}
+
+	return session, nil
+}
+
+// "/workouts/{workout_id}/start", "/workouts/{workout_id}/finish"
+func parseWorkoutIDFromSessionPath(path string) (int32, error) {
+	pathParts := strings.Split(path, "/")
+	if len(pathParts) < 4 {
+		return 0, fmt.Errorf("invalid path")
+	}
+
+	id, err := strconv.ParseInt(pathParts[2], 10, 32)
+	if err != nil {
+		return 0, err
+	}
+	return int32(id), nil
+}
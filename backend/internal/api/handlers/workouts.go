@@ -29,12 +29,17 @@ func NewWorkoutHandler(q *sqlc.Queries, jwtSecret []byte) *WorkoutHandler {
 type CreateWorkoutRequest struct {
 	Title       string           `json:"title"`             // name of workout, e.g. "Upper Body 2"
 	WorkoutDate utils.CustomDate `json:"clientworkoutdate"` // YYYY-MM-DD
+	// optional, RFC3339 w/ offset - lets users log several sessions on the same day (AM/PM doubles)
+	StartedAt *time.Time `json:"started_at"`
+	EndedAt   *time.Time `json:"ended_at"`
 }
 
 type WorkoutResponse struct {
 	WorkoutID   int32          `json:"WorkoutID"`
 	WorkoutDate string         `json:"WorkoutDate"` // post-db query, pre-response, sent as string
 	Title       sql.NullString `json:"Title"`
+	StartedAt   sql.NullTime   `json:"StartedAt"`
+	EndedAt     sql.NullTime   `json:"EndedAt"`
 	CreatedAt   sql.NullTime   `json:"CreatedAt"`
 }
 
@@ -42,7 +47,7 @@ func (h *WorkoutHandler) HandleWorkouts(w http.ResponseWriter, r *http.Request)
 	dateQueryParams := r.URL.Query()
 	if _, exists := dateQueryParams["date"]; exists {
 		if r.Method == http.MethodGet {
-			h.GetWorkoutByUserIDAndDate(w, r)
+			h.GetWorkoutsByUserIDAndDate(w, r)
 			return
 		}
 		response.SendError(w, "Method not allowed", http.StatusMethodNotAllowed)
@@ -88,6 +93,8 @@ func (h *WorkoutHandler) GetAllWorkoutsForUser(w http.ResponseWriter, r *http.Re
 			WorkoutID:   workout.WorkoutID,
 			WorkoutDate: clientTime.Format("2006-01-02"),
 			Title:       workout.Title,
+			StartedAt:   workout.StartedAt,
+			EndedAt:     workout.EndedAt,
 			CreatedAt:   workout.CreatedAt,
 		})
 	}
@@ -95,8 +102,9 @@ func (h *WorkoutHandler) GetAllWorkoutsForUser(w http.ResponseWriter, r *http.Re
 	response.SendSuccess(w, resp)
 }
 
+// Returns every session logged on that date, so the response is always a list (empty if none).
 // "/workouts?date=2024-01-05"
-func (h *WorkoutHandler) GetWorkoutByUserIDAndDate(w http.ResponseWriter, r *http.Request) {
+func (h *WorkoutHandler) GetWorkoutsByUserIDAndDate(w http.ResponseWriter, r *http.Request) {
 	userID, err := middleware.GetUserIDFromContext(r.Context())
 	if err != nil {
 		response.SendError(w, "Unauthorized", http.StatusUnauthorized)
@@ -121,20 +129,34 @@ func (h *WorkoutHandler) GetWorkoutByUserIDAndDate(w http.ResponseWriter, r *htt
 		return
 	}
 
-	workout, err := h.queries.GetWorkoutByUserIDAndDate(r.Context(), sqlc.GetWorkoutByUserIDAndDateParams{
+	workouts, err := h.queries.GetWorkoutsByUserIDAndDate(r.Context(), sqlc.GetWorkoutsByUserIDAndDateParams{
 		UserID:      utils.ToNullInt32(userID),
 		WorkoutDate: utcTime,
 	})
 	if err != nil {
-		if err == sql.ErrNoRows {
-			response.SendError(w, "Workout not found", http.StatusNotFound)
-			return
-		}
 		response.SendError(w, "Internal server error", http.StatusInternalServerError)
 		return
 	}
 
-	response.SendSuccess(w, workout)
+	resp := make([]WorkoutResponse, 0, len(workouts))
+	for _, workout := range workouts {
+		clientTime, err := utils.FromUTCToClientTimezone(workout.WorkoutDate, r)
+		if err != nil {
+			response.SendError(w, "Timezone conversion error", http.StatusBadRequest)
+			return
+		}
+
+		resp = append(resp, WorkoutResponse{
+			WorkoutID:   workout.WorkoutID,
+			WorkoutDate: clientTime.Format("2006-01-02"),
+			Title:       workout.Title,
+			StartedAt:   workout.StartedAt,
+			EndedAt:     workout.EndedAt,
+			CreatedAt:   workout.CreatedAt,
+		})
+	}
+
+	response.SendSuccess(w, resp)
 }
 
 // "/workouts"
@@ -151,6 +173,11 @@ func (h *WorkoutHandler) CreateWorkout(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if request.StartedAt != nil && request.EndedAt != nil && request.EndedAt.Before(*request.StartedAt) {
+		response.SendError(w, "ended_at must not be before started_at", http.StatusBadRequest)
+		return
+	}
+
 	workoutDate := request.WorkoutDate.ToTime()
 
 	utcTime, err := utils.FromClientTimezoneToUTC(workoutDate, r)
@@ -163,6 +190,8 @@ func (h *WorkoutHandler) CreateWorkout(w http.ResponseWriter, r *http.Request) {
 		UserID:      utils.ToNullInt32(userID),
 		WorkoutDate: utcTime,
 		Title:       utils.ToNullString(request.Title),
+		StartedAt:   utils.ToNullTimeFromTimePtr(request.StartedAt),
+		EndedAt:     utils.ToNullTimeFromTimePtr(request.EndedAt),
 	})
 	if err != nil {
 		response.SendError(w, "Failed to create workout", http.StatusInternalServerError)
@@ -171,3 +200,40 @@ func (h *WorkoutHandler) CreateWorkout(w http.ResponseWriter, r *http.Request) {
 
 	response.SendSuccess(w, workout, http.StatusCreated)
 }
+
+// "/workouts/active"
+func (h *WorkoutHandler) HandleActiveWorkout(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		response.SendError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	userID, err := middleware.GetUserIDFromContext(r.Context())
+	if err != nil {
+		response.SendError(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	workout, err := h.queries.GetActiveWorkoutForUser(r.Context(), utils.ToNullInt32(userID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			response.SendError(w, "No workout in progress", http.StatusNotFound)
+			return
+		}
+		response.SendError(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	units, ok := resolveRequestUnits(w, r, h.queries)
+	if !ok {
+		return
+	}
+
+	session, err := buildWorkoutSession(h.queries, r, workout, units)
+	if err != nil {
+		response.SendError(w, "Failed to load workout session", http.StatusInternalServerError)
+		return
+	}
+
+	response.SendSuccess(w, session)
+}
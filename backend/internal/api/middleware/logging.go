@@ -3,9 +3,13 @@ package middleware
 import (
 	"log"
 	"net/http"
+	"strings"
 	"time"
 )
 
+// Routes whose URL holds a secret (calendar feed tokens); everything after the prefix is masked in the log
+var secretPathPrefixes = []string{"/calendar-feeds/"}
+
 type ResponseWriter struct {
 	http.ResponseWriter
 	statusCode int
@@ -20,6 +24,13 @@ func (rw *ResponseWriter) WriteHeader(code int) {
 	rw.ResponseWriter.WriteHeader(code)
 }
 
+// passes flushes through so streamed responses (e.g. /me/export) reach the client as they're written
+func (rw *ResponseWriter) Flush() {
+	if flusher, ok := rw.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
 // written w/ 3 layers so we can initialize it in same fashion as other middlewares in main.go
 func LoggingMiddleware() func(http.HandlerFunc) http.HandlerFunc { // layer 1: factory func returns a middleware func
 	return func(next http.HandlerFunc) http.HandlerFunc { // layer 2: middleware func takes next handler & returns new handler
@@ -42,7 +53,7 @@ func LoggingMiddleware() func(http.HandlerFunc) http.HandlerFunc { // layer 1: f
 			log.Printf(
 				"%s %s %s %d %v",
 				r.Method,
-				r.RequestURI,
+				loggedURI(r),
 				r.RemoteAddr,
 				rw.statusCode,
 				duration,
@@ -50,3 +61,12 @@ func LoggingMiddleware() func(http.HandlerFunc) http.HandlerFunc { // layer 1: f
 		}
 	}
 }
+
+func loggedURI(r *http.Request) string {
+	for _, prefix := range secretPathPrefixes {
+		if strings.HasPrefix(r.URL.Path, prefix) {
+			return prefix + "[redacted]"
+		}
+	}
+	return r.RequestURI
+}
@@ -0,0 +1,181 @@
+package utils
+
+import (
+	"database/sql"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+
+	"go-reppy/backend/internal/database/sqlc"
+)
+
+// Weights are stored in kg, heights in cm & distances in meters; everything else is converted on the way in and out.
+const (
+	KgPerPound = 0.45359237
+	cmPerInch  = 2.54
+)
+
+// Used when a user has no profile (and so no saved preference) & no "?units=" override was sent
+const DefaultUnitSystem = sqlc.UnitSystemEnumImperial
+
+// Meters per unit for every distance unit a set can be logged in; distances are always stored in meters.
+var MetersPerDistanceUnit = map[string]float64{
+	"m":  1,
+	"km": 1000,
+	"mi": 1609.344,
+	"yd": 0.9144,
+	"ft": 0.3048,
+}
+
+// Converts a decimal distance string (as sent in requests) in the given unit to a meters string for SQLc's decimal params.
+func ToMetersString(distance string, unit string) (string, error) {
+	factor, ok := MetersPerDistanceUnit[unit]
+	if !ok {
+		return "", fmt.Errorf("distance_unit must be one of 'm', 'km', 'mi', 'yd', 'ft'")
+	}
+
+	value, err := strconv.ParseFloat(distance, 64)
+	if err != nil || value <= 0 {
+		return "", fmt.Errorf("distance must be a positive number")
+	}
+
+	return strconv.FormatFloat(value*factor, 'f', 2, 64), nil
+}
+
+// Parses the optional "?units=metric|imperial" query param that overrides the user's saved preference for a single request.
+func ParseUnitsOverride(r *http.Request) (sqlc.NullUnitSystemEnum, error) {
+	units := r.URL.Query().Get("units")
+	switch sqlc.UnitSystemEnum(units) {
+	case "":
+		return sqlc.NullUnitSystemEnum{}, nil
+	case sqlc.UnitSystemEnumMetric, sqlc.UnitSystemEnumImperial:
+		return sqlc.NullUnitSystemEnum{UnitSystemEnum: sqlc.UnitSystemEnum(units), Valid: true}, nil
+	default:
+		return sqlc.NullUnitSystemEnum{}, fmt.Errorf("units must be 'metric' or 'imperial'")
+	}
+}
+
+// Picks the unit system for a request: "?units=" wins, then the user's profile preference, then DefaultUnitSystem.
+func ResolveUnitSystem(r *http.Request, queries *sqlc.Queries, userID int32) (sqlc.UnitSystemEnum, error) {
+	override, err := ParseUnitsOverride(r)
+	if err != nil {
+		return "", err
+	}
+	if override.Valid {
+		return override.UnitSystemEnum, nil
+	}
+
+	profile, err := queries.GetUserProfile(r.Context(), ToNullInt32(userID))
+	if err == sql.ErrNoRows {
+		return DefaultUnitSystem, nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return profile.UnitPreference, nil
+}
+
+// Sets the "X-Units" response header so clients know how to label weights & heights in the response body.
+func SetUnitsHeader(w http.ResponseWriter, units sqlc.UnitSystemEnum) {
+	w.Header().Set("X-Units", string(units))
+}
+
+// Converts a weight string in the given unit system to a kg string for SQLc's decimal params. Empty strings pass through (for the NULLIF array params).
+func ToKgString(weight string, units sqlc.UnitSystemEnum) (string, error) {
+	if weight == "" {
+		return "", nil
+	}
+
+	value, err := strconv.ParseFloat(weight, 64)
+	if err != nil {
+		return "", fmt.Errorf("invalid weight value: %q", weight)
+	}
+	if units == sqlc.UnitSystemEnumImperial {
+		value *= KgPerPound
+	}
+	return strconv.FormatFloat(value, 'f', 3, 64), nil
+}
+
+// Converts a stored kg value to the given unit system for responses, rounded to 2 decimal places.
+func FromKg(kg sql.NullString, units sqlc.UnitSystemEnum) sql.NullString {
+	if !kg.Valid {
+		return kg
+	}
+
+	value, err := strconv.ParseFloat(kg.String, 64)
+	if err != nil {
+		return kg
+	}
+	if units == sqlc.UnitSystemEnumImperial {
+		value /= KgPerPound
+	}
+	return sql.NullString{String: formatDecimal(value), Valid: true}
+}
+
+// Converts a kg value computed in Go (1RMs, volume, etc.) to the given unit system, rounded to 2 decimal places.
+func FromKgFloat(kg float64, units sqlc.UnitSystemEnum) float64 {
+	if units == sqlc.UnitSystemEnumImperial {
+		kg /= KgPerPound
+	}
+	return math.Round(kg*100) / 100
+}
+
+// Converts a height in the given unit system (inches or cm) to a cm value for SQLc's decimal params.
+func ToCmFromFloatPtr(height *float64, units sqlc.UnitSystemEnum) sql.NullString {
+	if height == nil {
+		return sql.NullString{}
+	}
+
+	value := *height
+	if units == sqlc.UnitSystemEnumImperial {
+		value *= cmPerInch
+	}
+	return sql.NullString{String: strconv.FormatFloat(value, 'f', 2, 64), Valid: true}
+}
+
+// Converts a weight in the given unit system (lbs or kg) to a kg value for SQLc's decimal params.
+func ToKgFromFloatPtr(weight *float64, units sqlc.UnitSystemEnum) sql.NullString {
+	if weight == nil {
+		return sql.NullString{}
+	}
+
+	kg, _ := ToKgString(strconv.FormatFloat(*weight, 'f', -1, 64), units)
+	return sql.NullString{String: kg, Valid: true}
+}
+
+// Converts a stored cm value to the given unit system (inches or cm) for responses.
+func FromCm(cm sql.NullString, units sqlc.UnitSystemEnum) sql.NullString {
+	if !cm.Valid {
+		return cm
+	}
+
+	value, err := strconv.ParseFloat(cm.String, 64)
+	if err != nil {
+		return cm
+	}
+	if units == sqlc.UnitSystemEnumImperial {
+		value /= cmPerInch
+	}
+	return sql.NullString{String: formatDecimal(value), Valid: true}
+}
+
+// Weight & height unit labels for a unit system
+func WeightUnit(units sqlc.UnitSystemEnum) string {
+	if units == sqlc.UnitSystemEnumImperial {
+		return "lbs"
+	}
+	return "kg"
+}
+
+func HeightUnit(units sqlc.UnitSystemEnum) string {
+	if units == sqlc.UnitSystemEnumImperial {
+		return "in"
+	}
+	return "cm"
+}
+
+// Rounds to 2 decimal places & drops trailing zeros ("135", "61.25")
+func formatDecimal(value float64) string {
+	return strconv.FormatFloat(math.Round(value*100)/100, 'f', -1, 64)
+}
@@ -75,6 +75,23 @@ func FromUTCToClientTimezone(utcTime time.Time, r *http.Request) (time.Time, err
 	return utcTime.In(loc), nil
 }
 
+// Today's calendar date where the client is, per the "X-User-Timezone" header (UTC if absent), as midnight UTC so it
+// compares directly with DATE columns.
+func ClientToday(r *http.Request) (time.Time, error) {
+	clientTZ := r.Header.Get("X-User-Timezone")
+	if clientTZ == "" {
+		clientTZ = "UTC" // fallback
+	}
+
+	location, err := time.LoadLocation(clientTZ)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid timezone: %w", err)
+	}
+
+	now := time.Now().In(location)
+	return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC), nil
+}
+
 func GetIDFromPath(path string) (int32, error) {
 	parts := strings.Split(path, "/")
 	if len(parts) < 3 {
@@ -88,6 +105,24 @@ func GetIDFromPath(path string) (int32, error) {
 	return int32(id), nil
 }
 
+// Reads the optional "?from=&to=" (YYYY-MM-DD); missing ends are left open
+func ParseOptionalDateRange(r *http.Request) (time.Time, time.Time, error) {
+	from := time.Date(1900, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(9999, 12, 31, 0, 0, 0, 0, time.UTC)
+	for name, date := range map[string]*time.Time{"from": &from, "to": &to} {
+		value := strings.TrimSpace(r.URL.Query().Get(name))
+		if value == "" {
+			continue
+		}
+		parsed, err := time.Parse("2006-01-02", value)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("Invalid %s date format - use YYYY-MM-DD", name)
+		}
+		*date = parsed
+	}
+	return from, to, nil
+}
+
 // Used in APIs' SQLc params section for compact conversions.
 func ToNullString(s string) sql.NullString {
 	return sql.NullString{
@@ -139,6 +174,13 @@ func ToNullInt32FromIntPtr(i *int32) sql.NullInt32 {
 	return sql.NullInt32{Int32: *i, Valid: true}
 }
 
+func ToNullTimeFromTimePtr(t *time.Time) sql.NullTime {
+	if t == nil {
+		return sql.NullTime{}
+	}
+	return sql.NullTime{Time: *t, Valid: true}
+}
+
 func ToNullStringFromStringPtr(s *string) sql.NullString {
 	if s == nil {
 		return sql.NullString{}
@@ -165,6 +207,18 @@ func ToNullResistanceTypeEnumFromStringPtr(s *string) sqlc.NullResistanceTypeEnu
 	}
 }
 
+func ToNullSetTypeEnumFromStringPtr(s *string) sqlc.NullSetTypeEnum {
+	if s == nil {
+		return sqlc.NullSetTypeEnum{
+			Valid: false,
+		}
+	}
+	return sqlc.NullSetTypeEnum{
+		SetTypeEnum: sqlc.SetTypeEnum(*s),
+		Valid:       true,
+	}
+}
+
 func ToNullFloat64FromFloat32Ptr(f *float32) sql.NullFloat64 {
 	if f == nil {
 		return sql.NullFloat64{}
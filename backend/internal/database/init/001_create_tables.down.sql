@@ -1,3 +1,5 @@
+-- Every down file in this dir has to be idempotent (IF EXISTS, etc.): docker's initdb runs each .sql file here in name
+-- order, down files included, and "NNN_x.down.sql" sorts just before its "NNN_x.up.sql".
 -- drop tables in reverse order of creation
 DROP TABLE IF EXISTS user_profiles CASCADE;
 DROP TABLE IF EXISTS users CASCADE;
\ No newline at end of file
@@ -0,0 +1,10 @@
+-- Restoring the UNIQUE constraint will fail if any user still has more than one workout on the same day; merge those first.
+DROP INDEX IF EXISTS idx_workouts_user_id_workout_date;
+
+ALTER TABLE workouts
+    DROP CONSTRAINT IF EXISTS workouts_ended_after_started,
+    DROP COLUMN IF EXISTS started_at,
+    DROP COLUMN IF EXISTS ended_at;
+
+ALTER TABLE workouts DROP CONSTRAINT IF EXISTS workouts_user_id_workout_date_key;
+ALTER TABLE workouts ADD CONSTRAINT workouts_user_id_workout_date_key UNIQUE(user_id, workout_date);
@@ -0,0 +1,11 @@
+-- Users can now log more than one session on the same calendar day (e.g. AM/PM doubles), so the one-workout-per-day rule goes away.
+-- Existing rows are left untouched; started_at/ended_at stay NULL for workouts logged before this migration.
+ALTER TABLE workouts DROP CONSTRAINT IF EXISTS workouts_user_id_workout_date_key;
+
+ALTER TABLE workouts
+    ADD COLUMN started_at TIMESTAMP WITH TIME ZONE, -- Optional - when the session actually began
+    ADD COLUMN ended_at TIMESTAMP WITH TIME ZONE,   -- Optional - when the session actually ended
+    ADD CONSTRAINT workouts_ended_after_started CHECK (started_at IS NULL OR ended_at IS NULL OR ended_at >= started_at);
+
+-- Replaces the index we got for free from the old UNIQUE constraint; date lookups now return every session that day
+CREATE INDEX idx_workouts_user_id_workout_date ON workouts(user_id, workout_date);
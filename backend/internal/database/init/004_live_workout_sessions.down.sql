@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_workouts_in_progress;
+
+ALTER TABLE workout_sets DROP COLUMN IF EXISTS completed_at;
@@ -0,0 +1,7 @@
+-- Live session mode: workouts.started_at/ended_at (added in 003) get stamped by /start and /finish,
+-- and each set records when it was actually completed so rest times can be derived from consecutive sets.
+ALTER TABLE workout_sets
+    ADD COLUMN completed_at TIMESTAMP WITH TIME ZONE; -- Optional - set when the set is marked done during a live session
+
+-- "What's in progress?" lookups only care about started-but-not-finished workouts
+CREATE INDEX idx_workouts_in_progress ON workouts(user_id) WHERE started_at IS NOT NULL AND ended_at IS NULL;
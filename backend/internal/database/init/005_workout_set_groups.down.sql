@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_workout_sets_group_id;
+ALTER TABLE workout_sets DROP COLUMN IF EXISTS group_id;
+
+DROP TABLE IF EXISTS workout_set_groups;
+DROP TYPE IF EXISTS set_group_type_enum;
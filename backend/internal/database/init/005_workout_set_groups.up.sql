@@ -0,0 +1,20 @@
+-- Set groups let several exercises share rounds (supersets, giant sets, circuits, EMOMs) instead of being a flat list of straight sets.
+-- Sets belonging to a group point at it via workout_sets.group_id; rest prescriptions live on the group rather than on each set.
+CREATE TYPE set_group_type_enum AS ENUM ('superset', 'giant_set', 'circuit', 'emom');
+CREATE TABLE workout_set_groups (
+    group_id SERIAL PRIMARY KEY,
+    workout_id INTEGER REFERENCES workouts(workout_id) ON DELETE CASCADE NOT NULL,
+    group_type set_group_type_enum NOT NULL,
+    rounds INTEGER NOT NULL CHECK (rounds > 0),                   -- Times through the whole group
+    rest_between_exercises_seconds INTEGER,                       -- Optional - rest after each exercise within a round (usually 0 for supersets)
+    rest_between_rounds_seconds INTEGER,                          -- Optional - rest once a full round is done
+    interval_seconds INTEGER,                                     -- Optional - EMOM only, length of each interval (60 for a classic EMOM)
+    notes TEXT,                                                   -- Optional
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+ALTER TABLE workout_sets
+    ADD COLUMN group_id INTEGER REFERENCES workout_set_groups(group_id) ON DELETE SET NULL; -- Optional - NULL for straight sets
+
+CREATE INDEX idx_workout_set_groups_workout_id ON workout_set_groups(workout_id);
+CREATE INDEX idx_workout_sets_group_id ON workout_sets(group_id);
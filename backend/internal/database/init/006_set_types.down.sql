@@ -0,0 +1,27 @@
+-- Restore the original view first since it depends on set_type
+DO $$
+BEGIN
+    IF EXISTS (SELECT 1 FROM information_schema.columns WHERE table_name = 'workout_sets' AND column_name = 'set_type') THEN
+        CREATE OR REPLACE VIEW exercise_one_rm AS
+        SELECT 
+            w.user_id,
+            e.exercise_name,
+            MAX(ws.resistance_value / (1.0278 - 0.0278 * ws.reps)) as estimated_1rm
+        FROM workouts w
+        JOIN workout_sets ws ON w.workout_id = ws.workout_id
+        JOIN exercises e ON ws.exercise_id = e.exercise_id
+        WHERE ws.resistance_type = 'weight'
+          AND ws.resistance_value IS NOT NULL 
+          AND ws.reps IS NOT NULL
+        GROUP BY w.user_id, e.exercise_name;
+    END IF;
+END $$;
+
+DROP INDEX IF EXISTS idx_workout_sets_parent_set;
+ALTER TABLE workout_sets DROP CONSTRAINT IF EXISTS workout_sets_parent_not_self;
+ALTER TABLE workout_sets DROP CONSTRAINT IF EXISTS workout_sets_drop_set_parent;
+ALTER TABLE workout_sets DROP CONSTRAINT IF EXISTS workout_sets_parent_set_fkey;
+ALTER TABLE workout_sets DROP COLUMN IF EXISTS parent_set_number;
+ALTER TABLE workout_sets DROP COLUMN IF EXISTS set_type;
+
+DROP TYPE IF EXISTS set_type_enum;
@@ -0,0 +1,31 @@
+-- Set types so warm-ups, drop sets, AMRAPs etc. can be told apart from regular working sets.
+-- Warm-ups are excluded from volume, 1RM and PR calculations by default.
+CREATE TYPE set_type_enum AS ENUM ('warmup', 'working', 'drop', 'amrap', 'failure', 'rest_pause');
+
+ALTER TABLE workout_sets
+    ADD COLUMN set_type set_type_enum NOT NULL DEFAULT 'working',
+    ADD COLUMN parent_set_number INTEGER; -- Optional - drop sets only, the overall_workout_set_number of the set they drop from
+
+-- Sub-sets of a drop set always point back at the set they drop from (within the same workout)
+ALTER TABLE workout_sets
+    ADD CONSTRAINT workout_sets_parent_set_fkey FOREIGN KEY (workout_id, parent_set_number)
+        REFERENCES workout_sets(workout_id, overall_workout_set_number) ON DELETE CASCADE,
+    ADD CONSTRAINT workout_sets_drop_set_parent CHECK ((set_type = 'drop') = (parent_set_number IS NOT NULL)),
+    ADD CONSTRAINT workout_sets_parent_not_self CHECK (parent_set_number <> overall_workout_set_number);
+
+CREATE INDEX idx_workout_sets_parent_set ON workout_sets(workout_id, parent_set_number) WHERE parent_set_number IS NOT NULL;
+
+-- Same as before, minus warm-ups
+CREATE OR REPLACE VIEW exercise_one_rm AS
+SELECT 
+    w.user_id,
+    e.exercise_name,
+    MAX(ws.resistance_value / (1.0278 - 0.0278 * ws.reps)) as estimated_1rm
+FROM workouts w
+JOIN workout_sets ws ON w.workout_id = ws.workout_id
+JOIN exercises e ON ws.exercise_id = e.exercise_id
+WHERE ws.resistance_type = 'weight'
+  AND ws.resistance_value IS NOT NULL 
+  AND ws.reps IS NOT NULL
+  AND ws.set_type <> 'warmup'
+GROUP BY w.user_id, e.exercise_name;
@@ -0,0 +1,9 @@
+ALTER TABLE workout_sets DROP COLUMN IF EXISTS pace_seconds_per_km;
+ALTER TABLE workout_sets DROP COLUMN IF EXISTS calories;
+ALTER TABLE workout_sets DROP COLUMN IF EXISTS distance_unit;
+ALTER TABLE workout_sets DROP COLUMN IF EXISTS distance_meters;
+ALTER TABLE workout_sets DROP COLUMN IF EXISTS duration_seconds;
+DROP TYPE IF EXISTS distance_unit_enum;
+
+ALTER TABLE exercises DROP COLUMN IF EXISTS tracking_mode;
+DROP TYPE IF EXISTS exercise_tracking_mode_enum;
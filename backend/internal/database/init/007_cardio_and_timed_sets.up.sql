@@ -0,0 +1,24 @@
+-- Tracking modes decide which set fields make sense for an exercise:
+--   reps_load     - reps x resistance (bench press, pull-ups)
+--   reps_only     - reps with no load (crunches, air squats)
+--   time          - duration only, optionally loaded (planks, dead hangs)
+--   distance      - distance only, optionally loaded (sled pushes, farmer's carries)
+--   time_distance - duration & distance (runs, rows, rides)
+CREATE TYPE exercise_tracking_mode_enum AS ENUM ('reps_load', 'reps_only', 'time', 'distance', 'time_distance');
+ALTER TABLE exercises
+    ADD COLUMN tracking_mode exercise_tracking_mode_enum NOT NULL DEFAULT 'reps_load';
+
+UPDATE exercises SET tracking_mode = 'time' WHERE exercise_name = 'Plank';
+
+-- Distance is always stored in meters; distance_unit remembers what the user logged it in so it can be shown back the same way
+CREATE TYPE distance_unit_enum AS ENUM ('m', 'km', 'mi', 'yd', 'ft');
+ALTER TABLE workout_sets
+    ADD COLUMN duration_seconds INTEGER CHECK (duration_seconds > 0),       -- Optional
+    ADD COLUMN distance_meters DECIMAL(10,2) CHECK (distance_meters > 0),   -- Optional
+    ADD COLUMN distance_unit distance_unit_enum,                            -- Optional - unit the distance was entered in
+    ADD COLUMN calories INTEGER CHECK (calories > 0),                       -- Optional
+    ADD COLUMN pace_seconds_per_km DECIMAL(8,2) GENERATED ALWAYS AS (       -- Derived - only when both duration & distance are logged
+        CASE WHEN duration_seconds IS NOT NULL AND distance_meters IS NOT NULL
+            THEN (duration_seconds / (distance_meters / 1000.0))::DECIMAL(8,2)
+        END
+    ) STORED;
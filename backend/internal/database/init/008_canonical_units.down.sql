@@ -0,0 +1,39 @@
+-- Only undo the conversion if it was actually applied, since converting twice would corrupt the data
+DO $$
+BEGIN
+    IF EXISTS (SELECT 1 FROM information_schema.columns WHERE table_name = 'user_profiles' AND column_name = 'unit_preference') THEN
+        ALTER TABLE user_profiles
+            ADD COLUMN height_inches INTEGER,
+            ADD COLUMN weight_pounds INTEGER;
+
+        UPDATE user_profiles
+        SET height_inches = ROUND(height_cm / 2.54),
+            weight_pounds = ROUND(weight_kg / 0.45359237);
+
+        ALTER TABLE user_profiles
+            DROP COLUMN height_cm,
+            DROP COLUMN weight_kg,
+            DROP COLUMN unit_preference;
+
+        DROP VIEW IF EXISTS exercise_one_rm;
+
+        ALTER TABLE workout_sets
+            ALTER COLUMN resistance_value TYPE DECIMAL(5,1) USING resistance_value / 0.45359237;
+
+        CREATE VIEW exercise_one_rm AS
+        SELECT 
+            w.user_id,
+            e.exercise_name,
+            MAX(ws.resistance_value / (1.0278 - 0.0278 * ws.reps)) as estimated_1rm
+        FROM workouts w
+        JOIN workout_sets ws ON w.workout_id = ws.workout_id
+        JOIN exercises e ON ws.exercise_id = e.exercise_id
+        WHERE ws.resistance_type = 'weight'
+          AND ws.resistance_value IS NOT NULL 
+          AND ws.reps IS NOT NULL
+          AND ws.set_type <> 'warmup'
+        GROUP BY w.user_id, e.exercise_name;
+    END IF;
+END $$;
+
+DROP TYPE IF EXISTS unit_system_enum;
@@ -0,0 +1,38 @@
+-- All weights are stored in kg and heights in cm from here on; the API converts to & from the user's preferred units.
+-- Existing values were logged in lbs/inches (the app's only units until now), so they're converted in place.
+CREATE TYPE unit_system_enum AS ENUM ('metric', 'imperial');
+
+ALTER TABLE user_profiles
+    ADD COLUMN unit_preference unit_system_enum NOT NULL DEFAULT 'imperial',
+    ADD COLUMN height_cm DECIMAL(5,2),  -- Optional
+    ADD COLUMN weight_kg DECIMAL(6,3);  -- Optional
+
+UPDATE user_profiles
+SET height_cm = height_inches * 2.54,
+    weight_kg = weight_pounds * 0.45359237;
+
+ALTER TABLE user_profiles
+    DROP COLUMN height_inches,
+    DROP COLUMN weight_pounds;
+
+-- The view depends on resistance_value, so it has to be rebuilt around the type change.
+-- 3 decimal places keep lbs -> kg -> lbs round trips exact at display precision.
+DROP VIEW IF EXISTS exercise_one_rm;
+
+ALTER TABLE workout_sets
+    ALTER COLUMN resistance_value TYPE DECIMAL(8,3) USING resistance_value * 0.45359237; -- Optional - always kg
+
+-- estimated_1rm is in kg
+CREATE VIEW exercise_one_rm AS
+SELECT 
+    w.user_id,
+    e.exercise_name,
+    MAX(ws.resistance_value / (1.0278 - 0.0278 * ws.reps)) as estimated_1rm
+FROM workouts w
+JOIN workout_sets ws ON w.workout_id = ws.workout_id
+JOIN exercises e ON ws.exercise_id = e.exercise_id
+WHERE ws.resistance_type = 'weight'
+  AND ws.resistance_value IS NOT NULL 
+  AND ws.reps IS NOT NULL
+  AND ws.set_type <> 'warmup'
+GROUP BY w.user_id, e.exercise_name;
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS personal_records;
+DROP TYPE IF EXISTS record_type_enum;
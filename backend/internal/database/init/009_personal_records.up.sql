@@ -0,0 +1,26 @@
+-- Personal records are detected whenever a set is saved. Beaten records are kept (superseded_at set) so the full PR history survives.
+--   max_weight          - heaviest weight lifted for any reps (kg)
+--   estimated_1rm       - best estimated 1RM (kg, Brzycki, sets of 10 reps or fewer)
+--   max_reps_at_weight  - most reps at one specific weight (reps; the weight is in weight_kg)
+--   set_volume          - best single-set weight x reps (kg)
+--   session_volume      - best total weight x reps for the exercise in one workout (kg)
+CREATE TYPE record_type_enum AS ENUM ('max_weight', 'estimated_1rm', 'max_reps_at_weight', 'set_volume', 'session_volume');
+CREATE TABLE personal_records (
+    record_id SERIAL PRIMARY KEY,
+    user_id INTEGER REFERENCES users(user_id) ON DELETE CASCADE NOT NULL,
+    exercise_id INTEGER REFERENCES exercises(exercise_id) NOT NULL,
+    record_type record_type_enum NOT NULL,
+    value DECIMAL(12,3) NOT NULL,
+    weight_kg DECIMAL(8,3),                     -- Optional - max_reps_at_weight only
+    workout_id INTEGER REFERENCES workouts(workout_id) ON DELETE CASCADE NOT NULL,
+    overall_workout_set_number INTEGER,         -- Optional - NULL for session_volume, which belongs to the whole workout
+    achieved_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    superseded_at TIMESTAMP WITH TIME ZONE,     -- NULL while this is the current record
+    FOREIGN KEY (workout_id, overall_workout_set_number)
+        REFERENCES workout_sets(workout_id, overall_workout_set_number) ON DELETE CASCADE
+);
+
+-- Only one current record per exercise & type (& weight, for reps-at-weight)
+CREATE UNIQUE INDEX idx_personal_records_current ON personal_records(user_id, exercise_id, record_type, COALESCE(weight_kg, 0))
+    WHERE superseded_at IS NULL;
+CREATE INDEX idx_personal_records_user_id ON personal_records(user_id);
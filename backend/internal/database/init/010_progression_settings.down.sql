@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS exercise_progression_settings;
+DROP TYPE IF EXISTS progression_strategy_enum;
@@ -0,0 +1,21 @@
+-- How the next-target engine progresses each exercise for each user. Exercises without a row use the column defaults.
+-- Deloads aren't a separate strategy: any strategy backs off once deload_after_misses sessions in a row missed their target.
+CREATE TYPE progression_strategy_enum AS ENUM ('double_progression', 'rpe');
+CREATE TABLE exercise_progression_settings (
+    user_id INTEGER REFERENCES users(user_id) ON DELETE CASCADE NOT NULL,
+    exercise_id INTEGER REFERENCES exercises(exercise_id) NOT NULL,
+    strategy progression_strategy_enum NOT NULL DEFAULT 'double_progression',
+    min_reps INTEGER NOT NULL DEFAULT 8,                    -- Bottom of the rep range; also the deload & post-increase target
+    max_reps INTEGER NOT NULL DEFAULT 12,                   -- Top of the rep range; hitting it on every set earns a load increase
+    load_increment_kg DECIMAL(6,3) NOT NULL DEFAULT 2.5,    -- Smallest jump in load; targets are rounded to multiples of it
+    target_rpe DECIMAL(3,1) NOT NULL DEFAULT 8,             -- rpe strategy only
+    deload_after_misses INTEGER NOT NULL DEFAULT 3,         -- 0 turns deloads off
+    deload_percent DECIMAL(4,1) NOT NULL DEFAULT 10,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (user_id, exercise_id),
+    CHECK (min_reps > 0 AND max_reps >= min_reps),
+    CHECK (load_increment_kg > 0),
+    CHECK (target_rpe BETWEEN 6.5 AND 10),
+    CHECK (deload_after_misses >= 0),
+    CHECK (deload_percent > 0 AND deload_percent < 100)
+);
@@ -0,0 +1,6 @@
+DROP TRIGGER IF EXISTS workouts_daily_training_load_trigger ON workouts;
+DROP TRIGGER IF EXISTS workout_sets_daily_training_load_trigger ON workout_sets;
+DROP FUNCTION IF EXISTS workouts_refresh_daily_training_load();
+DROP FUNCTION IF EXISTS workout_sets_refresh_daily_training_load();
+DROP FUNCTION IF EXISTS refresh_daily_training_load(INTEGER, DATE);
+DROP TABLE IF EXISTS daily_training_loads;
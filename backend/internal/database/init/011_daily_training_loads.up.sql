@@ -0,0 +1,82 @@
+-- Per-user, per-day rollup behind /reports/load so the rolling acute/chronic windows don't rescan every set each request.
+-- Triggers on workout_sets & workouts recompute just the affected user/day, so the table stays current as sets are logged.
+--   volume_kg        - weight x reps of weighted working sets
+--   volume_rpe_load  - weight x reps x RPE/10, for sets that have an RPE
+--   srpe_load        - session RPE (Foster): session minutes x average set RPE, for workouts with both started_at & ended_at
+CREATE TABLE daily_training_loads (
+    user_id INTEGER REFERENCES users(user_id) ON DELETE CASCADE NOT NULL,
+    load_date DATE NOT NULL,
+    sessions INTEGER NOT NULL,
+    hard_sets INTEGER NOT NULL,                  -- Non warm-up sets
+    volume_kg DECIMAL(12,3) NOT NULL,
+    volume_rpe_load DECIMAL(12,3) NOT NULL,
+    srpe_load DECIMAL(10,2) NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (user_id, load_date)
+);
+
+CREATE OR REPLACE FUNCTION refresh_daily_training_load(p_user_id INTEGER, p_date DATE)
+RETURNS VOID AS $$
+BEGIN
+    IF p_user_id IS NULL OR p_date IS NULL THEN
+        RETURN;
+    END IF;
+
+    DELETE FROM daily_training_loads WHERE user_id = p_user_id AND load_date = p_date;
+
+    WITH per_workout AS (
+        SELECT
+            COUNT(*) AS hard_sets,
+            COALESCE(SUM(ws.resistance_value * ws.reps) FILTER (WHERE ws.resistance_type = 'weight'), 0) AS volume_kg,
+            COALESCE(SUM(ws.resistance_value * ws.reps * ws.rpe / 10) FILTER (WHERE ws.resistance_type = 'weight'), 0) AS volume_rpe_load,
+            COALESCE(EXTRACT(EPOCH FROM (w.ended_at - w.started_at)) / 60 * AVG(ws.rpe), 0) AS srpe_load
+        FROM workouts w
+        JOIN workout_sets ws ON w.workout_id = ws.workout_id
+        WHERE w.user_id = p_user_id
+        AND w.workout_date = p_date
+        AND ws.set_type <> 'warmup'
+        GROUP BY w.workout_id, w.started_at, w.ended_at
+    )
+    INSERT INTO daily_training_loads (user_id, load_date, sessions, hard_sets, volume_kg, volume_rpe_load, srpe_load)
+    SELECT p_user_id, p_date, COUNT(*), SUM(hard_sets), SUM(volume_kg), SUM(volume_rpe_load), SUM(srpe_load)
+    FROM per_workout
+    HAVING COUNT(*) > 0;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE FUNCTION workout_sets_refresh_daily_training_load()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF TG_OP IN ('UPDATE', 'DELETE') THEN
+        PERFORM refresh_daily_training_load(w.user_id, w.workout_date) FROM workouts w WHERE w.workout_id = OLD.workout_id;
+    END IF;
+    IF TG_OP IN ('INSERT', 'UPDATE') THEN
+        PERFORM refresh_daily_training_load(w.user_id, w.workout_date) FROM workouts w WHERE w.workout_id = NEW.workout_id;
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER workout_sets_daily_training_load_trigger
+    AFTER INSERT OR UPDATE OR DELETE ON workout_sets
+    FOR EACH ROW
+    EXECUTE FUNCTION workout_sets_refresh_daily_training_load();
+
+-- Moving a workout to another day (or changing its times) moves its load with it
+CREATE OR REPLACE FUNCTION workouts_refresh_daily_training_load()
+RETURNS TRIGGER AS $$
+BEGIN
+    PERFORM refresh_daily_training_load(OLD.user_id, OLD.workout_date);
+    PERFORM refresh_daily_training_load(NEW.user_id, NEW.workout_date);
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER workouts_daily_training_load_trigger
+    AFTER UPDATE OF user_id, workout_date, started_at, ended_at ON workouts
+    FOR EACH ROW
+    EXECUTE FUNCTION workouts_refresh_daily_training_load();
+
+-- Backfill existing history
+SELECT refresh_daily_training_load(user_id, workout_date)
+FROM (SELECT DISTINCT user_id, workout_date FROM workouts) AS days;
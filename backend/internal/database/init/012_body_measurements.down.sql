@@ -0,0 +1,35 @@
+DROP TABLE IF EXISTS body_measurements; -- drops body_measurements_sync_trigger with it
+DROP FUNCTION IF EXISTS body_measurements_sync();
+
+-- Back to 011's weighted-sets-only rollup before the functions it would call go away
+CREATE OR REPLACE FUNCTION refresh_daily_training_load(p_user_id INTEGER, p_date DATE)
+RETURNS VOID AS $$
+BEGIN
+    IF p_user_id IS NULL OR p_date IS NULL THEN
+        RETURN;
+    END IF;
+
+    DELETE FROM daily_training_loads WHERE user_id = p_user_id AND load_date = p_date;
+
+    WITH per_workout AS (
+        SELECT
+            COUNT(*) AS hard_sets,
+            COALESCE(SUM(ws.resistance_value * ws.reps) FILTER (WHERE ws.resistance_type = 'weight'), 0) AS volume_kg,
+            COALESCE(SUM(ws.resistance_value * ws.reps * ws.rpe / 10) FILTER (WHERE ws.resistance_type = 'weight'), 0) AS volume_rpe_load,
+            COALESCE(EXTRACT(EPOCH FROM (w.ended_at - w.started_at)) / 60 * AVG(ws.rpe), 0) AS srpe_load
+        FROM workouts w
+        JOIN workout_sets ws ON w.workout_id = ws.workout_id
+        WHERE w.user_id = p_user_id
+        AND w.workout_date = p_date
+        AND ws.set_type <> 'warmup'
+        GROUP BY w.workout_id, w.started_at, w.ended_at
+    )
+    INSERT INTO daily_training_loads (user_id, load_date, sessions, hard_sets, volume_kg, volume_rpe_load, srpe_load)
+    SELECT p_user_id, p_date, COUNT(*), SUM(hard_sets), SUM(volume_kg), SUM(volume_rpe_load), SUM(srpe_load)
+    FROM per_workout
+    HAVING COUNT(*) > 0;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP FUNCTION IF EXISTS effective_load_kg(resistance_type_enum, DECIMAL, INTEGER, DATE);
+DROP FUNCTION IF EXISTS bodyweight_kg_on(INTEGER, DATE);
@@ -0,0 +1,130 @@
+-- Dated log of bodyweight, body fat & circumferences, replacing the single overwritten user_profiles.weight_kg.
+-- Weights are stored in kg & lengths in cm like everywhere else. user_profiles.weight_kg is kept in sync with the latest
+-- logged bodyweight so existing profile consumers still see the current value.
+CREATE TABLE body_measurements (
+    measurement_id SERIAL PRIMARY KEY,
+    user_id INTEGER REFERENCES users(user_id) ON DELETE CASCADE NOT NULL,
+    measured_on DATE NOT NULL,
+    bodyweight_kg DECIMAL(6,3),                 -- Optional
+    body_fat_percent DECIMAL(4,1),              -- Optional
+    neck_cm DECIMAL(5,2),                       -- Optional
+    chest_cm DECIMAL(5,2),                      -- Optional
+    waist_cm DECIMAL(5,2),                      -- Optional
+    hips_cm DECIMAL(5,2),                       -- Optional
+    arm_cm DECIMAL(5,2),                        -- Optional
+    forearm_cm DECIMAL(5,2),                    -- Optional
+    thigh_cm DECIMAL(5,2),                      -- Optional
+    calf_cm DECIMAL(5,2),                       -- Optional
+    notes TEXT,                                 -- Optional
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT body_measurements_not_empty CHECK (
+        COALESCE(bodyweight_kg, body_fat_percent, neck_cm, chest_cm, waist_cm, hips_cm, arm_cm, forearm_cm, thigh_cm, calf_cm) IS NOT NULL
+    ),
+    CONSTRAINT body_measurements_body_fat_range CHECK (body_fat_percent IS NULL OR body_fat_percent BETWEEN 0 AND 100)
+);
+
+CREATE INDEX idx_body_measurements_user_id_measured_on ON body_measurements(user_id, measured_on);
+
+-- Carry over existing profile weights as the first log entry
+INSERT INTO body_measurements (user_id, measured_on, bodyweight_kg)
+SELECT user_id, COALESCE(updated_at, created_at, CURRENT_TIMESTAMP)::date, weight_kg
+FROM user_profiles
+WHERE user_id IS NOT NULL AND weight_kg IS NOT NULL;
+
+-- Bodyweight on a date: the closest logged measurement (earlier wins a tie), else the profile weight
+CREATE OR REPLACE FUNCTION bodyweight_kg_on(p_user_id INTEGER, p_date DATE)
+RETURNS DECIMAL AS $$
+    SELECT COALESCE(
+        (SELECT bodyweight_kg
+         FROM body_measurements
+         WHERE user_id = p_user_id AND bodyweight_kg IS NOT NULL
+         ORDER BY ABS(measured_on - p_date), measured_on, measurement_id DESC
+         LIMIT 1),
+        (SELECT weight_kg FROM user_profiles WHERE user_id = p_user_id)
+    );
+$$ LANGUAGE sql STABLE;
+
+-- The load a set moved: the weight for weighted sets, bodyweight on the day plus any added weight for bodyweight sets.
+-- NULL for bands & anything else that can't be expressed in kg.
+CREATE OR REPLACE FUNCTION effective_load_kg(p_resistance_type resistance_type_enum, p_resistance_value DECIMAL, p_user_id INTEGER, p_date DATE)
+RETURNS DECIMAL AS $$
+    SELECT CASE p_resistance_type
+        WHEN 'weight' THEN p_resistance_value
+        WHEN 'bodyweight' THEN bodyweight_kg_on(p_user_id, p_date) + COALESCE(p_resistance_value, 0)
+    END;
+$$ LANGUAGE sql STABLE;
+
+-- Same as 011 but bodyweight sets now count towards volume
+CREATE OR REPLACE FUNCTION refresh_daily_training_load(p_user_id INTEGER, p_date DATE)
+RETURNS VOID AS $$
+BEGIN
+    IF p_user_id IS NULL OR p_date IS NULL THEN
+        RETURN;
+    END IF;
+
+    DELETE FROM daily_training_loads WHERE user_id = p_user_id AND load_date = p_date;
+
+    WITH per_workout AS (
+        SELECT
+            COUNT(*) AS hard_sets,
+            COALESCE(SUM(effective_load_kg(ws.resistance_type, ws.resistance_value, w.user_id, w.workout_date) * ws.reps), 0) AS volume_kg,
+            COALESCE(SUM(effective_load_kg(ws.resistance_type, ws.resistance_value, w.user_id, w.workout_date) * ws.reps * ws.rpe / 10), 0) AS volume_rpe_load,
+            COALESCE(EXTRACT(EPOCH FROM (w.ended_at - w.started_at)) / 60 * AVG(ws.rpe), 0) AS srpe_load
+        FROM workouts w
+        JOIN workout_sets ws ON w.workout_id = ws.workout_id
+        WHERE w.user_id = p_user_id
+        AND w.workout_date = p_date
+        AND ws.set_type <> 'warmup'
+        GROUP BY w.workout_id, w.started_at, w.ended_at
+    )
+    INSERT INTO daily_training_loads (user_id, load_date, sessions, hard_sets, volume_kg, volume_rpe_load, srpe_load)
+    SELECT p_user_id, p_date, COUNT(*), SUM(hard_sets), SUM(volume_kg), SUM(volume_rpe_load), SUM(srpe_load)
+    FROM per_workout
+    HAVING COUNT(*) > 0;
+END;
+$$ LANGUAGE plpgsql;
+
+-- A new or corrected bodyweight can change the closest measurement for any past bodyweight set, so every day with
+-- bodyweight sets is recomputed, and the profile weight follows the latest entry.
+CREATE OR REPLACE FUNCTION body_measurements_sync()
+RETURNS TRIGGER AS $$
+DECLARE
+    affected_user_id INTEGER := COALESCE(NEW.user_id, OLD.user_id);
+BEGIN
+    PERFORM refresh_daily_training_load(affected_user_id, days.workout_date)
+    FROM (
+        SELECT DISTINCT w.workout_date
+        FROM workouts w
+        JOIN workout_sets ws ON w.workout_id = ws.workout_id
+        WHERE w.user_id = affected_user_id AND ws.resistance_type = 'bodyweight'
+    ) AS days;
+
+    UPDATE user_profiles
+    SET weight_kg = latest.bodyweight_kg
+    FROM (
+        SELECT bodyweight_kg
+        FROM body_measurements
+        WHERE user_id = affected_user_id AND bodyweight_kg IS NOT NULL
+        ORDER BY measured_on DESC, measurement_id DESC
+        LIMIT 1
+    ) AS latest
+    WHERE user_profiles.user_id = affected_user_id;
+
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER body_measurements_sync_trigger
+    AFTER INSERT OR UPDATE OR DELETE ON body_measurements
+    FOR EACH ROW
+    EXECUTE FUNCTION body_measurements_sync();
+
+-- Recompute existing days that have bodyweight sets so they're included
+SELECT refresh_daily_training_load(days.user_id, days.workout_date)
+FROM (
+    SELECT DISTINCT w.user_id, w.workout_date
+    FROM workouts w
+    JOIN workout_sets ws ON w.workout_id = ws.workout_id
+    WHERE ws.resistance_type = 'bodyweight'
+) AS days;
@@ -0,0 +1,24 @@
+-- Restoring the UNIQUE constraint will fail if a custom exercise shares its name with another exercise; rename or delete those first.
+DROP VIEW IF EXISTS exercise_one_rm;
+CREATE VIEW exercise_one_rm AS
+SELECT 
+    w.user_id,
+    e.exercise_name,
+    MAX(ws.resistance_value / (1.0278 - 0.0278 * ws.reps)) as estimated_1rm
+FROM workouts w
+JOIN workout_sets ws ON w.workout_id = ws.workout_id
+JOIN exercises e ON ws.exercise_id = e.exercise_id
+WHERE ws.resistance_type = 'weight'
+  AND ws.resistance_value IS NOT NULL 
+  AND ws.reps IS NOT NULL
+  AND ws.set_type <> 'warmup'
+GROUP BY w.user_id, e.exercise_name;
+
+DROP INDEX IF EXISTS idx_exercises_owner_name;
+DROP INDEX IF EXISTS idx_exercises_global_name;
+
+ALTER TABLE exercises DROP COLUMN IF EXISTS owner_user_id;
+ALTER TABLE exercises DROP CONSTRAINT IF EXISTS exercises_exercise_name_key;
+ALTER TABLE exercises ADD CONSTRAINT exercises_exercise_name_key UNIQUE(exercise_name);
+
+ALTER TABLE users DROP COLUMN IF EXISTS is_admin;
@@ -0,0 +1,27 @@
+-- Exercises can now belong to a user. owner_user_id NULL is the global catalog everyone sees; anything else is that user's
+-- custom exercise, visible only to them until an admin promotes it into the catalog (which just clears the owner).
+ALTER TABLE users ADD COLUMN is_admin BOOLEAN NOT NULL DEFAULT false; -- Granted directly in the database, there's no endpoint for it
+
+ALTER TABLE exercises ADD COLUMN owner_user_id INTEGER REFERENCES users(user_id) ON DELETE CASCADE;
+
+-- Names only have to be unique within the catalog or within one user's customs, so two users can both have a "Cable Fly (Single Arm)"
+ALTER TABLE exercises DROP CONSTRAINT IF EXISTS exercises_exercise_name_key;
+CREATE UNIQUE INDEX idx_exercises_global_name ON exercises(exercise_name) WHERE owner_user_id IS NULL;
+CREATE UNIQUE INDEX idx_exercises_owner_name ON exercises(owner_user_id, exercise_name) WHERE owner_user_id IS NOT NULL;
+
+-- With names no longer unique, the view has to group by exercise or a custom "Bench Press" would merge with the catalog's
+DROP VIEW IF EXISTS exercise_one_rm;
+CREATE VIEW exercise_one_rm AS
+SELECT 
+    w.user_id,
+    e.exercise_id,
+    e.exercise_name,
+    MAX(ws.resistance_value / (1.0278 - 0.0278 * ws.reps)) as estimated_1rm
+FROM workouts w
+JOIN workout_sets ws ON w.workout_id = ws.workout_id
+JOIN exercises e ON ws.exercise_id = e.exercise_id
+WHERE ws.resistance_type = 'weight'
+  AND ws.resistance_value IS NOT NULL 
+  AND ws.reps IS NOT NULL
+  AND ws.set_type <> 'warmup'
+GROUP BY w.user_id, e.exercise_id, e.exercise_name;
@@ -0,0 +1,12 @@
+DROP INDEX IF EXISTS idx_exercises_parent_exercise_id;
+
+ALTER TABLE exercises
+    DROP CONSTRAINT IF EXISTS exercises_not_own_parent,
+    DROP COLUMN IF EXISTS parent_exercise_id,
+    DROP COLUMN IF EXISTS laterality,
+    DROP COLUMN IF EXISTS movement_pattern,
+    DROP COLUMN IF EXISTS equipment;
+
+DROP TYPE IF EXISTS laterality_enum;
+DROP TYPE IF EXISTS movement_pattern_enum;
+DROP TYPE IF EXISTS equipment_enum;
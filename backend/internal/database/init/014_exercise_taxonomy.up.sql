@@ -0,0 +1,14 @@
+-- Structured attributes for filtering the catalog. All optional, since not every exercise fits a movement pattern (e.g. a plank).
+CREATE TYPE equipment_enum AS ENUM ('barbell', 'dumbbell', 'cable', 'machine', 'band', 'bodyweight');
+CREATE TYPE movement_pattern_enum AS ENUM ('hinge', 'squat', 'push', 'pull', 'carry');
+CREATE TYPE laterality_enum AS ENUM ('bilateral', 'unilateral');
+
+ALTER TABLE exercises
+    ADD COLUMN equipment equipment_enum,
+    ADD COLUMN movement_pattern movement_pattern_enum,
+    ADD COLUMN laterality laterality_enum,
+    -- Variations point at the exercise they're a variation of (e.g. Close-Grip Bench Press -> Bench Press); one level deep in practice
+    ADD COLUMN parent_exercise_id INTEGER REFERENCES exercises(exercise_id) ON DELETE SET NULL,
+    ADD CONSTRAINT exercises_not_own_parent CHECK (parent_exercise_id <> exercise_id);
+
+CREATE INDEX idx_exercises_parent_exercise_id ON exercises(parent_exercise_id);
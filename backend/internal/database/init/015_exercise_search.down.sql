@@ -0,0 +1,4 @@
+-- The pg_trgm extension is left installed; dropping it could break anything else that has started using it.
+DROP INDEX IF EXISTS idx_exercises_description_fts;
+DROP INDEX IF EXISTS idx_exercises_name_trgm;
+DROP TABLE IF EXISTS exercise_aliases;
@@ -0,0 +1,14 @@
+-- Fuzzy search: trigram similarity on names & aliases (so "bench" and "pullup" find "Bench Press" and "Pull-up"),
+-- full-text matching on descriptions, and alternate names like "RDL" or "OHP" in exercise_aliases.
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+CREATE TABLE exercise_aliases (
+    alias_id SERIAL PRIMARY KEY,
+    exercise_id INTEGER REFERENCES exercises(exercise_id) ON DELETE CASCADE NOT NULL,
+    alias VARCHAR(100) NOT NULL,
+    UNIQUE(exercise_id, alias)
+);
+
+-- No trigram indexes: search scores every name & alias (a catalog is hundreds of rows, not millions) and the 0.3 cut-off
+-- is lower than what the indexable <% operator filters on. The description index is used by the @@ match.
+CREATE INDEX idx_exercises_description_fts ON exercises USING GIN (to_tsvector('english', COALESCE(description, '')));
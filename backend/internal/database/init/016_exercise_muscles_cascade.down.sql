@@ -0,0 +1,5 @@
+ALTER TABLE exercise_muscles
+    DROP CONSTRAINT IF EXISTS exercise_muscles_exercise_id_fkey,
+    DROP CONSTRAINT IF EXISTS exercise_muscles_muscle_id_fkey,
+    ADD CONSTRAINT exercise_muscles_exercise_id_fkey FOREIGN KEY (exercise_id) REFERENCES exercises(exercise_id),
+    ADD CONSTRAINT exercise_muscles_muscle_id_fkey FOREIGN KEY (muscle_id) REFERENCES muscles(muscle_id);
@@ -0,0 +1,7 @@
+-- Muscle mappings are editable through the API now, so deleting an exercise (e.g. a user's custom one) takes its mappings with it
+-- instead of failing on the foreign key. Same for muscles.
+ALTER TABLE exercise_muscles
+    DROP CONSTRAINT IF EXISTS exercise_muscles_exercise_id_fkey,
+    DROP CONSTRAINT IF EXISTS exercise_muscles_muscle_id_fkey,
+    ADD CONSTRAINT exercise_muscles_exercise_id_fkey FOREIGN KEY (exercise_id) REFERENCES exercises(exercise_id) ON DELETE CASCADE,
+    ADD CONSTRAINT exercise_muscles_muscle_id_fkey FOREIGN KEY (muscle_id) REFERENCES muscles(muscle_id) ON DELETE CASCADE;
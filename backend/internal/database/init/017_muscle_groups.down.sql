@@ -0,0 +1,21 @@
+DROP VIEW IF EXISTS muscle_details;
+
+-- Only put the free-text column back if it was actually replaced
+DO $$
+BEGIN
+    IF EXISTS (SELECT 1 FROM information_schema.columns WHERE table_name = 'muscles' AND column_name = 'muscle_group_id') THEN
+        ALTER TABLE muscles ADD COLUMN muscle_group VARCHAR(50);
+
+        UPDATE muscles m
+        SET muscle_group = g.group_name
+        FROM muscle_groups g
+        WHERE m.muscle_group_id = g.muscle_group_id;
+
+        ALTER TABLE muscles
+            ALTER COLUMN muscle_group SET NOT NULL,
+            DROP COLUMN muscle_group_id;
+    END IF;
+END $$;
+
+DROP TABLE IF EXISTS muscle_groups;
+DROP TYPE IF EXISTS body_region_enum;
@@ -0,0 +1,66 @@
+-- Muscle groups get their own table instead of free text on muscles, so "Back", "back" & "Upper Back" can't drift apart.
+-- Groups can nest (e.g. Legs -> Quads); muscles point at the most specific group that fits.
+CREATE TYPE body_region_enum AS ENUM ('upper_body', 'lower_body', 'core');
+CREATE TABLE muscle_groups (
+    muscle_group_id SERIAL PRIMARY KEY,
+    group_name VARCHAR(50) NOT NULL,
+    body_region body_region_enum,
+    parent_group_id INTEGER REFERENCES muscle_groups(muscle_group_id),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT muscle_groups_not_own_parent CHECK (parent_group_id <> muscle_group_id)
+);
+CREATE UNIQUE INDEX idx_muscle_groups_group_name ON muscle_groups(lower(group_name));
+
+INSERT INTO muscle_groups (group_name, body_region) VALUES
+    ('Chest', 'upper_body'),
+    ('Back', 'upper_body'),
+    ('Shoulders', 'upper_body'),
+    ('Arms', 'upper_body'),
+    ('Legs', 'lower_body'),
+    ('Glutes', 'lower_body'),
+    ('Core', 'core'),
+    ('Lower Back', 'core');
+
+-- Normalize the existing free text: trim & ignore case, fold common synonyms into the groups above, and give anything
+-- left over a group of its own
+CREATE TEMPORARY TABLE muscle_group_synonyms (synonym TEXT PRIMARY KEY, group_name TEXT NOT NULL);
+INSERT INTO muscle_group_synonyms VALUES
+    ('upper back', 'Back'), ('mid back', 'Back'), ('lats', 'Back'), ('traps', 'Back'),
+    ('pecs', 'Chest'), ('delts', 'Shoulders'), ('shoulder', 'Shoulders'), ('arm', 'Arms'),
+    ('leg', 'Legs'), ('glute', 'Glutes'), ('abs', 'Core'), ('abdominals', 'Core'), ('lower-back', 'Lower Back');
+
+INSERT INTO muscle_groups (group_name)
+SELECT DISTINCT ON (lower(trim(m.muscle_group))) initcap(trim(m.muscle_group))
+FROM muscles m
+WHERE NOT EXISTS (SELECT 1 FROM muscle_groups g WHERE lower(g.group_name) = lower(trim(m.muscle_group)))
+AND NOT EXISTS (SELECT 1 FROM muscle_group_synonyms s WHERE s.synonym = lower(trim(m.muscle_group)));
+
+ALTER TABLE muscles ADD COLUMN muscle_group_id INTEGER REFERENCES muscle_groups(muscle_group_id);
+
+UPDATE muscles m
+SET muscle_group_id = g.muscle_group_id
+FROM muscle_groups g
+WHERE lower(g.group_name) = COALESCE(
+    (SELECT lower(s.group_name) FROM muscle_group_synonyms s WHERE s.synonym = lower(trim(m.muscle_group))),
+    lower(trim(m.muscle_group))
+);
+
+DROP TABLE muscle_group_synonyms;
+
+ALTER TABLE muscles
+    ALTER COLUMN muscle_group_id SET NOT NULL,
+    DROP COLUMN muscle_group;
+
+CREATE INDEX idx_muscles_muscle_group_id ON muscles(muscle_group_id);
+
+-- Muscles with their group's name & region, which is how the API & reports want them
+CREATE VIEW muscle_details AS
+SELECT
+    m.muscle_id,
+    m.muscle_name,
+    m.muscle_group_id,
+    g.group_name AS muscle_group,
+    g.body_region,
+    m.created_at
+FROM muscles m
+JOIN muscle_groups g ON m.muscle_group_id = g.muscle_group_id;
@@ -0,0 +1,3 @@
+ALTER TABLE user_profiles
+    DROP CONSTRAINT IF EXISTS user_profiles_weekly_session_target_range,
+    DROP COLUMN IF EXISTS weekly_session_target;
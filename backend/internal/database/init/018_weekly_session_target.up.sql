@@ -0,0 +1,4 @@
+-- How many sessions a week the user is aiming for; the calendar measures weekly adherence against it
+ALTER TABLE user_profiles
+    ADD COLUMN weekly_session_target INTEGER, -- Optional - NULL means no target
+    ADD CONSTRAINT user_profiles_weekly_session_target_range CHECK (weekly_session_target BETWEEN 1 AND 14);
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS goals;
+DROP TYPE IF EXISTS goal_status_enum;
+DROP TYPE IF EXISTS goal_type_enum;
@@ -0,0 +1,29 @@
+-- Goals like "Squat 315 lb by June", "Bodyweight 180" or "Train 4x/week for 8 weeks". Progress is always computed from
+-- the logged data; only the status is stored, so finished goals keep their outcome.
+--   estimated_1rm    - an exercise's best estimated 1RM (kg)
+--   max_weight       - the heaviest weight lifted on an exercise for any reps (kg)
+--   bodyweight       - the latest bodyweight (kg); a target below the starting weight means losing
+--   weekly_sessions  - sessions in every 7-day block from start_date to the deadline
+CREATE TYPE goal_type_enum AS ENUM ('estimated_1rm', 'max_weight', 'bodyweight', 'weekly_sessions');
+CREATE TYPE goal_status_enum AS ENUM ('active', 'completed', 'missed', 'abandoned');
+CREATE TABLE goals (
+    goal_id SERIAL PRIMARY KEY,
+    user_id INTEGER REFERENCES users(user_id) ON DELETE CASCADE NOT NULL,
+    goal_type goal_type_enum NOT NULL,
+    exercise_id INTEGER REFERENCES exercises(exercise_id) ON DELETE CASCADE, -- estimated_1rm & max_weight only
+    target_value DECIMAL(8,3) NOT NULL,         -- kg, or sessions per week
+    start_value DECIMAL(8,3),                   -- Optional - where the user stood when the goal was set
+    start_date DATE NOT NULL DEFAULT CURRENT_DATE,
+    deadline DATE,                              -- Optional - except for weekly_sessions, where it ends the last block
+    status goal_status_enum NOT NULL DEFAULT 'active',
+    completed_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT goals_exercise_required CHECK ((goal_type IN ('estimated_1rm', 'max_weight')) = (exercise_id IS NOT NULL)),
+    CONSTRAINT goals_weekly_sessions_deadline CHECK (goal_type <> 'weekly_sessions' OR deadline IS NOT NULL),
+    CONSTRAINT goals_target_positive CHECK (target_value > 0),
+    CONSTRAINT goals_deadline_after_start CHECK (deadline IS NULL OR deadline >= start_date)
+);
+
+-- Completion checks run on every set save and only look at active goals
+CREATE INDEX idx_goals_user_id_status ON goals(user_id, status);
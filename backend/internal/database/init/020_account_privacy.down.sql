@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS account_erasures;
+DROP TABLE IF EXISTS account_data_exports;
+DROP TYPE IF EXISTS account_export_status_enum;
@@ -0,0 +1,28 @@
+-- Privacy requests: "download my data" archives built in the background, and account erasure after a grace period.
+-- Archives are kept in the database (there's no file storage) until they expire.
+CREATE TYPE account_export_status_enum AS ENUM ('pending', 'running', 'completed', 'failed');
+CREATE TABLE account_data_exports (
+    export_id SERIAL PRIMARY KEY,
+    user_id INTEGER REFERENCES users(user_id) ON DELETE CASCADE NOT NULL,
+    status account_export_status_enum NOT NULL DEFAULT 'pending',
+    archive BYTEA,                              -- Optional - the ZIP, once completed
+    size_bytes INTEGER,
+    error TEXT,                                 -- Optional - why a failed export failed
+    requested_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    started_at TIMESTAMP WITH TIME ZONE,
+    completed_at TIMESTAMP WITH TIME ZONE,
+    expires_at TIMESTAMP WITH TIME ZONE         -- Optional - when the archive is deleted
+);
+
+-- The worker picks up the oldest pending export
+CREATE INDEX idx_account_data_exports_status ON account_data_exports(status, requested_at);
+CREATE INDEX idx_account_data_exports_user_id ON account_data_exports(user_id);
+-- One export in the works per user at a time
+CREATE UNIQUE INDEX idx_account_data_exports_one_in_progress ON account_data_exports(user_id) WHERE status IN ('pending', 'running');
+
+-- One row per account waiting to be erased; cancelling deletes the row, erasing the account deletes it by cascade
+CREATE TABLE account_erasures (
+    user_id INTEGER PRIMARY KEY REFERENCES users(user_id) ON DELETE CASCADE,
+    requested_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    scheduled_for TIMESTAMP WITH TIME ZONE NOT NULL
+);
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS calendar_feed_tokens;
+ALTER TABLE user_profiles
+    DROP COLUMN IF EXISTS timezone;
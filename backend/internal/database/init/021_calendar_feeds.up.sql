@@ -0,0 +1,11 @@
+-- Where "today" is for the calendar feed
+ALTER TABLE user_profiles
+    ADD COLUMN timezone VARCHAR(64); -- Optional - an IANA name like 'Europe/London', NULL means UTC
+
+-- The secret behind each user's calendar feed URL. Calendar apps can't send a JWT, so knowing the token is the access
+-- check; regenerating replaces the row (revoking the old URL) & deleting it turns the feed off.
+CREATE TABLE calendar_feed_tokens (
+    user_id INTEGER PRIMARY KEY REFERENCES users(user_id) ON DELETE CASCADE,
+    token VARCHAR(64) UNIQUE NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
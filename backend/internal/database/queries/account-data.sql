@@ -0,0 +1,165 @@
+-- name: CreateAccountDataExport :one
+INSERT INTO account_data_exports (user_id)
+VALUES ($1)
+RETURNING export_id, user_id, status, size_bytes, error, requested_at, started_at, completed_at, expires_at;
+
+-- Newest first; the archives themselves are only read by GetAccountDataExportArchive
+-- name: GetAccountDataExportsForUser :many
+SELECT export_id, user_id, status, size_bytes, error, requested_at, started_at, completed_at, expires_at
+FROM account_data_exports
+WHERE user_id = $1
+ORDER BY requested_at DESC, export_id DESC;
+
+-- name: GetAccountDataExportByIDForUser :one
+SELECT export_id, user_id, status, size_bytes, error, requested_at, started_at, completed_at, expires_at
+FROM account_data_exports
+WHERE export_id = $1 AND user_id = $2;
+
+-- No rows unless the export is completed & hasn't expired yet
+-- name: GetAccountDataExportArchive :one
+SELECT archive
+FROM account_data_exports
+WHERE export_id = $1 AND user_id = $2
+AND status = 'completed' AND archive IS NOT NULL AND expires_at > CURRENT_TIMESTAMP;
+
+-- Marks the oldest pending export as running & returns it. Exports stuck "running" for half an hour were interrupted
+-- (e.g. by a restart) & are picked up again. SKIP LOCKED keeps two workers from claiming the same one.
+-- name: ClaimNextAccountDataExport :one
+UPDATE account_data_exports
+SET status = 'running', started_at = CURRENT_TIMESTAMP
+WHERE export_id = (
+  SELECT export_id
+  FROM account_data_exports
+  WHERE status = 'pending'
+  OR (status = 'running' AND started_at < CURRENT_TIMESTAMP - INTERVAL '30 minutes')
+  ORDER BY requested_at
+  LIMIT 1
+  FOR UPDATE SKIP LOCKED
+)
+RETURNING export_id, user_id;
+
+-- name: CompleteAccountDataExport :exec
+UPDATE account_data_exports
+SET
+  status = 'completed',
+  archive = sqlc.arg('archive'),
+  size_bytes = octet_length(sqlc.arg('archive')),
+  completed_at = CURRENT_TIMESTAMP,
+  expires_at = sqlc.arg('expires_at')
+WHERE export_id = sqlc.arg('export_id');
+
+-- name: FailAccountDataExport :exec
+UPDATE account_data_exports
+SET status = 'failed', error = $2, completed_at = CURRENT_TIMESTAMP
+WHERE export_id = $1;
+
+-- Expired archives are dropped but the export itself is kept, so downloading it says it expired rather than that it
+-- never existed; the user can always ask for a new one
+-- name: ClearExpiredAccountDataExportArchives :execrows
+UPDATE account_data_exports
+SET archive = NULL
+WHERE expires_at <= CURRENT_TIMESTAMP
+AND archive IS NOT NULL;
+
+-- Everything stored about a user, one JSON document per file of their data export. A single statement, so the files
+-- are one consistent snapshot. password_hash is left out.
+-- name: GetAccountDataJSON :one
+SELECT
+  COALESCE((
+    SELECT row_to_json(u) FROM (
+      SELECT user_id, email, username, active, is_admin, created_at, updated_at, last_login
+      FROM users
+      WHERE user_id = $1
+    ) u
+  )::text, 'null')::text AS user_json,
+  COALESCE((SELECT row_to_json(p) FROM user_profiles p WHERE p.user_id = $1)::text, 'null')::text AS profile_json,
+  COALESCE((
+    SELECT json_agg(w ORDER BY w.workout_date, w.workout_id)
+    FROM workouts w
+    WHERE w.user_id = $1
+  )::text, '[]')::text AS workouts_json,
+  COALESCE((
+    SELECT json_agg(s ORDER BY s.workout_id, s.overall_workout_set_number) FROM (
+      SELECT ws.*, e.exercise_name
+      FROM workout_sets ws
+      JOIN workouts w ON ws.workout_id = w.workout_id
+      JOIN exercises e ON ws.exercise_id = e.exercise_id
+      WHERE w.user_id = $1
+    ) s
+  )::text, '[]')::text AS workout_sets_json,
+  COALESCE((
+    SELECT json_agg(g ORDER BY g.workout_id, g.group_id)
+    FROM workout_set_groups g
+    JOIN workouts w ON g.workout_id = w.workout_id
+    WHERE w.user_id = $1
+  )::text, '[]')::text AS workout_set_groups_json,
+  COALESCE((
+    SELECT json_agg(x ORDER BY x.exercise_id) FROM (
+      SELECT e.*, COALESCE((
+        SELECT json_agg(a.alias ORDER BY a.alias) FROM exercise_aliases a WHERE a.exercise_id = e.exercise_id
+      ), '[]') AS aliases
+      FROM exercises e
+      WHERE e.owner_user_id = $1
+    ) x
+  )::text, '[]')::text AS custom_exercises_json,
+  COALESCE((
+    SELECT json_agg(b ORDER BY b.measured_on, b.measurement_id)
+    FROM body_measurements b
+    WHERE b.user_id = $1
+  )::text, '[]')::text AS body_measurements_json,
+  COALESCE((
+    SELECT json_agg(pr ORDER BY pr.achieved_at, pr.record_id)
+    FROM personal_records pr
+    WHERE pr.user_id = $1
+  )::text, '[]')::text AS personal_records_json,
+  COALESCE((
+    SELECT json_agg(ps ORDER BY ps.exercise_id)
+    FROM exercise_progression_settings ps
+    WHERE ps.user_id = $1
+  )::text, '[]')::text AS progression_settings_json,
+  COALESCE((
+    SELECT json_agg(gl ORDER BY gl.goal_id)
+    FROM goals gl
+    WHERE gl.user_id = $1
+  )::text, '[]')::text AS goals_json;
+
+-- name: CreateAccountErasure :one
+INSERT INTO account_erasures (user_id, scheduled_for)
+VALUES ($1, $2)
+RETURNING *;
+
+-- name: GetAccountErasureForUser :one
+SELECT * FROM account_erasures
+WHERE user_id = $1;
+
+-- Cancels a scheduled erasure
+-- name: DeleteAccountErasure :one
+DELETE FROM account_erasures
+WHERE user_id = $1
+RETURNING user_id;
+
+-- name: GetDueAccountErasures :many
+SELECT user_id
+FROM account_erasures
+WHERE scheduled_for <= CURRENT_TIMESTAMP
+ORDER BY scheduled_for;
+
+-- The erasure itself, run in this order in one transaction. Sets & workouts don't cascade from users, so they go first;
+-- deleting the user then takes every other user_id-keyed row with it (profile aside, which also doesn't cascade):
+-- personal records, progression settings, training loads, body measurements, custom exercises, goals, data exports &
+-- the erasure request itself.
+-- name: EraseWorkoutSetsForUser :exec
+DELETE FROM workout_sets
+WHERE workout_id IN (SELECT workout_id FROM workouts WHERE user_id = $1);
+
+-- name: EraseWorkoutsForUser :exec
+DELETE FROM workouts
+WHERE user_id = $1;
+
+-- name: EraseUserProfile :exec
+DELETE FROM user_profiles
+WHERE user_id = $1;
+
+-- name: EraseUser :exec
+DELETE FROM users
+WHERE user_id = $1;
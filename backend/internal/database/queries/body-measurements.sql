@@ -0,0 +1,85 @@
+-- name: CreateBodyMeasurement :one
+INSERT INTO body_measurements (
+    user_id,
+    measured_on,
+    bodyweight_kg,
+    body_fat_percent,
+    neck_cm,
+    chest_cm,
+    waist_cm,
+    hips_cm,
+    arm_cm,
+    forearm_cm,
+    thigh_cm,
+    calf_cm,
+    notes
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13
+) RETURNING *;
+
+-- Oldest first, so the list doubles as a time series
+-- name: GetBodyMeasurementsForUser :many
+SELECT * FROM body_measurements
+WHERE user_id = sqlc.arg('user_id')
+AND measured_on BETWEEN sqlc.arg('from_date')::date AND sqlc.arg('to_date')::date
+ORDER BY measured_on, measurement_id;
+
+-- name: GetBodyMeasurementByIDForUser :one
+SELECT * FROM body_measurements
+WHERE measurement_id = $1
+AND user_id = $2;
+
+-- Fields left NULL keep their current value
+-- name: UpdateBodyMeasurement :one
+UPDATE body_measurements
+SET
+    measured_on = COALESCE(sqlc.narg('measured_on')::date, measured_on),
+    bodyweight_kg = COALESCE(sqlc.narg('bodyweight_kg')::decimal, bodyweight_kg),
+    body_fat_percent = COALESCE(sqlc.narg('body_fat_percent')::decimal, body_fat_percent),
+    neck_cm = COALESCE(sqlc.narg('neck_cm')::decimal, neck_cm),
+    chest_cm = COALESCE(sqlc.narg('chest_cm')::decimal, chest_cm),
+    waist_cm = COALESCE(sqlc.narg('waist_cm')::decimal, waist_cm),
+    hips_cm = COALESCE(sqlc.narg('hips_cm')::decimal, hips_cm),
+    arm_cm = COALESCE(sqlc.narg('arm_cm')::decimal, arm_cm),
+    forearm_cm = COALESCE(sqlc.narg('forearm_cm')::decimal, forearm_cm),
+    thigh_cm = COALESCE(sqlc.narg('thigh_cm')::decimal, thigh_cm),
+    calf_cm = COALESCE(sqlc.narg('calf_cm')::decimal, calf_cm),
+    notes = COALESCE(sqlc.narg('notes'), notes),
+    updated_at = CURRENT_TIMESTAMP
+WHERE measurement_id = sqlc.arg('measurement_id')
+AND user_id = sqlc.arg('user_id')
+RETURNING *;
+
+-- name: DeleteBodyMeasurement :one
+DELETE FROM body_measurements
+WHERE measurement_id = $1
+AND user_id = $2
+RETURNING *;
+
+-- Each exercise's best estimated 1RM relative to bodyweight on the day it was lifted (Brzycki, sets of 1-10 reps).
+-- Bodyweight exercises count the lifter's bodyweight as part of the load.
+-- name: GetRelativeStrengthForUser :many
+SELECT DISTINCT ON (e.exercise_id)
+  e.exercise_id,
+  e.exercise_name,
+  s.workout_date,
+  (s.load_kg / (1.0278 - 0.0278 * s.reps))::decimal AS estimated_1rm_kg,
+  s.bodyweight_kg::decimal AS bodyweight_kg,
+  (s.load_kg / (1.0278 - 0.0278 * s.reps) / s.bodyweight_kg)::decimal AS relative_strength
+FROM (
+  SELECT
+    ws.exercise_id,
+    w.workout_date,
+    ws.reps,
+    effective_load_kg(ws.resistance_type, ws.resistance_value, w.user_id, w.workout_date) AS load_kg,
+    bodyweight_kg_on(w.user_id, w.workout_date) AS bodyweight_kg
+  FROM workout_sets ws
+  JOIN workouts w ON ws.workout_id = w.workout_id
+  WHERE w.user_id = $1
+  AND ws.set_type <> 'warmup'
+  AND ws.reps BETWEEN 1 AND 10
+) s
+JOIN exercises e ON s.exercise_id = e.exercise_id
+WHERE s.load_kg > 0
+AND s.bodyweight_kg > 0
+ORDER BY e.exercise_id, relative_strength DESC;
@@ -0,0 +1,56 @@
+-- A new token replaces the old one, so the previous feed URL stops working straight away
+-- name: UpsertCalendarFeedToken :one
+INSERT INTO calendar_feed_tokens (user_id, token)
+VALUES ($1, $2)
+ON CONFLICT (user_id) DO UPDATE
+SET token = EXCLUDED.token, created_at = CURRENT_TIMESTAMP
+RETURNING user_id, token, created_at;
+
+-- name: GetCalendarFeedTokenForUser :one
+SELECT user_id, token, created_at
+FROM calendar_feed_tokens
+WHERE user_id = $1;
+
+-- name: DeleteCalendarFeedToken :execrows
+DELETE FROM calendar_feed_tokens
+WHERE user_id = $1;
+
+-- Deactivated accounts get no feed; a missing profile just means no timezone
+-- name: GetCalendarFeedOwner :one
+SELECT t.user_id, up.timezone
+FROM calendar_feed_tokens t
+JOIN users u ON u.user_id = t.user_id
+LEFT JOIN user_profiles up ON up.user_id = t.user_id
+WHERE t.token = $1 AND u.active = true;
+
+-- READ: Workouts from a date on, each with its exercises in the order they're done ("Bench Press - 3 sets", one a line)
+-- and when it or its sets last changed, for the feed's LAST-MODIFIED
+-- name: GetCalendarFeedWorkouts :many
+SELECT
+  w.workout_id,
+  w.workout_date,
+  w.title,
+  w.started_at,
+  w.ended_at,
+  COALESCE((
+    SELECT string_agg(
+      x.exercise_name || ' - ' || x.set_count || CASE WHEN x.set_count = 1 THEN ' set' ELSE ' sets' END,
+      E'\n' ORDER BY x.first_set
+    )
+    FROM (
+      SELECT e.exercise_name, COUNT(*) set_count, MIN(ws.overall_workout_set_number) first_set
+      FROM workout_sets ws
+      JOIN exercises e ON e.exercise_id = ws.exercise_id
+      WHERE ws.workout_id = w.workout_id
+      GROUP BY e.exercise_id, e.exercise_name
+    ) x
+  ), '')::text exercises,
+  COALESCE(GREATEST(
+    w.created_at,
+    w.updated_at,
+    (SELECT MAX(ws.created_at) FROM workout_sets ws WHERE ws.workout_id = w.workout_id)
+  ), CURRENT_TIMESTAMP)::timestamptz last_modified
+FROM workouts w
+WHERE w.user_id = sqlc.arg('user_id') AND w.workout_date >= sqlc.arg('from_date')::date
+ORDER BY w.workout_date, w.started_at NULLS LAST, w.workout_id
+LIMIT sqlc.arg('max_workouts');
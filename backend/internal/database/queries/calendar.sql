@@ -0,0 +1,48 @@
+-- One row per workout in the range with its set counts & tonnage (weighted & bodyweight sets, warm-ups left out)
+-- name: GetCalendarWorkouts :many
+SELECT
+  w.workout_id,
+  w.workout_date,
+  w.title,
+  w.started_at,
+  w.ended_at,
+  COUNT(ws.overall_workout_set_number)::int AS set_count,
+  COUNT(ws.completed_at)::int AS completed_set_count,
+  COALESCE(SUM(effective_load_kg(ws.resistance_type, ws.resistance_value, w.user_id, w.workout_date) * ws.reps)
+    FILTER (WHERE ws.set_type <> 'warmup'), 0)::decimal AS tonnage_kg
+FROM workouts w
+LEFT JOIN workout_sets ws ON w.workout_id = ws.workout_id
+WHERE w.user_id = sqlc.arg('user_id')
+AND w.workout_date BETWEEN sqlc.arg('from_date')::date AND sqlc.arg('to_date')::date
+GROUP BY w.workout_id
+ORDER BY w.workout_date, w.started_at NULLS LAST, w.workout_id;
+
+-- The muscle groups each workout in the range hit as a primary mover
+-- name: GetCalendarPrimaryMuscleGroups :many
+SELECT DISTINCT
+  w.workout_id,
+  m.muscle_group
+FROM workouts w
+JOIN workout_sets ws ON w.workout_id = ws.workout_id
+JOIN exercise_muscles em ON ws.exercise_id = em.exercise_id AND em.involvement_level = 'primary'
+JOIN muscle_details m ON em.muscle_id = m.muscle_id
+WHERE w.user_id = sqlc.arg('user_id')
+AND w.workout_date BETWEEN sqlc.arg('from_date')::date AND sqlc.arg('to_date')::date
+ORDER BY w.workout_id, m.muscle_group;
+
+-- Every day the user trained, with how many sessions: workouts up to today with logged sets, matching the calendar's
+-- "completed" status. A running session counts once a set has been ticked off; any other workout once it has sets.
+-- name: GetTrainingDaysForUser :many
+SELECT
+  w.workout_date,
+  COUNT(*)::int AS sessions
+FROM workouts w
+WHERE w.user_id = sqlc.arg('user_id')
+AND w.workout_date <= sqlc.arg('today')::date
+AND EXISTS (
+  SELECT 1 FROM workout_sets ws
+  WHERE ws.workout_id = w.workout_id
+  AND (ws.completed_at IS NOT NULL OR w.started_at IS NULL OR w.ended_at IS NOT NULL)
+)
+GROUP BY w.workout_date
+ORDER BY w.workout_date;
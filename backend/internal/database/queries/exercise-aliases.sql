@@ -0,0 +1,4 @@
+-- name: CreateExerciseAlias :one
+INSERT INTO exercise_aliases (exercise_id, alias)
+VALUES ($1, $2)
+RETURNING *;
@@ -17,9 +17,11 @@ SELECT
   m.muscle_group
 FROM exercise_muscles em
 JOIN exercises e ON em.exercise_id = e.exercise_id
-JOIN muscles m ON em.muscle_id = m.muscle_id
-WHERE em.exercise_id = $1;
+JOIN muscle_details m ON em.muscle_id = m.muscle_id
+WHERE em.exercise_id = $1
+ORDER BY em.involvement_level, m.muscle_name;
 
+-- Only exercises the user can see: the global catalog plus their own customs
 -- name: GetMuscleExercises :many
 SELECT 
   em.*,
@@ -28,8 +30,10 @@ SELECT
   m.muscle_group
 FROM exercise_muscles em
 JOIN exercises e ON em.exercise_id = e.exercise_id
-JOIN muscles m ON em.muscle_id = m.muscle_id
-WHERE em.muscle_id = $1;
+JOIN muscle_details m ON em.muscle_id = m.muscle_id
+WHERE em.muscle_id = sqlc.arg('muscle_id')
+AND (e.owner_user_id IS NULL OR e.owner_user_id = sqlc.arg('user_id'))
+ORDER BY em.involvement_level, e.exercise_name;
 
 -- name: UpdateExerciseMuscle :one
 UPDATE exercise_muscles 
@@ -52,7 +56,7 @@ SELECT
   m.muscle_group
 FROM exercise_muscles em
 JOIN exercises e ON em.exercise_id = e.exercise_id
-JOIN muscles m ON em.muscle_id = m.muscle_id
+JOIN muscle_details m ON em.muscle_id = m.muscle_id
 ORDER BY e.exercise_name, m.muscle_name;
 
 -- name: ExerciseMuscleExists :one
@@ -69,7 +73,7 @@ SELECT
   m.muscle_group
 FROM exercise_muscles em
 JOIN exercises e ON em.exercise_id = e.exercise_id
-JOIN muscles m ON em.muscle_id = m.muscle_id
+JOIN muscle_details m ON em.muscle_id = m.muscle_id
 WHERE m.muscle_group = $1
 ORDER BY e.exercise_name;
 
@@ -78,7 +82,7 @@ SELECT
   m.muscle_name,
   m.muscle_group
 FROM exercise_muscles em
-JOIN muscles m ON em.muscle_id = m.muscle_id
+JOIN muscle_details m ON em.muscle_id = m.muscle_id
 WHERE em.exercise_id = $1 
-AND em.involvement_level = 'Primary'
+AND em.involvement_level = 'primary'
 ORDER BY m.muscle_name;
\ No newline at end of file
@@ -1,40 +1,139 @@
 -- name: CreateExercise :one
 INSERT INTO exercises (
     exercise_name,
-    description
+    description,
+    tracking_mode,
+    owner_user_id,
+    equipment,
+    movement_pattern,
+    laterality,
+    parent_exercise_id
 ) VALUES (
-    $1, $2
+    $1, $2, $3, $4, $5, $6, $7, $8
 ) RETURNING *;
 
 -- name: GetExerciseById :one
 SELECT * FROM exercises 
 WHERE exercise_id = $1;
 
+-- Only exercises the user can see: the global catalog plus their own customs
+-- name: GetExerciseByIDForUser :one
+SELECT * FROM exercises
+WHERE exercise_id = sqlc.arg('exercise_id')
+AND (owner_user_id IS NULL OR owner_user_id = sqlc.arg('user_id'));
+
+-- A user's own custom exercise wins over a global one with the same name
 -- name: GetExerciseByName :one
 SELECT * FROM exercises 
-WHERE exercise_name = $1;
+WHERE exercise_name = sqlc.arg('exercise_name')
+AND (owner_user_id IS NULL OR owner_user_id = sqlc.arg('user_id'))
+ORDER BY owner_user_id NULLS LAST
+LIMIT 1;
 
 -- name: GetAllExercises :many
 SELECT * FROM exercises 
 ORDER BY exercise_id;
 
+-- The global catalog merged with the user's custom exercises; each taxonomy filter is skipped when NULL
+-- name: GetExercisesForUser :many
+SELECT * FROM exercises
+WHERE (owner_user_id IS NULL OR owner_user_id = sqlc.arg('user_id'))
+AND (sqlc.narg('equipment')::equipment_enum IS NULL OR equipment = sqlc.narg('equipment'))
+AND (sqlc.narg('movement_pattern')::movement_pattern_enum IS NULL OR movement_pattern = sqlc.narg('movement_pattern'))
+AND (sqlc.narg('laterality')::laterality_enum IS NULL OR laterality = sqlc.narg('laterality'))
+AND (sqlc.narg('parent_exercise_id')::int IS NULL OR parent_exercise_id = sqlc.narg('parent_exercise_id'))
+ORDER BY exercise_id;
+
 -- name: UpdateExercise :one
 UPDATE exercises 
 SET exercise_name = $2, description = $3
 WHERE exercise_id = $1
 RETURNING *;
 
+-- Moves a custom exercise into the global catalog; no rows if it's already global
+-- name: PromoteExercise :one
+UPDATE exercises
+SET owner_user_id = NULL
+WHERE exercise_id = $1
+AND owner_user_id IS NOT NULL
+RETURNING *;
+
 -- name: ExerciseExists :one
 SELECT EXISTS(
   SELECT 1 FROM exercises 
   WHERE exercise_name = $1
 );
 
+-- Fuzzy search over names, aliases & descriptions, best match per exercise, boosted by how often the user has done it lately
 -- name: SearchExercises :many
-SELECT * FROM exercises 
-WHERE exercise_name ILIKE $1 
-ORDER BY exercise_name 
-LIMIT $2;
+WITH matches AS (
+  -- names & aliases are compared with & without punctuation/spaces, so "pullup" matches "Pull-up"
+  SELECT
+    e.exercise_id,
+    'exercise_name' AS matched_field,
+    e.exercise_name::text AS matched_text,
+    GREATEST(
+      word_similarity(lower(sqlc.arg('query')), lower(e.exercise_name)),
+      similarity(regexp_replace(lower(e.exercise_name), '[^a-z0-9]', '', 'g'), regexp_replace(lower(sqlc.arg('query')), '[^a-z0-9]', '', 'g'))
+    ) AS score
+  FROM exercises e
+  UNION ALL
+  SELECT
+    a.exercise_id,
+    'alias',
+    a.alias::text,
+    GREATEST(
+      word_similarity(lower(sqlc.arg('query')), lower(a.alias)),
+      similarity(regexp_replace(lower(a.alias), '[^a-z0-9]', '', 'g'), regexp_replace(lower(sqlc.arg('query')), '[^a-z0-9]', '', 'g'))
+    )
+  FROM exercise_aliases a
+  UNION ALL
+  -- description hits count for less than name hits; they're mostly there to catch muscle & equipment words
+  SELECT
+    e.exercise_id,
+    'description',
+    e.description::text,
+    0.3 + ts_rank(to_tsvector('english', COALESCE(e.description, '')), plainto_tsquery('english', sqlc.arg('query')))
+  FROM exercises e
+  WHERE to_tsvector('english', COALESCE(e.description, '')) @@ plainto_tsquery('english', sqlc.arg('query'))
+),
+best_match AS (
+  SELECT DISTINCT ON (exercise_id) exercise_id, matched_field, matched_text, score
+  FROM matches
+  WHERE score >= 0.3
+  ORDER BY exercise_id, score DESC
+),
+recent_sets AS (
+  SELECT ws.exercise_id, COUNT(*) AS set_count
+  FROM workout_sets ws
+  JOIN workouts w ON ws.workout_id = w.workout_id
+  WHERE w.user_id = sqlc.arg('user_id')
+  AND w.workout_date >= CURRENT_DATE - 90
+  GROUP BY ws.exercise_id
+)
+SELECT
+  e.*,
+  b.matched_field::text AS matched_field,
+  b.matched_text::text AS matched_text,
+  COALESCE(r.set_count, 0)::int AS recent_set_count,
+  -- up to +0.2 for exercises the user has done a lot of in the last 90 days
+  (b.score + LEAST(COALESCE(r.set_count, 0), 20) * 0.01)::float8 AS rank
+FROM best_match b
+JOIN exercises e ON b.exercise_id = e.exercise_id
+LEFT JOIN recent_sets r ON b.exercise_id = r.exercise_id
+WHERE e.owner_user_id IS NULL OR e.owner_user_id = sqlc.arg('user_id')
+ORDER BY rank DESC, e.exercise_name
+LIMIT sqlc.arg('limit');
+
+-- Estimated 1RMs come out of the view in kg
+-- name: GetEstimatedOneRepMaxesForUser :many
+SELECT
+  exercise_id,
+  exercise_name,
+  estimated_1rm::decimal AS estimated_1rm_kg
+FROM exercise_one_rm
+WHERE user_id = $1
+ORDER BY exercise_name, exercise_id;
 
 -- name: DeleteExercise :one
 DELETE FROM exercises 
@@ -42,4 +141,71 @@ WHERE exercise_id = $1
 RETURNING *;
 
 -- name: DeleteAllExercises :exec
-DELETE FROM exercises;
\ No newline at end of file
+DELETE FROM exercises;
+
+-- Other visible exercises with the same tracking mode that work the same muscles or share the movement pattern.
+-- Muscle overlap is 0-1: each of the source's muscles scores 4 when both sides list it as primary, 2 when one does and
+-- 1 when neither does, out of the most the source's own muscles could score. An empty equipment list means no filter;
+-- exercises with no equipment recorded are always kept.
+-- name: GetExerciseAlternatives :many
+WITH source AS (
+  SELECT * FROM exercises
+  WHERE exercise_id = sqlc.arg('exercise_id')
+),
+source_muscles AS (
+  SELECT muscle_id, CASE WHEN involvement_level = 'primary' THEN 2 ELSE 1 END AS weight
+  FROM exercise_muscles
+  WHERE exercise_id = sqlc.arg('exercise_id')
+),
+possible AS (
+  SELECT COALESCE(SUM(weight * 2), 0)::float8 AS score FROM source_muscles
+),
+overlap AS (
+  SELECT
+    em.exercise_id,
+    COUNT(*)::int AS shared_muscles,
+    SUM(sm.weight * CASE WHEN em.involvement_level = 'primary' THEN 2 ELSE 1 END)::float8 AS score
+  FROM exercise_muscles em
+  JOIN source_muscles sm ON em.muscle_id = sm.muscle_id
+  GROUP BY em.exercise_id
+),
+candidates AS (
+  SELECT
+    e.*,
+    COALESCE(o.shared_muscles, 0)::int AS shared_muscles,
+    COALESCE(o.score / NULLIF(p.score, 0), 0)::float8 AS muscle_overlap,
+    (e.movement_pattern IS NOT NULL AND e.movement_pattern = s.movement_pattern)::bool AS same_movement_pattern
+  FROM exercises e
+  CROSS JOIN source s
+  CROSS JOIN possible p
+  LEFT JOIN overlap o ON e.exercise_id = o.exercise_id
+  WHERE e.exercise_id <> s.exercise_id
+  AND (e.owner_user_id IS NULL OR e.owner_user_id = sqlc.arg('user_id'))
+  AND e.tracking_mode = s.tracking_mode
+  AND (cardinality(sqlc.arg('equipment')::text[]) = 0 OR e.equipment IS NULL OR e.equipment::text = ANY(sqlc.arg('equipment')::text[]))
+)
+SELECT
+  c.exercise_id, c.exercise_name, c.description, c.created_at, c.tracking_mode, c.owner_user_id, c.equipment, c.movement_pattern, c.laterality, c.parent_exercise_id,
+  c.shared_muscles,
+  c.muscle_overlap,
+  c.same_movement_pattern,
+  (c.muscle_overlap * 0.7 + CASE WHEN c.same_movement_pattern THEN 0.3 ELSE 0 END)::float8 AS score
+FROM candidates c
+WHERE c.shared_muscles > 0 OR c.same_movement_pattern
+ORDER BY score DESC, c.exercise_name
+LIMIT sqlc.arg('limit');
+
+-- Exact match on a name or alias, ignoring case, spaces & punctuation ("pull up" finds "Pull-up"). Names beat aliases &
+-- a user's custom exercise beats a global one
+-- name: MatchExerciseName :one
+SELECT e.*
+FROM exercises e
+LEFT JOIN exercise_aliases a ON e.exercise_id = a.exercise_id
+  AND regexp_replace(lower(a.alias), '[^a-z0-9]', '', 'g') = regexp_replace(lower(sqlc.arg('name')), '[^a-z0-9]', '', 'g')
+WHERE (e.owner_user_id IS NULL OR e.owner_user_id = sqlc.arg('user_id'))
+AND (
+  regexp_replace(lower(e.exercise_name), '[^a-z0-9]', '', 'g') = regexp_replace(lower(sqlc.arg('name')), '[^a-z0-9]', '', 'g')
+  OR a.alias IS NOT NULL
+)
+ORDER BY a.alias IS NOT NULL, e.owner_user_id NULLS LAST, e.exercise_id
+LIMIT 1;
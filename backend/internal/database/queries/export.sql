@@ -0,0 +1,54 @@
+-- One page of a user's training history for /me/export: a row per set, plus a row with NULL set columns for each workout
+-- without sets, oldest first. Pages are keyed on the last row's (workout_date, workout_id, overall_workout_set_number),
+-- with 0 standing in for the set number of set-less workouts; start from (from_date, 0, 0). Muscles are ';'-separated.
+-- name: GetExportPage :many
+SELECT
+  w.workout_id,
+  w.workout_date,
+  w.title AS workout_title,
+  w.started_at,
+  w.ended_at,
+  ws.overall_workout_set_number,
+  ws.set_number,
+  ws.exercise_id,
+  e.exercise_name,
+  COALESCE((
+    SELECT string_agg(m.muscle_name, ';' ORDER BY m.muscle_name)
+    FROM exercise_muscles em
+    JOIN muscles m ON em.muscle_id = m.muscle_id
+    WHERE em.exercise_id = ws.exercise_id
+    AND em.involvement_level = 'primary'
+  ), '')::text AS primary_muscles,
+  COALESCE((
+    SELECT string_agg(m.muscle_name, ';' ORDER BY m.muscle_name)
+    FROM exercise_muscles em
+    JOIN muscles m ON em.muscle_id = m.muscle_id
+    WHERE em.exercise_id = ws.exercise_id
+    AND em.involvement_level = 'secondary'
+  ), '')::text AS secondary_muscles,
+  ws.set_type,
+  ws.parent_set_number,
+  ws.group_id,
+  ws.reps,
+  ws.resistance_value,
+  ws.resistance_type,
+  ws.resistance_detail,
+  ws.rpe,
+  ws.percent_1rm,
+  ws.duration_seconds,
+  ws.distance_meters,
+  ws.distance_unit,
+  ws.calories,
+  ws.pace_seconds_per_km,
+  ws.notes,
+  ws.created_at,
+  ws.completed_at
+FROM workouts w
+LEFT JOIN workout_sets ws ON w.workout_id = ws.workout_id
+LEFT JOIN exercises e ON ws.exercise_id = e.exercise_id
+WHERE w.user_id = sqlc.arg('user_id')
+AND w.workout_date <= sqlc.arg('to_date')::date
+AND (w.workout_date, w.workout_id, COALESCE(ws.overall_workout_set_number, 0))
+  > (sqlc.arg('after_date')::date, sqlc.arg('after_workout_id')::int, sqlc.arg('after_set_number')::int)
+ORDER BY w.workout_date, w.workout_id, COALESCE(ws.overall_workout_set_number, 0)
+LIMIT sqlc.arg('page_size');
@@ -0,0 +1,81 @@
+-- name: CreateGoal :one
+INSERT INTO goals (user_id, goal_type, exercise_id, target_value, start_value, start_date, deadline)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+RETURNING *;
+
+-- Newest first; the status filter is skipped when NULL
+-- name: GetGoalsForUser :many
+SELECT
+  g.*,
+  e.exercise_name
+FROM goals g
+LEFT JOIN exercises e ON g.exercise_id = e.exercise_id
+WHERE g.user_id = sqlc.arg('user_id')
+AND (sqlc.narg('status')::goal_status_enum IS NULL OR g.status = sqlc.narg('status'))
+ORDER BY g.created_at DESC, g.goal_id DESC;
+
+-- name: GetGoalByIDForUser :one
+SELECT
+  g.*,
+  e.exercise_name
+FROM goals g
+LEFT JOIN exercises e ON g.exercise_id = e.exercise_id
+WHERE g.goal_id = $1
+AND g.user_id = $2;
+
+-- name: GetActiveGoalsForUser :many
+SELECT * FROM goals
+WHERE user_id = $1
+AND status = 'active'
+ORDER BY goal_id;
+
+-- Fields left NULL keep their current value; reopening a goal clears its completion time
+-- name: UpdateGoal :one
+UPDATE goals
+SET
+  target_value = COALESCE(sqlc.narg('target_value'), target_value),
+  deadline = COALESCE(sqlc.narg('deadline'), deadline),
+  status = COALESCE(sqlc.narg('status')::goal_status_enum, status),
+  completed_at = CASE WHEN sqlc.narg('status')::goal_status_enum = 'active' THEN NULL ELSE completed_at END,
+  updated_at = CURRENT_TIMESTAMP
+WHERE goal_id = sqlc.arg('goal_id')
+AND user_id = sqlc.arg('user_id')
+RETURNING *;
+
+-- Only moves goals that are still active, so a goal the user abandoned or reopened isn't overwritten by a stale check
+-- name: SetGoalStatus :exec
+UPDATE goals
+SET
+  status = sqlc.arg('status')::goal_status_enum,
+  completed_at = CASE WHEN sqlc.arg('status')::goal_status_enum = 'completed' THEN CURRENT_TIMESTAMP ELSE completed_at END,
+  updated_at = CURRENT_TIMESTAMP
+WHERE goal_id = sqlc.arg('goal_id')
+AND status = 'active';
+
+-- name: DeleteGoal :one
+DELETE FROM goals
+WHERE goal_id = $1
+AND user_id = $2
+RETURNING *;
+
+-- Heaviest weight & best estimated 1RM (Brzycki, sets of 10 reps or fewer) per day for one exercise, in kg, for trend lines
+-- name: GetDailyBestsForExercise :many
+SELECT
+  w.workout_date,
+  MAX(ws.resistance_value)::decimal AS max_weight_kg,
+  COALESCE(MAX(ws.resistance_value / (1.0278 - 0.0278 * ws.reps)) FILTER (WHERE ws.reps <= 10), 0)::decimal AS estimated_1rm_kg
+FROM workout_sets ws
+JOIN workouts w ON ws.workout_id = w.workout_id
+WHERE w.user_id = sqlc.arg('user_id')
+AND ws.exercise_id = sqlc.arg('exercise_id')
+AND w.workout_date >= sqlc.arg('from_date')::date
+AND ws.resistance_type = 'weight'
+AND ws.set_type <> 'warmup'
+AND ws.resistance_value IS NOT NULL
+AND ws.reps > 0
+GROUP BY w.workout_date
+ORDER BY w.workout_date;
+
+-- Bodyweight on a day per bodyweight_kg_on (nearest measurement, else the profile), in kg; 0 when there's none at all
+-- name: GetBodyweightOn :one
+SELECT COALESCE(bodyweight_kg_on(sqlc.arg('user_id'), sqlc.arg('on_date')::date), 0)::decimal AS bodyweight_kg;
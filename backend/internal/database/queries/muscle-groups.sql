@@ -0,0 +1,36 @@
+-- name: CreateMuscleGroup :one
+INSERT INTO muscle_groups (group_name, body_region, parent_group_id)
+VALUES ($1, $2, $3)
+RETURNING *;
+
+-- Top-level groups first so callers can build the tree in one pass
+-- name: GetAllMuscleGroups :many
+SELECT * FROM muscle_groups
+ORDER BY parent_group_id NULLS FIRST, group_name;
+
+-- name: GetMuscleGroupByID :one
+SELECT * FROM muscle_groups
+WHERE muscle_group_id = $1;
+
+-- Names are unique ignoring case, so this is how free text from clients gets matched to a group
+-- name: GetMuscleGroupByName :one
+SELECT * FROM muscle_groups
+WHERE lower(group_name) = lower(trim(sqlc.arg('group_name')::text));
+
+-- Fields left NULL keep their current value; clear_parent makes the group top-level
+-- name: UpdateMuscleGroup :one
+UPDATE muscle_groups
+SET
+  group_name = COALESCE(sqlc.narg('group_name'), group_name),
+  body_region = COALESCE(sqlc.narg('body_region'), body_region),
+  parent_group_id = CASE
+    WHEN sqlc.arg('clear_parent')::bool THEN NULL
+    ELSE COALESCE(sqlc.narg('parent_group_id'), parent_group_id)
+  END
+WHERE muscle_group_id = sqlc.arg('muscle_group_id')
+RETURNING *;
+
+-- name: DeleteMuscleGroup :one
+DELETE FROM muscle_groups
+WHERE muscle_group_id = $1
+RETURNING *;
@@ -1,17 +1,38 @@
 -- name: GetMuscle :one
-SELECT muscle_name, muscle_group
-FROM muscles
+SELECT * FROM muscle_details
 WHERE muscle_name = $1;
 
+-- name: GetMuscleByID :one
+SELECT * FROM muscle_details
+WHERE muscle_id = $1;
+
 -- name: CreateMuscle :one
-INSERT INTO muscles (muscle_name, muscle_group)
+INSERT INTO muscles (muscle_name, muscle_group_id)
 VALUES ($1, $2)
 RETURNING *;
 
+-- Fields left NULL keep their current value
+-- name: UpdateMuscle :one
+UPDATE muscles
+SET
+  muscle_name = COALESCE(sqlc.narg('muscle_name'), muscle_name),
+  muscle_group_id = COALESCE(sqlc.narg('muscle_group_id'), muscle_group_id)
+WHERE muscle_id = sqlc.arg('muscle_id')
+RETURNING *;
+
 -- name: DeleteMuscle :one
 DELETE FROM muscles
 WHERE muscle_name = $1
 RETURNING *;
 
+-- name: DeleteMuscleByID :one
+DELETE FROM muscles
+WHERE muscle_id = $1
+RETURNING *;
+
 -- name: DeleteAllMuscles :exec
-DELETE FROM muscles;
\ No newline at end of file
+DELETE FROM muscles;
+
+-- name: GetAllMuscles :many
+SELECT * FROM muscle_details
+ORDER BY muscle_group, muscle_name;
@@ -0,0 +1,112 @@
+-- name: GetCurrentPersonalRecords :many
+SELECT * FROM personal_records
+WHERE user_id = $1
+AND exercise_id = $2
+AND superseded_at IS NULL;
+
+-- name: CreatePersonalRecord :one
+INSERT INTO personal_records (
+    user_id,
+    exercise_id,
+    record_type,
+    value,
+    weight_kg,
+    workout_id,
+    overall_workout_set_number
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7
+) RETURNING *;
+
+-- Clears the per-set records held by specific sets, e.g. ones that were just moved to another exercise
+-- name: DeletePersonalRecordsForWorkoutSets :exec
+DELETE FROM personal_records
+WHERE workout_id = sqlc.arg('workout_id')
+AND overall_workout_set_number = ANY(sqlc.arg('overall_workout_set_numbers')::int[]);
+
+-- name: SupersedePersonalRecord :exec
+UPDATE personal_records
+SET superseded_at = CURRENT_TIMESTAMP
+WHERE record_id = $1;
+
+-- A workout's session volume record grows in place as more sets are logged in that same workout
+-- name: UpdatePersonalRecordValue :exec
+UPDATE personal_records
+SET value = $2
+WHERE record_id = $1;
+
+-- All records (current & superseded) for a user, newest first within each exercise & type
+-- name: GetPersonalRecordsForUser :many
+SELECT
+  pr.*,
+  e.exercise_name,
+  w.workout_date
+FROM personal_records pr
+JOIN exercises e ON pr.exercise_id = e.exercise_id
+JOIN workouts w ON pr.workout_id = w.workout_id
+WHERE pr.user_id = sqlc.arg('user_id')
+AND (pr.superseded_at IS NULL OR NOT sqlc.arg('current_only')::boolean)
+ORDER BY e.exercise_name, pr.record_type, pr.weight_kg NULLS FIRST, pr.achieved_at DESC;
+
+-- Total weight x reps for one exercise in one workout, skipping warm-ups
+-- name: GetSessionVolumeForExercise :one
+SELECT COALESCE(SUM(resistance_value * reps), 0)::decimal AS volume_kg
+FROM workout_sets
+WHERE workout_id = $1
+AND exercise_id = $2
+AND resistance_type = 'weight'
+AND set_type <> 'warmup'
+AND resistance_value IS NOT NULL
+AND reps IS NOT NULL;
+
+-- Drops the session volume records a workout holds for an exercise, e.g. after one of its sets was edited or deleted
+-- name: DeleteSessionVolumeRecordsForWorkout :exec
+DELETE FROM personal_records
+WHERE workout_id = $1
+AND exercise_id = $2
+AND record_type = 'session_volume';
+
+-- Current & superseded records for one exercise, for rebuilding which ones are current
+-- name: GetPersonalRecordsForExercise :many
+SELECT * FROM personal_records
+WHERE user_id = $1
+AND exercise_id = $2;
+
+-- Every set that can hold a record (see recordEligibleWeightKg), oldest first so ties go to whoever got there first
+-- name: GetRecordEligibleSetsForExercise :many
+SELECT
+  ws.workout_id,
+  ws.overall_workout_set_number,
+  ws.resistance_value,
+  ws.reps
+FROM workout_sets ws
+JOIN workouts w ON ws.workout_id = w.workout_id
+WHERE w.user_id = sqlc.arg('user_id')
+AND ws.exercise_id = sqlc.arg('exercise_id')
+AND ws.resistance_type = 'weight'
+AND ws.set_type <> 'warmup'
+AND ws.resistance_value > 0
+AND ws.reps > 0
+ORDER BY w.workout_date, ws.workout_id, ws.overall_workout_set_number;
+
+-- GetSessionVolumeForExercise for every workout with the exercise, oldest first
+-- name: GetSessionVolumesForExercise :many
+SELECT
+  ws.workout_id,
+  SUM(ws.resistance_value * ws.reps)::decimal AS volume_kg
+FROM workout_sets ws
+JOIN workouts w ON ws.workout_id = w.workout_id
+WHERE w.user_id = sqlc.arg('user_id')
+AND ws.exercise_id = sqlc.arg('exercise_id')
+AND ws.resistance_type = 'weight'
+AND ws.set_type <> 'warmup'
+AND ws.resistance_value IS NOT NULL
+AND ws.reps IS NOT NULL
+GROUP BY ws.workout_id, w.workout_date
+HAVING SUM(ws.resistance_value * ws.reps) > 0
+ORDER BY w.workout_date, ws.workout_id;
+
+-- Makes a superseded record current again once whatever beat it is gone
+-- name: RestorePersonalRecord :exec
+UPDATE personal_records
+SET superseded_at = NULL
+WHERE record_id = $1;
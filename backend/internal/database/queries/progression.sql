@@ -0,0 +1,60 @@
+-- name: GetProgressionSettings :one
+SELECT * FROM exercise_progression_settings
+WHERE user_id = $1
+AND exercise_id = $2;
+
+-- name: UpsertProgressionSettings :one
+INSERT INTO exercise_progression_settings (
+    user_id,
+    exercise_id,
+    strategy,
+    min_reps,
+    max_reps,
+    load_increment_kg,
+    target_rpe,
+    deload_after_misses,
+    deload_percent
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9
+)
+ON CONFLICT (user_id, exercise_id) DO UPDATE SET
+    strategy = EXCLUDED.strategy,
+    min_reps = EXCLUDED.min_reps,
+    max_reps = EXCLUDED.max_reps,
+    load_increment_kg = EXCLUDED.load_increment_kg,
+    target_rpe = EXCLUDED.target_rpe,
+    deload_after_misses = EXCLUDED.deload_after_misses,
+    deload_percent = EXCLUDED.deload_percent,
+    updated_at = CURRENT_TIMESTAMP
+RETURNING *;
+
+-- Working sets (no warm-ups or drop sets) from the user's last N sessions of an exercise, newest session first
+-- name: GetRecentWorkingSetsForExercise :many
+SELECT
+  ws.workout_id,
+  w.workout_date,
+  ws.overall_workout_set_number,
+  ws.reps,
+  ws.resistance_value,
+  ws.rpe
+FROM workout_sets ws
+JOIN workouts w ON ws.workout_id = w.workout_id
+WHERE w.user_id = sqlc.arg('user_id')
+AND ws.exercise_id = sqlc.arg('exercise_id')
+AND ws.set_type NOT IN ('warmup', 'drop')
+AND ws.reps IS NOT NULL
+AND ws.workout_id IN (
+  SELECT rw.workout_id
+  FROM workouts rw
+  WHERE rw.user_id = sqlc.arg('user_id')
+  AND EXISTS (
+    SELECT 1 FROM workout_sets rws
+    WHERE rws.workout_id = rw.workout_id
+    AND rws.exercise_id = sqlc.arg('exercise_id')
+    AND rws.set_type NOT IN ('warmup', 'drop')
+    AND rws.reps IS NOT NULL
+  )
+  ORDER BY rw.workout_date DESC, rw.workout_id DESC
+  LIMIT sqlc.arg('sessions')
+)
+ORDER BY w.workout_date DESC, ws.workout_id DESC, ws.overall_workout_set_number;
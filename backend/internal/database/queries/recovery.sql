@@ -0,0 +1,27 @@
+-- Every (set, muscle) pair worked between two points in time, skipping warm-ups. In a live session (one still running, or
+-- with sets ticked off) only completed sets count, at the time they were completed; planned sets not done yet & sets
+-- skipped in a finished session put no fatigue on anything. Sets logged after the fact (or imported) count at the end or
+-- start of their workout, else midday (UTC) on the workout date. The upper bound keeps future sets from counting.
+-- name: GetRecentMuscleWorkForUser :many
+WITH set_times AS (
+  SELECT
+    em.muscle_id,
+    em.involvement_level,
+    CASE
+      WHEN w.started_at IS NOT NULL AND (w.ended_at IS NULL OR EXISTS (
+        SELECT 1 FROM workout_sets done WHERE done.workout_id = w.workout_id AND done.completed_at IS NOT NULL
+      )) THEN ws.completed_at
+      ELSE COALESCE(ws.completed_at, w.ended_at, w.started_at, (w.workout_date + TIME '12:00') AT TIME ZONE 'UTC')
+    END::timestamptz AS performed_at,
+    ws.reps,
+    ws.rpe
+  FROM workout_sets ws
+  JOIN workouts w ON ws.workout_id = w.workout_id
+  JOIN exercise_muscles em ON ws.exercise_id = em.exercise_id
+  WHERE w.user_id = sqlc.arg('user_id')
+  AND ws.set_type <> 'warmup'
+)
+SELECT muscle_id, involvement_level, performed_at, reps, rpe
+FROM set_times
+WHERE performed_at BETWEEN sqlc.arg('since')::timestamptz AND sqlc.arg('until')::timestamptz
+ORDER BY performed_at;
@@ -0,0 +1,57 @@
+-- Hard (non warm-up) sets, reps & tonnage (weighted & bodyweight sets) per muscle per period, split by involvement so the caller can weight
+-- secondary muscles. Sets of exercises with several muscles count once for each muscle.
+-- name: GetMuscleVolumeForUser :many
+SELECT
+  date_trunc(sqlc.arg('granularity')::text, w.workout_date)::date AS period_start,
+  m.muscle_id,
+  m.muscle_name,
+  m.muscle_group,
+  em.involvement_level,
+  COUNT(*) AS hard_sets,
+  COALESCE(SUM(ws.reps), 0)::bigint AS reps,
+  COALESCE(SUM(effective_load_kg(ws.resistance_type, ws.resistance_value, w.user_id, w.workout_date) * ws.reps), 0)::decimal AS tonnage_kg
+FROM workout_sets ws
+JOIN workouts w ON ws.workout_id = w.workout_id
+JOIN exercise_muscles em ON ws.exercise_id = em.exercise_id
+JOIN muscle_details m ON em.muscle_id = m.muscle_id
+WHERE w.user_id = sqlc.arg('user_id')
+AND w.workout_date BETWEEN sqlc.arg('from_date')::date AND sqlc.arg('to_date')::date
+AND ws.set_type <> 'warmup'
+GROUP BY period_start, m.muscle_id, m.muscle_name, m.muscle_group, em.involvement_level
+ORDER BY period_start, m.muscle_group, m.muscle_name;
+
+-- The same totals per muscle group, with each set counted once per group at its highest involvement among the group's
+-- muscles; summing the per-muscle rows would count a squat twice towards legs (quads & hamstrings).
+-- name: GetMuscleGroupVolumeForUser :many
+WITH set_groups AS (
+  SELECT
+    date_trunc(sqlc.arg('granularity')::text, w.workout_date)::date AS period_start,
+    m.muscle_group,
+    BOOL_OR(em.involvement_level = 'primary') AS is_primary,
+    ws.reps,
+    effective_load_kg(ws.resistance_type, ws.resistance_value, w.user_id, w.workout_date) * ws.reps AS tonnage_kg
+  FROM workout_sets ws
+  JOIN workouts w ON ws.workout_id = w.workout_id
+  JOIN exercise_muscles em ON ws.exercise_id = em.exercise_id
+  JOIN muscle_details m ON em.muscle_id = m.muscle_id
+  WHERE w.user_id = sqlc.arg('user_id')
+  AND w.workout_date BETWEEN sqlc.arg('from_date')::date AND sqlc.arg('to_date')::date
+  AND ws.set_type <> 'warmup'
+  GROUP BY w.workout_id, ws.workout_id, ws.overall_workout_set_number, m.muscle_group
+)
+SELECT
+  period_start,
+  muscle_group,
+  (CASE WHEN is_primary THEN 'primary' ELSE 'secondary' END)::involvement_level_enum AS involvement_level,
+  COUNT(*) AS hard_sets,
+  COALESCE(SUM(reps), 0)::bigint AS reps,
+  COALESCE(SUM(tonnage_kg), 0)::decimal AS tonnage_kg
+FROM set_groups
+GROUP BY period_start, muscle_group, is_primary
+ORDER BY period_start, muscle_group;
+
+-- name: GetDailyTrainingLoads :many
+SELECT * FROM daily_training_loads
+WHERE user_id = sqlc.arg('user_id')
+AND load_date BETWEEN sqlc.arg('from_date')::date AND sqlc.arg('to_date')::date
+ORDER BY load_date;
@@ -16,11 +16,14 @@ SELECT
   ups.last_name,
   ups.date_of_birth,
   ups.gender,
-  ups.height_inches,
-  ups.weight_pounds,
   ups.profile_picture_url,
   ups.created_at,
   ups.updated_at,
+  ups.unit_preference,
+  ups.height_cm,
+  ups.weight_kg,
+  ups.weekly_session_target,
+  ups.timezone,
   u.active
 FROM user_profiles ups
 JOIN users u ON ups.user_id = u.user_id
@@ -35,32 +38,43 @@ SELECT
   ups.last_name,
   ups.date_of_birth,
   ups.gender,
-  ups.height_inches,
-  ups.weight_pounds,
   ups.profile_picture_url,
   ups.created_at,
   ups.updated_at,
+  ups.unit_preference,
+  ups.height_cm,
+  ups.weight_kg,
+  ups.weekly_session_target,
+  ups.timezone,
   u.active
 FROM user_profiles ups
 JOIN users u ON ups.user_id = u.user_id
 WHERE u.active = false;
 
+-- Height & weight are always cm & kg - handlers convert from the user's preferred units first
 -- name: CreateUserProfile :one
-INSERT INTO user_profiles (user_id, first_name, last_name, date_of_birth, gender, height_inches, weight_pounds)
-VALUES ($1, $2, $3, $4, $5, $6, $7)
+INSERT INTO user_profiles (user_id, first_name, last_name, date_of_birth, gender, height_cm, weight_kg, unit_preference, weekly_session_target, timezone)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
 RETURNING *;
 
+-- weekly_session_target is left alone when NULL & cleared by 0; timezone is left alone when NULL
 -- name: UpdateUserProfile :one
 UPDATE user_profiles
 SET 
-  first_name = $2, 
-  last_name = $3, 
-  date_of_birth = $4,
-  gender = $5,
-  height_inches = $6,
-  weight_pounds = $7,
+  first_name = sqlc.narg('first_name'), 
+  last_name = sqlc.narg('last_name'), 
+  date_of_birth = sqlc.narg('date_of_birth'),
+  gender = sqlc.narg('gender'),
+  height_cm = sqlc.narg('height_cm'),
+  weight_kg = sqlc.narg('weight_kg'),
+  unit_preference = COALESCE(sqlc.narg('unit_preference')::unit_system_enum, unit_preference),
+  weekly_session_target = CASE
+    WHEN sqlc.narg('weekly_session_target')::int = 0 THEN NULL
+    ELSE COALESCE(sqlc.narg('weekly_session_target'), weekly_session_target)
+  END,
+  timezone = COALESCE(sqlc.narg('timezone'), timezone),
   updated_at = CURRENT_TIMESTAMP
-WHERE user_id = $1
+WHERE user_id = sqlc.narg('user_id')
 RETURNING *;
 
 -- name: DeleteUserProfile :one
@@ -14,6 +14,10 @@ SELECT * FROM users
 WHERE email = $1 AND active = true
 LIMIT 1;
 
+-- name: GetUserIsAdmin :one
+SELECT is_admin FROM users
+WHERE user_id = $1;
+
 -- name: GetAllUsers :many
 SELECT *
 FROM users;
@@ -0,0 +1,21 @@
+-- name: CreateWorkoutSetGroup :one
+INSERT INTO workout_set_groups (
+  workout_id,
+  group_type,
+  rounds,
+  rest_between_exercises_seconds,
+  rest_between_rounds_seconds,
+  interval_seconds,
+  notes
+) VALUES (
+  $1, $2, $3, $4, $5, $6, $7
+) RETURNING *;
+
+-- name: GetWorkoutSetGroups :many
+SELECT * FROM workout_set_groups
+WHERE workout_id = $1
+ORDER BY group_id;
+
+-- name: DeleteAllWorkoutSetGroups :exec
+DELETE FROM workout_set_groups
+WHERE workout_id = $1;
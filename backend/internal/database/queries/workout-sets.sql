@@ -1,5 +1,7 @@
 -- RE: the "NULLIF" lines:
 -- they accept text arrays, convert empty strings to NULL, then cast non-NULL values to decimal
+-- set_type falls back to 'working' and a parent_set_number of 0 means "no parent"
+-- a duration or calorie count of 0 means "not logged"
 -- name: CreateWorkoutSets :many
 WITH input_rows AS (
   SELECT 
@@ -11,13 +13,56 @@ WITH input_rows AS (
     NULLIF(unnest($6::text[]), '')::resistance_type_enum resistance_type,
     unnest($7::text[]) resistance_detail,
     NULLIF(unnest($8::text[]), '')::decimal rpe,
-    unnest($9::text[]) notes
+    unnest($9::text[]) notes,
+    COALESCE(NULLIF(unnest($10::text[]), ''), 'working')::set_type_enum set_type,
+    NULLIF(unnest($11::int[]), 0) parent_set_number,
+    NULLIF(unnest($12::int[]), 0) duration_seconds,
+    NULLIF(unnest($13::text[]), '')::decimal distance_meters,
+    NULLIF(unnest($14::text[]), '')::distance_unit_enum distance_unit,
+    NULLIF(unnest($15::int[]), 0) calories
 )
 INSERT INTO workout_sets 
-(workout_id, exercise_id, set_number, reps, resistance_value, resistance_type, resistance_detail, rpe, notes)
+(workout_id, exercise_id, set_number, reps, resistance_value, resistance_type, resistance_detail, rpe, notes, set_type, parent_set_number, duration_seconds, distance_meters, distance_unit, calories)
 SELECT * FROM input_rows
 RETURNING *;
 
+-- Same as CreateWorkoutSets, but every row carries its own exercise_id so the rounds of a superset/circuit can interleave (A1, B1, A2, B2, ...)
+-- set_type falls back to 'working'; grouped sets can't be drop sets since there's no parent set to point at
+-- set_number is the round; it carries on from the exercise's sets already in the workout rather than restarting at 1
+-- name: CreateGroupedWorkoutSets :many
+WITH input_rows AS (
+  SELECT 
+    $1::int as workout_id,
+    $2::int as group_id,
+    unnest($3::int[]) exercise_id,
+    unnest($4::int[]) set_number,
+    unnest($5::int[]) reps,
+    NULLIF(unnest($6::text[]), '')::decimal resistance_value,
+    NULLIF(unnest($7::text[]), '')::resistance_type_enum resistance_type,
+    unnest($8::text[]) resistance_detail,
+    NULLIF(unnest($9::text[]), '')::decimal rpe,
+    unnest($10::text[]) notes,
+    NULLIF(unnest($11::int[]), 0) duration_seconds,
+    NULLIF(unnest($12::text[]), '')::decimal distance_meters,
+    NULLIF(unnest($13::text[]), '')::distance_unit_enum distance_unit,
+    NULLIF(unnest($14::int[]), 0) calories,
+    COALESCE(NULLIF(unnest($15::text[]), ''), 'working')::set_type_enum set_type
+)
+INSERT INTO workout_sets 
+(workout_id, group_id, exercise_id, set_number, reps, resistance_value, resistance_type, resistance_detail, rpe, notes, duration_seconds, distance_meters, distance_unit, calories, set_type)
+SELECT
+  i.workout_id,
+  i.group_id,
+  i.exercise_id,
+  i.set_number + COALESCE((
+    SELECT MAX(ws.set_number) FROM workout_sets ws
+    WHERE ws.workout_id = i.workout_id AND ws.exercise_id = i.exercise_id
+  ), 0),
+  i.reps, i.resistance_value, i.resistance_type, i.resistance_detail, i.rpe, i.notes,
+  i.duration_seconds, i.distance_meters, i.distance_unit, i.calories, i.set_type
+FROM input_rows i
+RETURNING *;
+
 -- name: GetAllWorkoutSets :many
 SELECT 
   ws.*,
@@ -28,19 +73,82 @@ WHERE ws.workout_id = $1
 ORDER BY ws.overall_workout_set_number;
 
 -- Make batch version of this later
+-- set_type & parent_set_number are only touched when a new set_type is sent, so partial updates keep drop set links intact
 -- name: UpdateWorkoutSetByID :one
 UPDATE workout_sets 
 SET 
-  reps = $2,
-  resistance_value = $3,
-  resistance_type = $4,
-  resistance_detail = $5,
-  rpe = $6,
-  notes = $7
-WHERE workout_id = $8 
-AND overall_workout_set_number = $1
+  reps = sqlc.narg('reps'),
+  resistance_value = sqlc.narg('resistance_value'),
+  resistance_type = sqlc.narg('resistance_type'),
+  resistance_detail = sqlc.narg('resistance_detail'),
+  rpe = sqlc.narg('rpe'),
+  notes = sqlc.narg('notes'),
+  duration_seconds = sqlc.narg('duration_seconds'),
+  distance_meters = sqlc.narg('distance_meters'),
+  distance_unit = sqlc.narg('distance_unit'),
+  calories = sqlc.narg('calories'),
+  set_type = COALESCE(sqlc.narg('set_type')::set_type_enum, set_type),
+  parent_set_number = CASE
+    WHEN sqlc.narg('set_type')::set_type_enum IS NULL THEN parent_set_number
+    ELSE sqlc.narg('parent_set_number')::int
+  END
+WHERE workout_id = sqlc.arg('workout_id')
+AND overall_workout_set_number = sqlc.arg('overall_workout_set_number')
 RETURNING *;
 
+-- Weighted sets for 1RM estimation; the rep cap drops high-rep sets where the formulas stop being reliable
+-- name: GetOneRepMaxSetsForExercise :many
+SELECT
+  w.workout_id,
+  w.workout_date,
+  ws.overall_workout_set_number,
+  ws.resistance_value,
+  ws.reps,
+  ws.rpe
+FROM workout_sets ws
+JOIN workouts w ON ws.workout_id = w.workout_id
+WHERE w.user_id = sqlc.arg('user_id')
+AND ws.exercise_id = sqlc.arg('exercise_id')
+AND ws.resistance_type = 'weight'
+AND ws.resistance_value > 0
+AND ws.reps > 0
+AND ws.reps <= sqlc.arg('max_reps')::int
+AND (ws.set_type <> 'warmup' OR sqlc.arg('include_warmups')::boolean)
+ORDER BY w.workout_date, ws.workout_id, ws.overall_workout_set_number;
+
+-- Used to validate a set update against its exercise's tracking mode
+-- name: GetExerciseTrackingModeForSet :one
+SELECT e.tracking_mode
+FROM workout_sets ws
+JOIN exercises e ON ws.exercise_id = e.exercise_id
+WHERE ws.workout_id = $1
+AND ws.overall_workout_set_number = $2;
+
+-- Live session mode; re-completing a set keeps its original timestamp so rest times don't shift
+-- name: CompleteWorkoutSet :one
+UPDATE workout_sets
+SET completed_at = COALESCE(completed_at, CURRENT_TIMESTAMP)
+WHERE workout_id = $1
+AND overall_workout_set_number = $2
+RETURNING *;
+
+-- Moves a workout's not-yet-completed sets for one exercise onto another in place, so set numbers, order, groups
+-- & targets all carry over
+-- name: SwapWorkoutSetsExercise :many
+UPDATE workout_sets
+SET exercise_id = sqlc.arg('to_exercise_id')
+WHERE workout_id = sqlc.arg('workout_id')
+AND exercise_id = sqlc.arg('from_exercise_id')
+AND completed_at IS NULL
+RETURNING *;
+
+-- name: WorkoutHasExercise :one
+SELECT EXISTS(
+  SELECT 1 FROM workout_sets
+  WHERE workout_id = $1
+  AND exercise_id = $2
+);
+
 -- name: DeleteWorkoutSetByID :one
 DELETE FROM workout_sets 
 WHERE workout_id = $1 
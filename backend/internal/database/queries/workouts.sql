@@ -1,36 +1,73 @@
 -- CREATE: Insert a new workout
 -- name: CreateWorkout :one
-INSERT INTO workouts (user_id, workout_date, title)
-VALUES ($1, $2, $3)
-RETURNING workout_id, user_id, workout_date, title, created_at;
+INSERT INTO workouts (user_id, workout_date, title, started_at, ended_at)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING workout_id, user_id, workout_date, title, started_at, ended_at, created_at;
 
 -- READ: Get all workouts for a specific user
 -- name: GetAllWorkoutsForUser :many
-SELECT workout_id, workout_date, title, created_at
+SELECT workout_id, workout_date, title, started_at, ended_at, created_at
 FROM workouts
 WHERE user_id = $1
-ORDER BY workout_date DESC;
+ORDER BY workout_date DESC, started_at DESC NULLS LAST, workout_id DESC;
 
 -- READ: Get a specific workout by ID
 -- name: GetWorkoutByIDForUser :one
-SELECT workout_id, user_id, workout_date, title, created_at
+SELECT workout_id, user_id, workout_date, title, started_at, ended_at, created_at
 FROM workouts
 WHERE workout_id = $1 AND user_id = $2;
 
--- READ: Get workout from a date (client-side) & userID (from context)
--- name: GetWorkoutByUserIDAndDate :one
-SELECT workout_id, workout_date, title, created_at
+-- READ: Get every workout (session) from a date (client-side) & userID (from context), in the order they happened
+-- name: GetWorkoutsByUserIDAndDate :many
+SELECT workout_id, workout_date, title, started_at, ended_at, created_at
 FROM workouts
-WHERE user_id = $1 AND workout_date = $2;
+WHERE user_id = $1 AND workout_date = $2
+ORDER BY started_at NULLS LAST, workout_id;
 
--- UPDATE: Modify an existing workout
+-- READ: Dates in a range the user already has at least one workout on (used by imports to flag clashes)
+-- name: GetWorkoutDatesForUser :many
+SELECT DISTINCT workout_date
+FROM workouts
+WHERE user_id = sqlc.arg('user_id')
+AND workout_date BETWEEN sqlc.arg('from_date')::date AND sqlc.arg('to_date')::date
+ORDER BY workout_date;
+
+-- UPDATE: Modify an existing workout. Session timestamps are only overwritten when provided.
 -- name: UpdateWorkout :one
 UPDATE workouts
 SET 
-  title = $1,
+  title = sqlc.arg('title'),
+  started_at = COALESCE(sqlc.narg('started_at')::timestamptz, started_at),
+  ended_at = COALESCE(sqlc.narg('ended_at')::timestamptz, ended_at),
+  updated_at = CURRENT_TIMESTAMP
+WHERE workout_id = sqlc.arg('workout_id') AND user_id = sqlc.arg('user_id')
+RETURNING workout_id, workout_date, title, started_at, ended_at, updated_at;
+
+-- UPDATE: Stamp the start of a live session. Workouts that were already started are left alone.
+-- name: StartWorkout :one
+UPDATE workouts
+SET
+  started_at = CURRENT_TIMESTAMP,
   updated_at = CURRENT_TIMESTAMP
-WHERE workout_id = $2 AND user_id = $3
-RETURNING workout_id, workout_date, title, updated_at;
+WHERE workout_id = $1 AND user_id = $2 AND started_at IS NULL
+RETURNING *;
+
+-- UPDATE: Stamp the end of a live session. Only in-progress workouts can be finished.
+-- name: FinishWorkout :one
+UPDATE workouts
+SET
+  ended_at = CURRENT_TIMESTAMP,
+  updated_at = CURRENT_TIMESTAMP
+WHERE workout_id = $1 AND user_id = $2 AND started_at IS NOT NULL AND ended_at IS NULL
+RETURNING *;
+
+-- READ: The user's in-progress (started, not yet finished) workout, if any
+-- name: GetActiveWorkoutForUser :one
+SELECT *
+FROM workouts
+WHERE user_id = $1 AND started_at IS NOT NULL AND ended_at IS NULL
+ORDER BY started_at DESC
+LIMIT 1;
 
 -- DELETE: Remove a workout
 -- name: DeleteWorkout :one
@@ -40,4 +77,5 @@ RETURNING workout_id;
 
 -- DELETE: Used exclusively in seeder.
 -- name: DeleteAllWorkouts :exec
-DELETE FROM workouts;
\ No newline at end of file
+DELETE FROM workouts;
+
@@ -92,14 +92,10 @@ func SeedUsers(queries *sqlc.Queries) error {
 					String: user.Gender,
 					Valid:  user.Gender != "",
 				},
-				HeightInches: sql.NullInt32{
-					Int32: user.Height,
-					Valid: user.Height != 0,
-				},
-				WeightPounds: sql.NullInt32{
-					Int32: user.Weight,
-					Valid: user.Weight != 0,
-				},
+				// seed heights & weights are in inches & lbs
+				HeightCm:       utils.ToCmFromFloatPtr(nonZeroFloat64Ptr(user.Height), sqlc.UnitSystemEnumImperial),
+				WeightKg:       utils.ToKgFromFloatPtr(nonZeroFloat64Ptr(user.Weight), sqlc.UnitSystemEnumImperial),
+				UnitPreference: sqlc.UnitSystemEnumImperial,
 			})
 			if err != nil {
 				return fmt.Errorf("failed to create profile for user %s: %v", user.Email, err)
@@ -109,3 +105,11 @@ func SeedUsers(queries *sqlc.Queries) error {
 	fmt.Println("Successfully seeded USERS & USER_PROFILES table")
 	return nil
 }
+
+func nonZeroFloat64Ptr(i int32) *float64 {
+	if i == 0 {
+		return nil
+	}
+	f := float64(i)
+	return &f
+}
@@ -10,7 +10,7 @@ import (
 
 type TestMuscles struct {
 	MuscleName  string
-	MuscleGroup string
+	MuscleGroup string // must name one of the groups the muscle_groups migration creates
 }
 
 func GetTestMuscles() []TestMuscles {
@@ -62,9 +62,14 @@ func GetTestMuscles() []TestMuscles {
 
 func SeedMuscles(queries *sqlc.Queries) error {
 	for _, muscle := range GetTestMuscles() {
-		_, err := queries.CreateMuscle(context.Background(), sqlc.CreateMuscleParams{
-			MuscleName:  muscle.MuscleName,
-			MuscleGroup: muscle.MuscleGroup,
+		group, err := queries.GetMuscleGroupByName(context.Background(), muscle.MuscleGroup)
+		if err != nil {
+			return fmt.Errorf("failed to find muscle group %s: %v", muscle.MuscleGroup, err)
+		}
+
+		_, err = queries.CreateMuscle(context.Background(), sqlc.CreateMuscleParams{
+			MuscleName:    muscle.MuscleName,
+			MuscleGroupID: group.MuscleGroupID,
 		})
 		if err != nil {
 			return fmt.Errorf("failed to create muscle %s: %v", muscle.MuscleName, err)
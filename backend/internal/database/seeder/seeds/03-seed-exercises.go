@@ -2,117 +2,272 @@ package seeds
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
 	"go-reppy/backend/internal/api/utils"
 	"go-reppy/backend/internal/database/sqlc"
 )
 
 type TestExercises struct {
-	ExerciseName string
-	Description  string
+	ExerciseName    string
+	Description     string
+	TrackingMode    string // defaults to 'reps_load' if empty
+	Equipment       string // taxonomy fields are left NULL if empty
+	MovementPattern string
+	Laterality      string
+	ParentName      string // the exercise this is a variation of; it has to come earlier in the list
+	Aliases         []string
 }
 
 func GetTestExercises() []TestExercises {
 	return []TestExercises{
 		// Compound Exercises - Upper Body Push
 		{
-			ExerciseName: "Bench Press",
-			Description:  "A compound exercise performed lying on a bench, pushing a barbell up from the chest to full arm extension.",
+			ExerciseName:    "Bench Press",
+			Aliases:         []string{"bench", "BP", "flat bench"},
+			Description:     "A compound exercise performed lying on a bench, pushing a barbell up from the chest to full arm extension.",
+			Equipment:       "barbell",
+			MovementPattern: "push",
+			Laterality:      "bilateral",
 		},
 		{
-			ExerciseName: "Overhead Press",
-			Description:  "A standing compound exercise pressing a barbell or dumbbells from shoulder level to overhead.",
+			ExerciseName:    "Overhead Press",
+			Aliases:         []string{"OHP", "military press", "shoulder press"},
+			Description:     "A standing compound exercise pressing a barbell or dumbbells from shoulder level to overhead.",
+			Equipment:       "barbell",
+			MovementPattern: "push",
+			Laterality:      "bilateral",
 		},
 		{
-			ExerciseName: "Push-up",
-			Description:  "A bodyweight exercise performed face-down, pushing the body up from the ground with arms.",
+			ExerciseName:    "Push-up",
+			Aliases:         []string{"pushup", "press-up"},
+			Description:     "A bodyweight exercise performed face-down, pushing the body up from the ground with arms.",
+			Equipment:       "bodyweight",
+			MovementPattern: "push",
+			Laterality:      "bilateral",
 		},
 
 		// Compound Exercises - Upper Body Pull
 		{
-			ExerciseName: "Pull-up",
-			Description:  "A bodyweight exercise pulling oneself up to a bar from a hanging position.",
+			ExerciseName:    "Pull-up",
+			Aliases:         []string{"pullup"},
+			Description:     "A bodyweight exercise pulling oneself up to a bar from a hanging position.",
+			Equipment:       "bodyweight",
+			MovementPattern: "pull",
+			Laterality:      "bilateral",
 		},
 		{
-			ExerciseName: "Barbell Row",
-			Description:  "A bent-over pulling movement targeting the back muscles using a barbell.",
+			ExerciseName:    "Barbell Row",
+			Aliases:         []string{"bent-over row", "BB row"},
+			Description:     "A bent-over pulling movement targeting the back muscles using a barbell.",
+			Equipment:       "barbell",
+			MovementPattern: "pull",
+			Laterality:      "bilateral",
 		},
 		{
-			ExerciseName: "Lat Pulldown",
-			Description:  "A cable exercise pulling a bar down to the upper chest, targeting the latissimus dorsi.",
+			ExerciseName:    "Lat Pulldown",
+			Aliases:         []string{"pulldown"},
+			Description:     "A cable exercise pulling a bar down to the upper chest, targeting the latissimus dorsi.",
+			Equipment:       "cable",
+			MovementPattern: "pull",
+			Laterality:      "bilateral",
 		},
 
 		// Compound Exercises - Lower Body
 		{
-			ExerciseName: "Squat",
-			Description:  "A fundamental lower body exercise performing a deep knee bend while keeping the torso upright.",
+			ExerciseName:    "Squat",
+			Aliases:         []string{"back squat"},
+			Description:     "A fundamental lower body exercise performing a deep knee bend while keeping the torso upright.",
+			Equipment:       "barbell",
+			MovementPattern: "squat",
+			Laterality:      "bilateral",
 		},
 		{
-			ExerciseName: "Deadlift",
-			Description:  "A compound exercise lifting a barbell from the ground while maintaining a neutral spine.",
+			ExerciseName:    "Deadlift",
+			Aliases:         []string{"DL", "conventional deadlift"},
+			Description:     "A compound exercise lifting a barbell from the ground while maintaining a neutral spine.",
+			Equipment:       "barbell",
+			MovementPattern: "hinge",
+			Laterality:      "bilateral",
 		},
 		{
-			ExerciseName: "Romanian Deadlift",
-			Description:  "A hip-hinge movement performed with straight legs, targeting the posterior chain.",
+			ExerciseName:    "Romanian Deadlift",
+			Aliases:         []string{"RDL", "stiff-leg deadlift"},
+			Description:     "A hip-hinge movement performed with straight legs, targeting the posterior chain.",
+			Equipment:       "barbell",
+			MovementPattern: "hinge",
+			Laterality:      "bilateral",
 		},
 		{
-			ExerciseName: "Lunge",
-			Description:  "A unilateral leg exercise stepping forward into a split stance position.",
+			ExerciseName:    "Lunge",
+			Description:     "A unilateral leg exercise stepping forward into a split stance position.",
+			Equipment:       "dumbbell",
+			MovementPattern: "squat",
+			Laterality:      "unilateral",
 		},
 
 		// Isolation Exercises - Upper Body
 		{
 			ExerciseName: "Bicep Curl",
+			Aliases:      []string{"curl", "biceps curl"},
 			Description:  "An isolation exercise for the biceps, curling weight from full arm extension to maximum flexion.",
+			Equipment:    "dumbbell",
+			Laterality:   "bilateral",
 		},
 		{
 			ExerciseName: "Tricep Extension",
+			Aliases:      []string{"triceps extension"},
 			Description:  "An isolation movement extending the arm to target the triceps.",
+			Equipment:    "cable",
+			Laterality:   "bilateral",
 		},
 		{
 			ExerciseName: "Lateral Raise",
+			Aliases:      []string{"side raise", "lat raise"},
 			Description:  "An isolation exercise raising dumbbells to the side to target the lateral deltoids.",
+			Equipment:    "dumbbell",
+			Laterality:   "bilateral",
 		},
 
 		// Isolation Exercises - Lower Body
 		{
 			ExerciseName: "Leg Extension",
+			Aliases:      []string{"quad extension"},
 			Description:  "A machine exercise extending the knee to target the quadriceps.",
+			Equipment:    "machine",
+			Laterality:   "bilateral",
 		},
 		{
 			ExerciseName: "Leg Curl",
+			Aliases:      []string{"hamstring curl"},
 			Description:  "A machine exercise curling the leg to target the hamstrings.",
+			Equipment:    "machine",
+			Laterality:   "bilateral",
 		},
 		{
 			ExerciseName: "Calf Raise",
 			Description:  "An isolation exercise rising onto the toes to target the calf muscles.",
+			Equipment:    "machine",
+			Laterality:   "bilateral",
 		},
 
 		// Core Exercises
 		{
 			ExerciseName: "Plank",
 			Description:  "An isometric core exercise maintaining a straight body position supported on forearms and toes.",
+			TrackingMode: "time",
+			Equipment:    "bodyweight",
 		},
 		{
 			ExerciseName: "Russian Twist",
 			Description:  "A rotational core exercise performed seated with the feet off the ground.",
+			Equipment:    "bodyweight",
 		},
 		{
 			ExerciseName: "Crunch",
+			Aliases:      []string{"ab crunch"},
 			Description:  "A basic abdominal exercise lifting the shoulders off the ground while lying on the back.",
+			TrackingMode: "reps_only",
+			Equipment:    "bodyweight",
+		},
+
+		// Cardio & Conditioning
+		{
+			ExerciseName: "Rowing Machine",
+			Aliases:      []string{"rower", "erg"},
+			Description:  "A full-body conditioning exercise on an ergometer, driving with the legs and finishing with the arms.",
+			TrackingMode: "time_distance",
+			Equipment:    "machine",
+		},
+		{
+			ExerciseName:    "Farmer's Carry",
+			Aliases:         []string{"farmer's walk", "farmers walk"},
+			Description:     "A loaded carry walking a set distance while holding heavy weights at the sides.",
+			TrackingMode:    "distance",
+			Equipment:       "dumbbell",
+			MovementPattern: "carry",
+			Laterality:      "bilateral",
+		},
+
+		// Variations - appended so the IDs referenced by the other seed files don't shift
+		{
+			ExerciseName:    "Close-Grip Bench Press",
+			Aliases:         []string{"CGBP", "close grip bench"},
+			Description:     "A bench press with the hands inside shoulder width, shifting more of the work onto the triceps.",
+			Equipment:       "barbell",
+			MovementPattern: "push",
+			Laterality:      "bilateral",
+			ParentName:      "Bench Press",
+		},
+		{
+			ExerciseName:    "Front Squat",
+			Description:     "A squat with the barbell racked across the front of the shoulders, keeping the torso more upright.",
+			Equipment:       "barbell",
+			MovementPattern: "squat",
+			Laterality:      "bilateral",
+			ParentName:      "Squat",
+		},
+		{
+			ExerciseName:    "Bulgarian Split Squat",
+			Aliases:         []string{"BSS", "rear foot elevated split squat"},
+			Description:     "A split squat with the rear foot elevated on a bench, loading the front leg.",
+			Equipment:       "dumbbell",
+			MovementPattern: "squat",
+			Laterality:      "unilateral",
+			ParentName:      "Lunge",
 		},
 	}
 }
 
 func SeedExercises(queries *sqlc.Queries) error {
+	seededIDs := make(map[string]int32)
 	for _, exercise := range GetTestExercises() {
-		_, err := queries.CreateExercise(context.Background(), sqlc.CreateExerciseParams{
+		trackingMode := sqlc.ExerciseTrackingModeEnumRepsLoad
+		if exercise.TrackingMode != "" {
+			trackingMode = sqlc.ExerciseTrackingModeEnum(exercise.TrackingMode)
+		}
+
+		var parentID sql.NullInt32
+		if exercise.ParentName != "" {
+			id, ok := seededIDs[exercise.ParentName]
+			if !ok {
+				return fmt.Errorf("failed to seed exercise %s: parent %s hasn't been seeded yet", exercise.ExerciseName, exercise.ParentName)
+			}
+			parentID = utils.ToNullInt32(id)
+		}
+
+		created, err := queries.CreateExercise(context.Background(), sqlc.CreateExerciseParams{
 			ExerciseName: exercise.ExerciseName,
 			Description:  utils.ToNullString(exercise.Description),
+			TrackingMode: trackingMode,
+			Equipment: sqlc.NullEquipmentEnum{
+				EquipmentEnum: sqlc.EquipmentEnum(exercise.Equipment),
+				Valid:         exercise.Equipment != "",
+			},
+			MovementPattern: sqlc.NullMovementPatternEnum{
+				MovementPatternEnum: sqlc.MovementPatternEnum(exercise.MovementPattern),
+				Valid:               exercise.MovementPattern != "",
+			},
+			Laterality: sqlc.NullLateralityEnum{
+				LateralityEnum: sqlc.LateralityEnum(exercise.Laterality),
+				Valid:          exercise.Laterality != "",
+			},
+			ParentExerciseID: parentID,
 		})
 		if err != nil {
 			return fmt.Errorf("failed to seed exercise %s: %v", exercise.ExerciseName, err)
 		}
+		seededIDs[exercise.ExerciseName] = created.ExerciseID
+
+		for _, alias := range exercise.Aliases {
+			_, err := queries.CreateExerciseAlias(context.Background(), sqlc.CreateExerciseAliasParams{
+				ExerciseID: created.ExerciseID,
+				Alias:      alias,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to seed alias %s for exercise %s: %v", alias, exercise.ExerciseName, err)
+			}
+		}
 	}
 	fmt.Println("Successfully seeded EXERCISES table")
 	return nil
@@ -15,7 +15,7 @@ type TestWorkoutSets struct { // Fields with pointers are nil-able
 	SetNumber        int32 // Incrementing logic will need to be handled in app layer
 	Reps             *int32
 	ResistanceType   *string  // 'weight', 'band', 'bodyweight'
-	ResistanceValue  *float32 // weight in pounds/kgs
+	ResistanceValue  *float32 // weight in lbs; stored as kg
 	ResistanceDetail *string  // "blue band", "wide grip", "olympic bar", etc.
 	RPE              *float32
 	Notes            *string
@@ -101,7 +101,11 @@ func SeedWorkoutSets(queries *sqlc.Queries) error {
 				reps[i] = *set.Reps
 			}
 			if set.ResistanceValue != nil {
-				resistanceValues[i] = fmt.Sprintf("%.1f", *set.ResistanceValue)
+				kg, err := utils.ToKgString(fmt.Sprintf("%.1f", *set.ResistanceValue), sqlc.UnitSystemEnumImperial)
+				if err != nil {
+					return fmt.Errorf("failed to convert seed weight for workout %d, exercise %d: %v", key.workoutID, key.exerciseID, err)
+				}
+				resistanceValues[i] = kg
 			}
 			if set.ResistanceType != nil {
 				resistanceTypes[i] = strings.ToLower(*set.ResistanceType)
@@ -7,6 +7,7 @@ package sqlc
 
 import (
 	"context"
+	"database/sql"
 )
 
 const createExerciseMuscle = `-- name: CreateExerciseMuscle :one
@@ -85,8 +86,9 @@ SELECT
   m.muscle_group
 FROM exercise_muscles em
 JOIN exercises e ON em.exercise_id = e.exercise_id
-JOIN muscles m ON em.muscle_id = m.muscle_id
+JOIN muscle_details m ON em.muscle_id = m.muscle_id
 WHERE em.exercise_id = $1
+ORDER BY em.involvement_level, m.muscle_name
 `
 
 type GetExerciseMusclesRow struct {
@@ -136,7 +138,7 @@ SELECT
   m.muscle_group
 FROM exercise_muscles em
 JOIN exercises e ON em.exercise_id = e.exercise_id
-JOIN muscles m ON em.muscle_id = m.muscle_id
+JOIN muscle_details m ON em.muscle_id = m.muscle_id
 WHERE m.muscle_group = $1
 ORDER BY e.exercise_name
 `
@@ -188,10 +190,17 @@ SELECT
   m.muscle_group
 FROM exercise_muscles em
 JOIN exercises e ON em.exercise_id = e.exercise_id
-JOIN muscles m ON em.muscle_id = m.muscle_id
+JOIN muscle_details m ON em.muscle_id = m.muscle_id
 WHERE em.muscle_id = $1
+AND (e.owner_user_id IS NULL OR e.owner_user_id = $2)
+ORDER BY em.involvement_level, e.exercise_name
 `
 
+type GetMuscleExercisesParams struct {
+	MuscleID int32
+	UserID   sql.NullInt32
+}
+
 type GetMuscleExercisesRow struct {
 	ExerciseID       int32
 	MuscleID         int32
@@ -201,8 +210,9 @@ type GetMuscleExercisesRow struct {
 	MuscleGroup      string
 }
 
-func (q *Queries) GetMuscleExercises(ctx context.Context, muscleID int32) ([]GetMuscleExercisesRow, error) {
-	rows, err := q.db.QueryContext(ctx, getMuscleExercises, muscleID)
+// Only exercises the user can see: the global catalog plus their own customs
+func (q *Queries) GetMuscleExercises(ctx context.Context, arg GetMuscleExercisesParams) ([]GetMuscleExercisesRow, error) {
+	rows, err := q.db.QueryContext(ctx, getMuscleExercises, arg.MuscleID, arg.UserID)
 	if err != nil {
 		return nil, err
 	}
@@ -236,9 +246,9 @@ SELECT
   m.muscle_name,
   m.muscle_group
 FROM exercise_muscles em
-JOIN muscles m ON em.muscle_id = m.muscle_id
+JOIN muscle_details m ON em.muscle_id = m.muscle_id
 WHERE em.exercise_id = $1 
-AND em.involvement_level = 'Primary'
+AND em.involvement_level = 'primary'
 ORDER BY m.muscle_name
 `
 
@@ -278,7 +288,7 @@ SELECT
   m.muscle_group
 FROM exercise_muscles em
 JOIN exercises e ON em.exercise_id = e.exercise_id
-JOIN muscles m ON em.muscle_id = m.muscle_id
+JOIN muscle_details m ON em.muscle_id = m.muscle_id
 ORDER BY e.exercise_name, m.muscle_name
 `
 
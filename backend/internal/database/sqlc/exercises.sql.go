@@ -8,30 +8,59 @@ package sqlc
 import (
 	"context"
 	"database/sql"
+
+	"github.com/lib/pq"
 )
 
 const createExercise = `-- name: CreateExercise :one
 INSERT INTO exercises (
     exercise_name,
-    description
+    description,
+    tracking_mode,
+    owner_user_id,
+    equipment,
+    movement_pattern,
+    laterality,
+    parent_exercise_id
 ) VALUES (
-    $1, $2
-) RETURNING exercise_id, exercise_name, description, created_at
+    $1, $2, $3, $4, $5, $6, $7, $8
+) RETURNING exercise_id, exercise_name, description, created_at, tracking_mode, owner_user_id, equipment, movement_pattern, laterality, parent_exercise_id
 `
 
 type CreateExerciseParams struct {
-	ExerciseName string
-	Description  sql.NullString
+	ExerciseName     string
+	Description      sql.NullString
+	TrackingMode     ExerciseTrackingModeEnum
+	OwnerUserID      sql.NullInt32
+	Equipment        NullEquipmentEnum
+	MovementPattern  NullMovementPatternEnum
+	Laterality       NullLateralityEnum
+	ParentExerciseID sql.NullInt32
 }
 
 func (q *Queries) CreateExercise(ctx context.Context, arg CreateExerciseParams) (Exercise, error) {
-	row := q.db.QueryRowContext(ctx, createExercise, arg.ExerciseName, arg.Description)
+	row := q.db.QueryRowContext(ctx, createExercise,
+		arg.ExerciseName,
+		arg.Description,
+		arg.TrackingMode,
+		arg.OwnerUserID,
+		arg.Equipment,
+		arg.MovementPattern,
+		arg.Laterality,
+		arg.ParentExerciseID,
+	)
 	var i Exercise
 	err := row.Scan(
 		&i.ExerciseID,
 		&i.ExerciseName,
 		&i.Description,
 		&i.CreatedAt,
+		&i.TrackingMode,
+		&i.OwnerUserID,
+		&i.Equipment,
+		&i.MovementPattern,
+		&i.Laterality,
+		&i.ParentExerciseID,
 	)
 	return i, err
 }
@@ -48,7 +77,7 @@ func (q *Queries) DeleteAllExercises(ctx context.Context) error {
 const deleteExercise = `-- name: DeleteExercise :one
 DELETE FROM exercises 
 WHERE exercise_id = $1
-RETURNING exercise_id, exercise_name, description, created_at
+RETURNING exercise_id, exercise_name, description, created_at, tracking_mode, owner_user_id, equipment, movement_pattern, laterality, parent_exercise_id
 `
 
 func (q *Queries) DeleteExercise(ctx context.Context, exerciseID int32) (Exercise, error) {
@@ -59,6 +88,12 @@ func (q *Queries) DeleteExercise(ctx context.Context, exerciseID int32) (Exercis
 		&i.ExerciseName,
 		&i.Description,
 		&i.CreatedAt,
+		&i.TrackingMode,
+		&i.OwnerUserID,
+		&i.Equipment,
+		&i.MovementPattern,
+		&i.Laterality,
+		&i.ParentExerciseID,
 	)
 	return i, err
 }
@@ -78,7 +113,7 @@ func (q *Queries) ExerciseExists(ctx context.Context, exerciseName string) (bool
 }
 
 const getAllExercises = `-- name: GetAllExercises :many
-SELECT exercise_id, exercise_name, description, created_at FROM exercises 
+SELECT exercise_id, exercise_name, description, created_at, tracking_mode, owner_user_id, equipment, movement_pattern, laterality, parent_exercise_id FROM exercises 
 ORDER BY exercise_id
 `
 
@@ -96,6 +131,12 @@ func (q *Queries) GetAllExercises(ctx context.Context) ([]Exercise, error) {
 			&i.ExerciseName,
 			&i.Description,
 			&i.CreatedAt,
+			&i.TrackingMode,
+			&i.OwnerUserID,
+			&i.Equipment,
+			&i.MovementPattern,
+			&i.Laterality,
+			&i.ParentExerciseID,
 		); err != nil {
 			return nil, err
 		}
@@ -110,8 +151,198 @@ func (q *Queries) GetAllExercises(ctx context.Context) ([]Exercise, error) {
 	return items, nil
 }
 
+const getEstimatedOneRepMaxesForUser = `-- name: GetEstimatedOneRepMaxesForUser :many
+SELECT
+  exercise_id,
+  exercise_name,
+  estimated_1rm::decimal AS estimated_1rm_kg
+FROM exercise_one_rm
+WHERE user_id = $1
+ORDER BY exercise_name, exercise_id
+`
+
+type GetEstimatedOneRepMaxesForUserRow struct {
+	ExerciseID     int32
+	ExerciseName   string
+	Estimated1rmKg string
+}
+
+// Estimated 1RMs come out of the view in kg
+func (q *Queries) GetEstimatedOneRepMaxesForUser(ctx context.Context, userID sql.NullInt32) ([]GetEstimatedOneRepMaxesForUserRow, error) {
+	rows, err := q.db.QueryContext(ctx, getEstimatedOneRepMaxesForUser, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetEstimatedOneRepMaxesForUserRow
+	for rows.Next() {
+		var i GetEstimatedOneRepMaxesForUserRow
+		if err := rows.Scan(&i.ExerciseID, &i.ExerciseName, &i.Estimated1rmKg); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getExerciseAlternatives = `-- name: GetExerciseAlternatives :many
+WITH source AS (
+  SELECT exercise_id, exercise_name, description, created_at, tracking_mode, owner_user_id, equipment, movement_pattern, laterality, parent_exercise_id FROM exercises
+  WHERE exercise_id = $1
+),
+source_muscles AS (
+  SELECT muscle_id, CASE WHEN involvement_level = 'primary' THEN 2 ELSE 1 END AS weight
+  FROM exercise_muscles
+  WHERE exercise_id = $1
+),
+possible AS (
+  SELECT COALESCE(SUM(weight * 2), 0)::float8 AS score FROM source_muscles
+),
+overlap AS (
+  SELECT
+    em.exercise_id,
+    COUNT(*)::int AS shared_muscles,
+    SUM(sm.weight * CASE WHEN em.involvement_level = 'primary' THEN 2 ELSE 1 END)::float8 AS score
+  FROM exercise_muscles em
+  JOIN source_muscles sm ON em.muscle_id = sm.muscle_id
+  GROUP BY em.exercise_id
+),
+candidates AS (
+  SELECT
+    e.exercise_id, e.exercise_name, e.description, e.created_at, e.tracking_mode, e.owner_user_id, e.equipment, e.movement_pattern, e.laterality, e.parent_exercise_id,
+    COALESCE(o.shared_muscles, 0)::int AS shared_muscles,
+    COALESCE(o.score / NULLIF(p.score, 0), 0)::float8 AS muscle_overlap,
+    (e.movement_pattern IS NOT NULL AND e.movement_pattern = s.movement_pattern)::bool AS same_movement_pattern
+  FROM exercises e
+  CROSS JOIN source s
+  CROSS JOIN possible p
+  LEFT JOIN overlap o ON e.exercise_id = o.exercise_id
+  WHERE e.exercise_id <> s.exercise_id
+  AND (e.owner_user_id IS NULL OR e.owner_user_id = $2)
+  AND e.tracking_mode = s.tracking_mode
+  AND (cardinality($3::text[]) = 0 OR e.equipment IS NULL OR e.equipment::text = ANY($3::text[]))
+)
+SELECT
+  c.exercise_id, c.exercise_name, c.description, c.created_at, c.tracking_mode, c.owner_user_id, c.equipment, c.movement_pattern, c.laterality, c.parent_exercise_id,
+  c.shared_muscles,
+  c.muscle_overlap,
+  c.same_movement_pattern,
+  (c.muscle_overlap * 0.7 + CASE WHEN c.same_movement_pattern THEN 0.3 ELSE 0 END)::float8 AS score
+FROM candidates c
+WHERE c.shared_muscles > 0 OR c.same_movement_pattern
+ORDER BY score DESC, c.exercise_name
+LIMIT $4
+`
+
+type GetExerciseAlternativesParams struct {
+	ExerciseID int32
+	UserID     sql.NullInt32
+	Equipment  []string
+	Limit      int32
+}
+
+type GetExerciseAlternativesRow struct {
+	ExerciseID          int32
+	ExerciseName        string
+	Description         sql.NullString
+	CreatedAt           sql.NullTime
+	TrackingMode        ExerciseTrackingModeEnum
+	OwnerUserID         sql.NullInt32
+	Equipment           NullEquipmentEnum
+	MovementPattern     NullMovementPatternEnum
+	Laterality          NullLateralityEnum
+	ParentExerciseID    sql.NullInt32
+	SharedMuscles       int32
+	MuscleOverlap       float64
+	SameMovementPattern bool
+	Score               float64
+}
+
+// Other visible exercises with the same tracking mode that work the same muscles or share the movement pattern.
+// Muscle overlap is 0-1: each of the source's muscles scores 4 when both sides list it as primary, 2 when one does and
+// 1 when neither does, out of the most the source's own muscles could score. An empty equipment list means no filter;
+// exercises with no equipment recorded are always kept.
+func (q *Queries) GetExerciseAlternatives(ctx context.Context, arg GetExerciseAlternativesParams) ([]GetExerciseAlternativesRow, error) {
+	rows, err := q.db.QueryContext(ctx, getExerciseAlternatives,
+		arg.ExerciseID,
+		arg.UserID,
+		pq.Array(arg.Equipment),
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetExerciseAlternativesRow
+	for rows.Next() {
+		var i GetExerciseAlternativesRow
+		if err := rows.Scan(
+			&i.ExerciseID,
+			&i.ExerciseName,
+			&i.Description,
+			&i.CreatedAt,
+			&i.TrackingMode,
+			&i.OwnerUserID,
+			&i.Equipment,
+			&i.MovementPattern,
+			&i.Laterality,
+			&i.ParentExerciseID,
+			&i.SharedMuscles,
+			&i.MuscleOverlap,
+			&i.SameMovementPattern,
+			&i.Score,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getExerciseByIDForUser = `-- name: GetExerciseByIDForUser :one
+SELECT exercise_id, exercise_name, description, created_at, tracking_mode, owner_user_id, equipment, movement_pattern, laterality, parent_exercise_id FROM exercises
+WHERE exercise_id = $1
+AND (owner_user_id IS NULL OR owner_user_id = $2)
+`
+
+type GetExerciseByIDForUserParams struct {
+	ExerciseID int32
+	UserID     sql.NullInt32
+}
+
+// Only exercises the user can see: the global catalog plus their own customs
+func (q *Queries) GetExerciseByIDForUser(ctx context.Context, arg GetExerciseByIDForUserParams) (Exercise, error) {
+	row := q.db.QueryRowContext(ctx, getExerciseByIDForUser, arg.ExerciseID, arg.UserID)
+	var i Exercise
+	err := row.Scan(
+		&i.ExerciseID,
+		&i.ExerciseName,
+		&i.Description,
+		&i.CreatedAt,
+		&i.TrackingMode,
+		&i.OwnerUserID,
+		&i.Equipment,
+		&i.MovementPattern,
+		&i.Laterality,
+		&i.ParentExerciseID,
+	)
+	return i, err
+}
+
 const getExerciseById = `-- name: GetExerciseById :one
-SELECT exercise_id, exercise_name, description, created_at FROM exercises 
+SELECT exercise_id, exercise_name, description, created_at, tracking_mode, owner_user_id, equipment, movement_pattern, laterality, parent_exercise_id FROM exercises 
 WHERE exercise_id = $1
 `
 
@@ -123,41 +354,75 @@ func (q *Queries) GetExerciseById(ctx context.Context, exerciseID int32) (Exerci
 		&i.ExerciseName,
 		&i.Description,
 		&i.CreatedAt,
+		&i.TrackingMode,
+		&i.OwnerUserID,
+		&i.Equipment,
+		&i.MovementPattern,
+		&i.Laterality,
+		&i.ParentExerciseID,
 	)
 	return i, err
 }
 
 const getExerciseByName = `-- name: GetExerciseByName :one
-SELECT exercise_id, exercise_name, description, created_at FROM exercises 
+SELECT exercise_id, exercise_name, description, created_at, tracking_mode, owner_user_id, equipment, movement_pattern, laterality, parent_exercise_id FROM exercises 
 WHERE exercise_name = $1
+AND (owner_user_id IS NULL OR owner_user_id = $2)
+ORDER BY owner_user_id NULLS LAST
+LIMIT 1
 `
 
-func (q *Queries) GetExerciseByName(ctx context.Context, exerciseName string) (Exercise, error) {
-	row := q.db.QueryRowContext(ctx, getExerciseByName, exerciseName)
+type GetExerciseByNameParams struct {
+	ExerciseName string
+	UserID       sql.NullInt32
+}
+
+// A user's own custom exercise wins over a global one with the same name
+func (q *Queries) GetExerciseByName(ctx context.Context, arg GetExerciseByNameParams) (Exercise, error) {
+	row := q.db.QueryRowContext(ctx, getExerciseByName, arg.ExerciseName, arg.UserID)
 	var i Exercise
 	err := row.Scan(
 		&i.ExerciseID,
 		&i.ExerciseName,
 		&i.Description,
 		&i.CreatedAt,
+		&i.TrackingMode,
+		&i.OwnerUserID,
+		&i.Equipment,
+		&i.MovementPattern,
+		&i.Laterality,
+		&i.ParentExerciseID,
 	)
 	return i, err
 }
 
-const searchExercises = `-- name: SearchExercises :many
-SELECT exercise_id, exercise_name, description, created_at FROM exercises 
-WHERE exercise_name ILIKE $1 
-ORDER BY exercise_name 
-LIMIT $2
+const getExercisesForUser = `-- name: GetExercisesForUser :many
+SELECT exercise_id, exercise_name, description, created_at, tracking_mode, owner_user_id, equipment, movement_pattern, laterality, parent_exercise_id FROM exercises
+WHERE (owner_user_id IS NULL OR owner_user_id = $1)
+AND ($2::equipment_enum IS NULL OR equipment = $2)
+AND ($3::movement_pattern_enum IS NULL OR movement_pattern = $3)
+AND ($4::laterality_enum IS NULL OR laterality = $4)
+AND ($5::int IS NULL OR parent_exercise_id = $5)
+ORDER BY exercise_id
 `
 
-type SearchExercisesParams struct {
-	ExerciseName string
-	Limit        int32
+type GetExercisesForUserParams struct {
+	UserID           sql.NullInt32
+	Equipment        NullEquipmentEnum
+	MovementPattern  NullMovementPatternEnum
+	Laterality       NullLateralityEnum
+	ParentExerciseID sql.NullInt32
 }
 
-func (q *Queries) SearchExercises(ctx context.Context, arg SearchExercisesParams) ([]Exercise, error) {
-	rows, err := q.db.QueryContext(ctx, searchExercises, arg.ExerciseName, arg.Limit)
+// The global catalog merged with the user's custom exercises; each taxonomy filter is skipped when NULL
+func (q *Queries) GetExercisesForUser(ctx context.Context, arg GetExercisesForUserParams) ([]Exercise, error) {
+	rows, err := q.db.QueryContext(ctx, getExercisesForUser,
+		arg.UserID,
+		arg.Equipment,
+		arg.MovementPattern,
+		arg.Laterality,
+		arg.ParentExerciseID,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -170,6 +435,201 @@ func (q *Queries) SearchExercises(ctx context.Context, arg SearchExercisesParams
 			&i.ExerciseName,
 			&i.Description,
 			&i.CreatedAt,
+			&i.TrackingMode,
+			&i.OwnerUserID,
+			&i.Equipment,
+			&i.MovementPattern,
+			&i.Laterality,
+			&i.ParentExerciseID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const matchExerciseName = `-- name: MatchExerciseName :one
+SELECT e.exercise_id, e.exercise_name, e.description, e.created_at, e.tracking_mode, e.owner_user_id, e.equipment, e.movement_pattern, e.laterality, e.parent_exercise_id
+FROM exercises e
+LEFT JOIN exercise_aliases a ON e.exercise_id = a.exercise_id
+  AND regexp_replace(lower(a.alias), '[^a-z0-9]', '', 'g') = regexp_replace(lower($1), '[^a-z0-9]', '', 'g')
+WHERE (e.owner_user_id IS NULL OR e.owner_user_id = $2)
+AND (
+  regexp_replace(lower(e.exercise_name), '[^a-z0-9]', '', 'g') = regexp_replace(lower($1), '[^a-z0-9]', '', 'g')
+  OR a.alias IS NOT NULL
+)
+ORDER BY a.alias IS NOT NULL, e.owner_user_id NULLS LAST, e.exercise_id
+LIMIT 1
+`
+
+type MatchExerciseNameParams struct {
+	Name   string
+	UserID sql.NullInt32
+}
+
+// Exact match on a name or alias, ignoring case, spaces & punctuation ("pull up" finds "Pull-up"). Names beat aliases &
+// a user's custom exercise beats a global one
+func (q *Queries) MatchExerciseName(ctx context.Context, arg MatchExerciseNameParams) (Exercise, error) {
+	row := q.db.QueryRowContext(ctx, matchExerciseName, arg.Name, arg.UserID)
+	var i Exercise
+	err := row.Scan(
+		&i.ExerciseID,
+		&i.ExerciseName,
+		&i.Description,
+		&i.CreatedAt,
+		&i.TrackingMode,
+		&i.OwnerUserID,
+		&i.Equipment,
+		&i.MovementPattern,
+		&i.Laterality,
+		&i.ParentExerciseID,
+	)
+	return i, err
+}
+
+const promoteExercise = `-- name: PromoteExercise :one
+UPDATE exercises
+SET owner_user_id = NULL
+WHERE exercise_id = $1
+AND owner_user_id IS NOT NULL
+RETURNING exercise_id, exercise_name, description, created_at, tracking_mode, owner_user_id, equipment, movement_pattern, laterality, parent_exercise_id
+`
+
+// Moves a custom exercise into the global catalog; no rows if it's already global
+func (q *Queries) PromoteExercise(ctx context.Context, exerciseID int32) (Exercise, error) {
+	row := q.db.QueryRowContext(ctx, promoteExercise, exerciseID)
+	var i Exercise
+	err := row.Scan(
+		&i.ExerciseID,
+		&i.ExerciseName,
+		&i.Description,
+		&i.CreatedAt,
+		&i.TrackingMode,
+		&i.OwnerUserID,
+		&i.Equipment,
+		&i.MovementPattern,
+		&i.Laterality,
+		&i.ParentExerciseID,
+	)
+	return i, err
+}
+
+const searchExercises = `-- name: SearchExercises :many
+WITH matches AS (
+  -- names & aliases are compared with & without punctuation/spaces, so "pullup" matches "Pull-up"
+  SELECT
+    e.exercise_id,
+    'exercise_name' AS matched_field,
+    e.exercise_name::text AS matched_text,
+    GREATEST(
+      word_similarity(lower($1), lower(e.exercise_name)),
+      similarity(regexp_replace(lower(e.exercise_name), '[^a-z0-9]', '', 'g'), regexp_replace(lower($1), '[^a-z0-9]', '', 'g'))
+    ) AS score
+  FROM exercises e
+  UNION ALL
+  SELECT
+    a.exercise_id,
+    'alias',
+    a.alias::text,
+    GREATEST(
+      word_similarity(lower($1), lower(a.alias)),
+      similarity(regexp_replace(lower(a.alias), '[^a-z0-9]', '', 'g'), regexp_replace(lower($1), '[^a-z0-9]', '', 'g'))
+    )
+  FROM exercise_aliases a
+  UNION ALL
+  -- description hits count for less than name hits; they're mostly there to catch muscle & equipment words
+  SELECT
+    e.exercise_id,
+    'description',
+    e.description::text,
+    0.3 + ts_rank(to_tsvector('english', COALESCE(e.description, '')), plainto_tsquery('english', $1))
+  FROM exercises e
+  WHERE to_tsvector('english', COALESCE(e.description, '')) @@ plainto_tsquery('english', $1)
+),
+best_match AS (
+  SELECT DISTINCT ON (exercise_id) exercise_id, matched_field, matched_text, score
+  FROM matches
+  WHERE score >= 0.3
+  ORDER BY exercise_id, score DESC
+),
+recent_sets AS (
+  SELECT ws.exercise_id, COUNT(*) AS set_count
+  FROM workout_sets ws
+  JOIN workouts w ON ws.workout_id = w.workout_id
+  WHERE w.user_id = $2
+  AND w.workout_date >= CURRENT_DATE - 90
+  GROUP BY ws.exercise_id
+)
+SELECT
+  e.exercise_id, e.exercise_name, e.description, e.created_at, e.tracking_mode, e.owner_user_id, e.equipment, e.movement_pattern, e.laterality, e.parent_exercise_id,
+  b.matched_field::text AS matched_field,
+  b.matched_text::text AS matched_text,
+  COALESCE(r.set_count, 0)::int AS recent_set_count,
+  -- up to +0.2 for exercises the user has done a lot of in the last 90 days
+  (b.score + LEAST(COALESCE(r.set_count, 0), 20) * 0.01)::float8 AS rank
+FROM best_match b
+JOIN exercises e ON b.exercise_id = e.exercise_id
+LEFT JOIN recent_sets r ON b.exercise_id = r.exercise_id
+WHERE e.owner_user_id IS NULL OR e.owner_user_id = $2
+ORDER BY rank DESC, e.exercise_name
+LIMIT $3
+`
+
+type SearchExercisesParams struct {
+	Query  string
+	UserID sql.NullInt32
+	Limit  int32
+}
+
+type SearchExercisesRow struct {
+	ExerciseID       int32
+	ExerciseName     string
+	Description      sql.NullString
+	CreatedAt        sql.NullTime
+	TrackingMode     ExerciseTrackingModeEnum
+	OwnerUserID      sql.NullInt32
+	Equipment        NullEquipmentEnum
+	MovementPattern  NullMovementPatternEnum
+	Laterality       NullLateralityEnum
+	ParentExerciseID sql.NullInt32
+	MatchedField     string
+	MatchedText      string
+	RecentSetCount   int32
+	Rank             float64
+}
+
+// Fuzzy search over names, aliases & descriptions, best match per exercise, boosted by how often the user has done it lately
+func (q *Queries) SearchExercises(ctx context.Context, arg SearchExercisesParams) ([]SearchExercisesRow, error) {
+	rows, err := q.db.QueryContext(ctx, searchExercises, arg.Query, arg.UserID, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []SearchExercisesRow
+	for rows.Next() {
+		var i SearchExercisesRow
+		if err := rows.Scan(
+			&i.ExerciseID,
+			&i.ExerciseName,
+			&i.Description,
+			&i.CreatedAt,
+			&i.TrackingMode,
+			&i.OwnerUserID,
+			&i.Equipment,
+			&i.MovementPattern,
+			&i.Laterality,
+			&i.ParentExerciseID,
+			&i.MatchedField,
+			&i.MatchedText,
+			&i.RecentSetCount,
+			&i.Rank,
 		); err != nil {
 			return nil, err
 		}
@@ -188,7 +648,7 @@ const updateExercise = `-- name: UpdateExercise :one
 UPDATE exercises 
 SET exercise_name = $2, description = $3
 WHERE exercise_id = $1
-RETURNING exercise_id, exercise_name, description, created_at
+RETURNING exercise_id, exercise_name, description, created_at, tracking_mode, owner_user_id, equipment, movement_pattern, laterality, parent_exercise_id
 `
 
 type UpdateExerciseParams struct {
@@ -205,6 +665,12 @@ func (q *Queries) UpdateExercise(ctx context.Context, arg UpdateExerciseParams)
 		&i.ExerciseName,
 		&i.Description,
 		&i.CreatedAt,
+		&i.TrackingMode,
+		&i.OwnerUserID,
+		&i.Equipment,
+		&i.MovementPattern,
+		&i.Laterality,
+		&i.ParentExerciseID,
 	)
 	return i, err
 }
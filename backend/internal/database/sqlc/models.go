@@ -11,6 +11,317 @@ import (
 	"time"
 )
 
+type AccountExportStatusEnum string
+
+const (
+	AccountExportStatusEnumPending   AccountExportStatusEnum = "pending"
+	AccountExportStatusEnumRunning   AccountExportStatusEnum = "running"
+	AccountExportStatusEnumCompleted AccountExportStatusEnum = "completed"
+	AccountExportStatusEnumFailed    AccountExportStatusEnum = "failed"
+)
+
+func (e *AccountExportStatusEnum) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = AccountExportStatusEnum(s)
+	case string:
+		*e = AccountExportStatusEnum(s)
+	default:
+		return fmt.Errorf("unsupported scan type for AccountExportStatusEnum: %T", src)
+	}
+	return nil
+}
+
+type NullAccountExportStatusEnum struct {
+	AccountExportStatusEnum AccountExportStatusEnum
+	Valid                   bool // Valid is true if AccountExportStatusEnum is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullAccountExportStatusEnum) Scan(value interface{}) error {
+	if value == nil {
+		ns.AccountExportStatusEnum, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.AccountExportStatusEnum.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullAccountExportStatusEnum) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.AccountExportStatusEnum), nil
+}
+
+type BodyRegionEnum string
+
+const (
+	BodyRegionEnumUpperBody BodyRegionEnum = "upper_body"
+	BodyRegionEnumLowerBody BodyRegionEnum = "lower_body"
+	BodyRegionEnumCore      BodyRegionEnum = "core"
+)
+
+func (e *BodyRegionEnum) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = BodyRegionEnum(s)
+	case string:
+		*e = BodyRegionEnum(s)
+	default:
+		return fmt.Errorf("unsupported scan type for BodyRegionEnum: %T", src)
+	}
+	return nil
+}
+
+type NullBodyRegionEnum struct {
+	BodyRegionEnum BodyRegionEnum
+	Valid          bool // Valid is true if BodyRegionEnum is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullBodyRegionEnum) Scan(value interface{}) error {
+	if value == nil {
+		ns.BodyRegionEnum, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.BodyRegionEnum.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullBodyRegionEnum) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.BodyRegionEnum), nil
+}
+
+type DistanceUnitEnum string
+
+const (
+	DistanceUnitEnumM  DistanceUnitEnum = "m"
+	DistanceUnitEnumKm DistanceUnitEnum = "km"
+	DistanceUnitEnumMi DistanceUnitEnum = "mi"
+	DistanceUnitEnumYd DistanceUnitEnum = "yd"
+	DistanceUnitEnumFt DistanceUnitEnum = "ft"
+)
+
+func (e *DistanceUnitEnum) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = DistanceUnitEnum(s)
+	case string:
+		*e = DistanceUnitEnum(s)
+	default:
+		return fmt.Errorf("unsupported scan type for DistanceUnitEnum: %T", src)
+	}
+	return nil
+}
+
+type NullDistanceUnitEnum struct {
+	DistanceUnitEnum DistanceUnitEnum
+	Valid            bool // Valid is true if DistanceUnitEnum is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullDistanceUnitEnum) Scan(value interface{}) error {
+	if value == nil {
+		ns.DistanceUnitEnum, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.DistanceUnitEnum.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullDistanceUnitEnum) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.DistanceUnitEnum), nil
+}
+
+type EquipmentEnum string
+
+const (
+	EquipmentEnumBarbell    EquipmentEnum = "barbell"
+	EquipmentEnumDumbbell   EquipmentEnum = "dumbbell"
+	EquipmentEnumCable      EquipmentEnum = "cable"
+	EquipmentEnumMachine    EquipmentEnum = "machine"
+	EquipmentEnumBand       EquipmentEnum = "band"
+	EquipmentEnumBodyweight EquipmentEnum = "bodyweight"
+)
+
+func (e *EquipmentEnum) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = EquipmentEnum(s)
+	case string:
+		*e = EquipmentEnum(s)
+	default:
+		return fmt.Errorf("unsupported scan type for EquipmentEnum: %T", src)
+	}
+	return nil
+}
+
+type NullEquipmentEnum struct {
+	EquipmentEnum EquipmentEnum
+	Valid         bool // Valid is true if EquipmentEnum is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullEquipmentEnum) Scan(value interface{}) error {
+	if value == nil {
+		ns.EquipmentEnum, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.EquipmentEnum.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullEquipmentEnum) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.EquipmentEnum), nil
+}
+
+type ExerciseTrackingModeEnum string
+
+const (
+	ExerciseTrackingModeEnumRepsLoad     ExerciseTrackingModeEnum = "reps_load"
+	ExerciseTrackingModeEnumRepsOnly     ExerciseTrackingModeEnum = "reps_only"
+	ExerciseTrackingModeEnumTime         ExerciseTrackingModeEnum = "time"
+	ExerciseTrackingModeEnumDistance     ExerciseTrackingModeEnum = "distance"
+	ExerciseTrackingModeEnumTimeDistance ExerciseTrackingModeEnum = "time_distance"
+)
+
+func (e *ExerciseTrackingModeEnum) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = ExerciseTrackingModeEnum(s)
+	case string:
+		*e = ExerciseTrackingModeEnum(s)
+	default:
+		return fmt.Errorf("unsupported scan type for ExerciseTrackingModeEnum: %T", src)
+	}
+	return nil
+}
+
+type NullExerciseTrackingModeEnum struct {
+	ExerciseTrackingModeEnum ExerciseTrackingModeEnum
+	Valid                    bool // Valid is true if ExerciseTrackingModeEnum is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullExerciseTrackingModeEnum) Scan(value interface{}) error {
+	if value == nil {
+		ns.ExerciseTrackingModeEnum, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.ExerciseTrackingModeEnum.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullExerciseTrackingModeEnum) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.ExerciseTrackingModeEnum), nil
+}
+
+type GoalStatusEnum string
+
+const (
+	GoalStatusEnumActive    GoalStatusEnum = "active"
+	GoalStatusEnumCompleted GoalStatusEnum = "completed"
+	GoalStatusEnumMissed    GoalStatusEnum = "missed"
+	GoalStatusEnumAbandoned GoalStatusEnum = "abandoned"
+)
+
+func (e *GoalStatusEnum) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = GoalStatusEnum(s)
+	case string:
+		*e = GoalStatusEnum(s)
+	default:
+		return fmt.Errorf("unsupported scan type for GoalStatusEnum: %T", src)
+	}
+	return nil
+}
+
+type NullGoalStatusEnum struct {
+	GoalStatusEnum GoalStatusEnum
+	Valid          bool // Valid is true if GoalStatusEnum is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullGoalStatusEnum) Scan(value interface{}) error {
+	if value == nil {
+		ns.GoalStatusEnum, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.GoalStatusEnum.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullGoalStatusEnum) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.GoalStatusEnum), nil
+}
+
+type GoalTypeEnum string
+
+const (
+	GoalTypeEnumEstimated1rm   GoalTypeEnum = "estimated_1rm"
+	GoalTypeEnumMaxWeight      GoalTypeEnum = "max_weight"
+	GoalTypeEnumBodyweight     GoalTypeEnum = "bodyweight"
+	GoalTypeEnumWeeklySessions GoalTypeEnum = "weekly_sessions"
+)
+
+func (e *GoalTypeEnum) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = GoalTypeEnum(s)
+	case string:
+		*e = GoalTypeEnum(s)
+	default:
+		return fmt.Errorf("unsupported scan type for GoalTypeEnum: %T", src)
+	}
+	return nil
+}
+
+type NullGoalTypeEnum struct {
+	GoalTypeEnum GoalTypeEnum
+	Valid        bool // Valid is true if GoalTypeEnum is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullGoalTypeEnum) Scan(value interface{}) error {
+	if value == nil {
+		ns.GoalTypeEnum, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.GoalTypeEnum.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullGoalTypeEnum) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.GoalTypeEnum), nil
+}
+
 type InvolvementLevelEnum string
 
 const (
@@ -53,6 +364,180 @@ func (ns NullInvolvementLevelEnum) Value() (driver.Value, error) {
 	return string(ns.InvolvementLevelEnum), nil
 }
 
+type LateralityEnum string
+
+const (
+	LateralityEnumBilateral  LateralityEnum = "bilateral"
+	LateralityEnumUnilateral LateralityEnum = "unilateral"
+)
+
+func (e *LateralityEnum) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = LateralityEnum(s)
+	case string:
+		*e = LateralityEnum(s)
+	default:
+		return fmt.Errorf("unsupported scan type for LateralityEnum: %T", src)
+	}
+	return nil
+}
+
+type NullLateralityEnum struct {
+	LateralityEnum LateralityEnum
+	Valid          bool // Valid is true if LateralityEnum is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullLateralityEnum) Scan(value interface{}) error {
+	if value == nil {
+		ns.LateralityEnum, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.LateralityEnum.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullLateralityEnum) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.LateralityEnum), nil
+}
+
+type MovementPatternEnum string
+
+const (
+	MovementPatternEnumHinge MovementPatternEnum = "hinge"
+	MovementPatternEnumSquat MovementPatternEnum = "squat"
+	MovementPatternEnumPush  MovementPatternEnum = "push"
+	MovementPatternEnumPull  MovementPatternEnum = "pull"
+	MovementPatternEnumCarry MovementPatternEnum = "carry"
+)
+
+func (e *MovementPatternEnum) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = MovementPatternEnum(s)
+	case string:
+		*e = MovementPatternEnum(s)
+	default:
+		return fmt.Errorf("unsupported scan type for MovementPatternEnum: %T", src)
+	}
+	return nil
+}
+
+type NullMovementPatternEnum struct {
+	MovementPatternEnum MovementPatternEnum
+	Valid               bool // Valid is true if MovementPatternEnum is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullMovementPatternEnum) Scan(value interface{}) error {
+	if value == nil {
+		ns.MovementPatternEnum, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.MovementPatternEnum.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullMovementPatternEnum) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.MovementPatternEnum), nil
+}
+
+type ProgressionStrategyEnum string
+
+const (
+	ProgressionStrategyEnumDoubleProgression ProgressionStrategyEnum = "double_progression"
+	ProgressionStrategyEnumRpe               ProgressionStrategyEnum = "rpe"
+)
+
+func (e *ProgressionStrategyEnum) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = ProgressionStrategyEnum(s)
+	case string:
+		*e = ProgressionStrategyEnum(s)
+	default:
+		return fmt.Errorf("unsupported scan type for ProgressionStrategyEnum: %T", src)
+	}
+	return nil
+}
+
+type NullProgressionStrategyEnum struct {
+	ProgressionStrategyEnum ProgressionStrategyEnum
+	Valid                   bool // Valid is true if ProgressionStrategyEnum is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullProgressionStrategyEnum) Scan(value interface{}) error {
+	if value == nil {
+		ns.ProgressionStrategyEnum, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.ProgressionStrategyEnum.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullProgressionStrategyEnum) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.ProgressionStrategyEnum), nil
+}
+
+type RecordTypeEnum string
+
+const (
+	RecordTypeEnumMaxWeight       RecordTypeEnum = "max_weight"
+	RecordTypeEnumEstimated1rm    RecordTypeEnum = "estimated_1rm"
+	RecordTypeEnumMaxRepsAtWeight RecordTypeEnum = "max_reps_at_weight"
+	RecordTypeEnumSetVolume       RecordTypeEnum = "set_volume"
+	RecordTypeEnumSessionVolume   RecordTypeEnum = "session_volume"
+)
+
+func (e *RecordTypeEnum) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = RecordTypeEnum(s)
+	case string:
+		*e = RecordTypeEnum(s)
+	default:
+		return fmt.Errorf("unsupported scan type for RecordTypeEnum: %T", src)
+	}
+	return nil
+}
+
+type NullRecordTypeEnum struct {
+	RecordTypeEnum RecordTypeEnum
+	Valid          bool // Valid is true if RecordTypeEnum is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullRecordTypeEnum) Scan(value interface{}) error {
+	if value == nil {
+		ns.RecordTypeEnum, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.RecordTypeEnum.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullRecordTypeEnum) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.RecordTypeEnum), nil
+}
+
 type ResistanceTypeEnum string
 
 const (
@@ -96,17 +581,216 @@ func (ns NullResistanceTypeEnum) Value() (driver.Value, error) {
 	return string(ns.ResistanceTypeEnum), nil
 }
 
+type SetGroupTypeEnum string
+
+const (
+	SetGroupTypeEnumSuperset SetGroupTypeEnum = "superset"
+	SetGroupTypeEnumGiantSet SetGroupTypeEnum = "giant_set"
+	SetGroupTypeEnumCircuit  SetGroupTypeEnum = "circuit"
+	SetGroupTypeEnumEmom     SetGroupTypeEnum = "emom"
+)
+
+func (e *SetGroupTypeEnum) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = SetGroupTypeEnum(s)
+	case string:
+		*e = SetGroupTypeEnum(s)
+	default:
+		return fmt.Errorf("unsupported scan type for SetGroupTypeEnum: %T", src)
+	}
+	return nil
+}
+
+type NullSetGroupTypeEnum struct {
+	SetGroupTypeEnum SetGroupTypeEnum
+	Valid            bool // Valid is true if SetGroupTypeEnum is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullSetGroupTypeEnum) Scan(value interface{}) error {
+	if value == nil {
+		ns.SetGroupTypeEnum, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.SetGroupTypeEnum.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullSetGroupTypeEnum) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.SetGroupTypeEnum), nil
+}
+
+type SetTypeEnum string
+
+const (
+	SetTypeEnumWarmup    SetTypeEnum = "warmup"
+	SetTypeEnumWorking   SetTypeEnum = "working"
+	SetTypeEnumDrop      SetTypeEnum = "drop"
+	SetTypeEnumAmrap     SetTypeEnum = "amrap"
+	SetTypeEnumFailure   SetTypeEnum = "failure"
+	SetTypeEnumRestPause SetTypeEnum = "rest_pause"
+)
+
+func (e *SetTypeEnum) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = SetTypeEnum(s)
+	case string:
+		*e = SetTypeEnum(s)
+	default:
+		return fmt.Errorf("unsupported scan type for SetTypeEnum: %T", src)
+	}
+	return nil
+}
+
+type NullSetTypeEnum struct {
+	SetTypeEnum SetTypeEnum
+	Valid       bool // Valid is true if SetTypeEnum is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullSetTypeEnum) Scan(value interface{}) error {
+	if value == nil {
+		ns.SetTypeEnum, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.SetTypeEnum.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullSetTypeEnum) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.SetTypeEnum), nil
+}
+
+type UnitSystemEnum string
+
+const (
+	UnitSystemEnumMetric   UnitSystemEnum = "metric"
+	UnitSystemEnumImperial UnitSystemEnum = "imperial"
+)
+
+func (e *UnitSystemEnum) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = UnitSystemEnum(s)
+	case string:
+		*e = UnitSystemEnum(s)
+	default:
+		return fmt.Errorf("unsupported scan type for UnitSystemEnum: %T", src)
+	}
+	return nil
+}
+
+type NullUnitSystemEnum struct {
+	UnitSystemEnum UnitSystemEnum
+	Valid          bool // Valid is true if UnitSystemEnum is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullUnitSystemEnum) Scan(value interface{}) error {
+	if value == nil {
+		ns.UnitSystemEnum, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.UnitSystemEnum.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullUnitSystemEnum) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.UnitSystemEnum), nil
+}
+
+type AccountDataExport struct {
+	ExportID    int32
+	UserID      int32
+	Status      AccountExportStatusEnum
+	Archive     []byte
+	SizeBytes   sql.NullInt32
+	Error       sql.NullString
+	RequestedAt time.Time
+	StartedAt   sql.NullTime
+	CompletedAt sql.NullTime
+	ExpiresAt   sql.NullTime
+}
+
+type AccountErasure struct {
+	UserID       int32
+	RequestedAt  time.Time
+	ScheduledFor time.Time
+}
+
 type AppState struct {
 	Key       string
 	Value     sql.NullString
 	CreatedAt sql.NullTime
 }
 
+type BodyMeasurement struct {
+	MeasurementID  int32
+	UserID         int32
+	MeasuredOn     time.Time
+	BodyweightKg   sql.NullString
+	BodyFatPercent sql.NullString
+	NeckCm         sql.NullString
+	ChestCm        sql.NullString
+	WaistCm        sql.NullString
+	HipsCm         sql.NullString
+	ArmCm          sql.NullString
+	ForearmCm      sql.NullString
+	ThighCm        sql.NullString
+	CalfCm         sql.NullString
+	Notes          sql.NullString
+	CreatedAt      sql.NullTime
+	UpdatedAt      sql.NullTime
+}
+
+type CalendarFeedToken struct {
+	UserID    int32
+	Token     string
+	CreatedAt time.Time
+}
+
+type DailyTrainingLoad struct {
+	UserID        int32
+	LoadDate      time.Time
+	Sessions      int32
+	HardSets      int32
+	VolumeKg      string
+	VolumeRpeLoad string
+	SrpeLoad      string
+	UpdatedAt     sql.NullTime
+}
+
 type Exercise struct {
-	ExerciseID   int32
-	ExerciseName string
-	Description  sql.NullString
-	CreatedAt    sql.NullTime
+	ExerciseID       int32
+	ExerciseName     string
+	Description      sql.NullString
+	CreatedAt        sql.NullTime
+	TrackingMode     ExerciseTrackingModeEnum
+	OwnerUserID      sql.NullInt32
+	Equipment        NullEquipmentEnum
+	MovementPattern  NullMovementPatternEnum
+	Laterality       NullLateralityEnum
+	ParentExerciseID sql.NullInt32
+}
+
+type ExerciseAlias struct {
+	AliasID    int32
+	ExerciseID int32
+	Alias      string
 }
 
 type ExerciseMuscle struct {
@@ -117,15 +801,74 @@ type ExerciseMuscle struct {
 
 type ExerciseOneRm struct {
 	UserID       sql.NullInt32
+	ExerciseID   int32
 	ExerciseName string
 	Estimated1rm interface{}
 }
 
-type Muscle struct {
-	MuscleID    int32
-	MuscleName  string
-	MuscleGroup string
+type ExerciseProgressionSetting struct {
+	UserID            int32
+	ExerciseID        int32
+	Strategy          ProgressionStrategyEnum
+	MinReps           int32
+	MaxReps           int32
+	LoadIncrementKg   string
+	TargetRpe         string
+	DeloadAfterMisses int32
+	DeloadPercent     string
+	UpdatedAt         sql.NullTime
+}
+
+type Goal struct {
+	GoalID      int32
+	UserID      int32
+	GoalType    GoalTypeEnum
+	ExerciseID  sql.NullInt32
+	TargetValue string
+	StartValue  sql.NullString
+	StartDate   time.Time
+	Deadline    sql.NullTime
+	Status      GoalStatusEnum
+	CompletedAt sql.NullTime
 	CreatedAt   sql.NullTime
+	UpdatedAt   sql.NullTime
+}
+
+type Muscle struct {
+	MuscleID      int32
+	MuscleName    string
+	CreatedAt     sql.NullTime
+	MuscleGroupID int32
+}
+
+type MuscleDetail struct {
+	MuscleID      int32
+	MuscleName    string
+	MuscleGroupID int32
+	MuscleGroup   string
+	BodyRegion    NullBodyRegionEnum
+	CreatedAt     sql.NullTime
+}
+
+type MuscleGroup struct {
+	MuscleGroupID int32
+	GroupName     string
+	BodyRegion    NullBodyRegionEnum
+	ParentGroupID sql.NullInt32
+	CreatedAt     sql.NullTime
+}
+
+type PersonalRecord struct {
+	RecordID                int32
+	UserID                  int32
+	ExerciseID              int32
+	RecordType              RecordTypeEnum
+	Value                   string
+	WeightKg                sql.NullString
+	WorkoutID               int32
+	OverallWorkoutSetNumber sql.NullInt32
+	AchievedAt              sql.NullTime
+	SupersededAt            sql.NullTime
 }
 
 type User struct {
@@ -137,20 +880,24 @@ type User struct {
 	CreatedAt    sql.NullTime
 	UpdatedAt    sql.NullTime
 	LastLogin    sql.NullTime
+	IsAdmin      bool
 }
 
 type UserProfile struct {
-	ProfileID         int32
-	UserID            sql.NullInt32
-	FirstName         sql.NullString
-	LastName          sql.NullString
-	DateOfBirth       sql.NullTime
-	Gender            sql.NullString
-	HeightInches      sql.NullInt32
-	WeightPounds      sql.NullInt32
-	ProfilePictureUrl sql.NullString
-	CreatedAt         sql.NullTime
-	UpdatedAt         sql.NullTime
+	ProfileID           int32
+	UserID              sql.NullInt32
+	FirstName           sql.NullString
+	LastName            sql.NullString
+	DateOfBirth         sql.NullTime
+	Gender              sql.NullString
+	ProfilePictureUrl   sql.NullString
+	CreatedAt           sql.NullTime
+	UpdatedAt           sql.NullTime
+	UnitPreference      UnitSystemEnum
+	HeightCm            sql.NullString
+	WeightKg            sql.NullString
+	WeeklySessionTarget sql.NullInt32
+	Timezone            sql.NullString
 }
 
 type Workout struct {
@@ -160,6 +907,8 @@ type Workout struct {
 	Title       sql.NullString
 	CreatedAt   sql.NullTime
 	UpdatedAt   sql.NullTime
+	StartedAt   sql.NullTime
+	EndedAt     sql.NullTime
 }
 
 type WorkoutSet struct {
@@ -175,4 +924,25 @@ type WorkoutSet struct {
 	Percent1rm              sql.NullString
 	Notes                   sql.NullString
 	CreatedAt               sql.NullTime
+	CompletedAt             sql.NullTime
+	GroupID                 sql.NullInt32
+	SetType                 SetTypeEnum
+	ParentSetNumber         sql.NullInt32
+	DurationSeconds         sql.NullInt32
+	DistanceMeters          sql.NullString
+	DistanceUnit            NullDistanceUnitEnum
+	Calories                sql.NullInt32
+	PaceSecondsPerKm        sql.NullString
+}
+
+type WorkoutSetGroup struct {
+	GroupID                     int32
+	WorkoutID                   int32
+	GroupType                   SetGroupTypeEnum
+	Rounds                      int32
+	RestBetweenExercisesSeconds sql.NullInt32
+	RestBetweenRoundsSeconds    sql.NullInt32
+	IntervalSeconds             sql.NullInt32
+	Notes                       sql.NullString
+	CreatedAt                   sql.NullTime
 }
@@ -7,27 +7,28 @@ package sqlc
 
 import (
 	"context"
+	"database/sql"
 )
 
 const createMuscle = `-- name: CreateMuscle :one
-INSERT INTO muscles (muscle_name, muscle_group)
+INSERT INTO muscles (muscle_name, muscle_group_id)
 VALUES ($1, $2)
-RETURNING muscle_id, muscle_name, muscle_group, created_at
+RETURNING muscle_id, muscle_name, created_at, muscle_group_id
 `
 
 type CreateMuscleParams struct {
-	MuscleName  string
-	MuscleGroup string
+	MuscleName    string
+	MuscleGroupID int32
 }
 
 func (q *Queries) CreateMuscle(ctx context.Context, arg CreateMuscleParams) (Muscle, error) {
-	row := q.db.QueryRowContext(ctx, createMuscle, arg.MuscleName, arg.MuscleGroup)
+	row := q.db.QueryRowContext(ctx, createMuscle, arg.MuscleName, arg.MuscleGroupID)
 	var i Muscle
 	err := row.Scan(
 		&i.MuscleID,
 		&i.MuscleName,
-		&i.MuscleGroup,
 		&i.CreatedAt,
+		&i.MuscleGroupID,
 	)
 	return i, err
 }
@@ -44,7 +45,7 @@ func (q *Queries) DeleteAllMuscles(ctx context.Context) error {
 const deleteMuscle = `-- name: DeleteMuscle :one
 DELETE FROM muscles
 WHERE muscle_name = $1
-RETURNING muscle_id, muscle_name, muscle_group, created_at
+RETURNING muscle_id, muscle_name, created_at, muscle_group_id
 `
 
 func (q *Queries) DeleteMuscle(ctx context.Context, muscleName string) (Muscle, error) {
@@ -53,26 +54,127 @@ func (q *Queries) DeleteMuscle(ctx context.Context, muscleName string) (Muscle,
 	err := row.Scan(
 		&i.MuscleID,
 		&i.MuscleName,
-		&i.MuscleGroup,
 		&i.CreatedAt,
+		&i.MuscleGroupID,
+	)
+	return i, err
+}
+
+const deleteMuscleByID = `-- name: DeleteMuscleByID :one
+DELETE FROM muscles
+WHERE muscle_id = $1
+RETURNING muscle_id, muscle_name, created_at, muscle_group_id
+`
+
+func (q *Queries) DeleteMuscleByID(ctx context.Context, muscleID int32) (Muscle, error) {
+	row := q.db.QueryRowContext(ctx, deleteMuscleByID, muscleID)
+	var i Muscle
+	err := row.Scan(
+		&i.MuscleID,
+		&i.MuscleName,
+		&i.CreatedAt,
+		&i.MuscleGroupID,
 	)
 	return i, err
 }
 
+const getAllMuscles = `-- name: GetAllMuscles :many
+SELECT muscle_id, muscle_name, muscle_group_id, muscle_group, body_region, created_at FROM muscle_details
+ORDER BY muscle_group, muscle_name
+`
+
+func (q *Queries) GetAllMuscles(ctx context.Context) ([]MuscleDetail, error) {
+	rows, err := q.db.QueryContext(ctx, getAllMuscles)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []MuscleDetail
+	for rows.Next() {
+		var i MuscleDetail
+		if err := rows.Scan(
+			&i.MuscleID,
+			&i.MuscleName,
+			&i.MuscleGroupID,
+			&i.MuscleGroup,
+			&i.BodyRegion,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getMuscle = `-- name: GetMuscle :one
-SELECT muscle_name, muscle_group
-FROM muscles
+SELECT muscle_id, muscle_name, muscle_group_id, muscle_group, body_region, created_at FROM muscle_details
 WHERE muscle_name = $1
 `
 
-type GetMuscleRow struct {
-	MuscleName  string
-	MuscleGroup string
+func (q *Queries) GetMuscle(ctx context.Context, muscleName string) (MuscleDetail, error) {
+	row := q.db.QueryRowContext(ctx, getMuscle, muscleName)
+	var i MuscleDetail
+	err := row.Scan(
+		&i.MuscleID,
+		&i.MuscleName,
+		&i.MuscleGroupID,
+		&i.MuscleGroup,
+		&i.BodyRegion,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getMuscleByID = `-- name: GetMuscleByID :one
+SELECT muscle_id, muscle_name, muscle_group_id, muscle_group, body_region, created_at FROM muscle_details
+WHERE muscle_id = $1
+`
+
+func (q *Queries) GetMuscleByID(ctx context.Context, muscleID int32) (MuscleDetail, error) {
+	row := q.db.QueryRowContext(ctx, getMuscleByID, muscleID)
+	var i MuscleDetail
+	err := row.Scan(
+		&i.MuscleID,
+		&i.MuscleName,
+		&i.MuscleGroupID,
+		&i.MuscleGroup,
+		&i.BodyRegion,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const updateMuscle = `-- name: UpdateMuscle :one
+UPDATE muscles
+SET
+  muscle_name = COALESCE($1, muscle_name),
+  muscle_group_id = COALESCE($2, muscle_group_id)
+WHERE muscle_id = $3
+RETURNING muscle_id, muscle_name, created_at, muscle_group_id
+`
+
+type UpdateMuscleParams struct {
+	MuscleName    sql.NullString
+	MuscleGroupID sql.NullInt32
+	MuscleID      int32
 }
 
-func (q *Queries) GetMuscle(ctx context.Context, muscleName string) (GetMuscleRow, error) {
-	row := q.db.QueryRowContext(ctx, getMuscle, muscleName)
-	var i GetMuscleRow
-	err := row.Scan(&i.MuscleName, &i.MuscleGroup)
+// Fields left NULL keep their current value
+func (q *Queries) UpdateMuscle(ctx context.Context, arg UpdateMuscleParams) (Muscle, error) {
+	row := q.db.QueryRowContext(ctx, updateMuscle, arg.MuscleName, arg.MuscleGroupID, arg.MuscleID)
+	var i Muscle
+	err := row.Scan(
+		&i.MuscleID,
+		&i.MuscleName,
+		&i.CreatedAt,
+		&i.MuscleGroupID,
+	)
 	return i, err
 }
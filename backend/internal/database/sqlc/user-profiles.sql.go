@@ -11,21 +11,25 @@ import (
 )
 
 const createUserProfile = `-- name: CreateUserProfile :one
-INSERT INTO user_profiles (user_id, first_name, last_name, date_of_birth, gender, height_inches, weight_pounds)
-VALUES ($1, $2, $3, $4, $5, $6, $7)
-RETURNING profile_id, user_id, first_name, last_name, date_of_birth, gender, height_inches, weight_pounds, profile_picture_url, created_at, updated_at
+INSERT INTO user_profiles (user_id, first_name, last_name, date_of_birth, gender, height_cm, weight_kg, unit_preference, weekly_session_target, timezone)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+RETURNING profile_id, user_id, first_name, last_name, date_of_birth, gender, profile_picture_url, created_at, updated_at, unit_preference, height_cm, weight_kg, weekly_session_target, timezone
 `
 
 type CreateUserProfileParams struct {
-	UserID       sql.NullInt32
-	FirstName    sql.NullString
-	LastName     sql.NullString
-	DateOfBirth  sql.NullTime
-	Gender       sql.NullString
-	HeightInches sql.NullInt32
-	WeightPounds sql.NullInt32
+	UserID              sql.NullInt32
+	FirstName           sql.NullString
+	LastName            sql.NullString
+	DateOfBirth         sql.NullTime
+	Gender              sql.NullString
+	HeightCm            sql.NullString
+	WeightKg            sql.NullString
+	UnitPreference      UnitSystemEnum
+	WeeklySessionTarget sql.NullInt32
+	Timezone            sql.NullString
 }
 
+// Height & weight are always cm & kg - handlers convert from the user's preferred units first
 func (q *Queries) CreateUserProfile(ctx context.Context, arg CreateUserProfileParams) (UserProfile, error) {
 	row := q.db.QueryRowContext(ctx, createUserProfile,
 		arg.UserID,
@@ -33,8 +37,11 @@ func (q *Queries) CreateUserProfile(ctx context.Context, arg CreateUserProfilePa
 		arg.LastName,
 		arg.DateOfBirth,
 		arg.Gender,
-		arg.HeightInches,
-		arg.WeightPounds,
+		arg.HeightCm,
+		arg.WeightKg,
+		arg.UnitPreference,
+		arg.WeeklySessionTarget,
+		arg.Timezone,
 	)
 	var i UserProfile
 	err := row.Scan(
@@ -44,11 +51,14 @@ func (q *Queries) CreateUserProfile(ctx context.Context, arg CreateUserProfilePa
 		&i.LastName,
 		&i.DateOfBirth,
 		&i.Gender,
-		&i.HeightInches,
-		&i.WeightPounds,
 		&i.ProfilePictureUrl,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.UnitPreference,
+		&i.HeightCm,
+		&i.WeightKg,
+		&i.WeeklySessionTarget,
+		&i.Timezone,
 	)
 	return i, err
 }
@@ -65,7 +75,7 @@ func (q *Queries) DeleteAllUserProfiles(ctx context.Context) error {
 const deleteUserProfile = `-- name: DeleteUserProfile :one
 DELETE FROM user_profiles
 WHERE user_id = $1
-RETURNING profile_id, user_id, first_name, last_name, date_of_birth, gender, height_inches, weight_pounds, profile_picture_url, created_at, updated_at
+RETURNING profile_id, user_id, first_name, last_name, date_of_birth, gender, profile_picture_url, created_at, updated_at, unit_preference, height_cm, weight_kg, weekly_session_target, timezone
 `
 
 func (q *Queries) DeleteUserProfile(ctx context.Context, userID sql.NullInt32) (UserProfile, error) {
@@ -78,11 +88,14 @@ func (q *Queries) DeleteUserProfile(ctx context.Context, userID sql.NullInt32) (
 		&i.LastName,
 		&i.DateOfBirth,
 		&i.Gender,
-		&i.HeightInches,
-		&i.WeightPounds,
 		&i.ProfilePictureUrl,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.UnitPreference,
+		&i.HeightCm,
+		&i.WeightKg,
+		&i.WeeklySessionTarget,
+		&i.Timezone,
 	)
 	return i, err
 }
@@ -95,11 +108,14 @@ SELECT
   ups.last_name,
   ups.date_of_birth,
   ups.gender,
-  ups.height_inches,
-  ups.weight_pounds,
   ups.profile_picture_url,
   ups.created_at,
   ups.updated_at,
+  ups.unit_preference,
+  ups.height_cm,
+  ups.weight_kg,
+  ups.weekly_session_target,
+  ups.timezone,
   u.active
 FROM user_profiles ups
 JOIN users u ON ups.user_id = u.user_id
@@ -107,18 +123,21 @@ WHERE u.active = true
 `
 
 type GetAllActiveUserProfilesRow struct {
-	ProfileID         int32
-	UserID            sql.NullInt32
-	FirstName         sql.NullString
-	LastName          sql.NullString
-	DateOfBirth       sql.NullTime
-	Gender            sql.NullString
-	HeightInches      sql.NullInt32
-	WeightPounds      sql.NullInt32
-	ProfilePictureUrl sql.NullString
-	CreatedAt         sql.NullTime
-	UpdatedAt         sql.NullTime
-	Active            sql.NullBool
+	ProfileID           int32
+	UserID              sql.NullInt32
+	FirstName           sql.NullString
+	LastName            sql.NullString
+	DateOfBirth         sql.NullTime
+	Gender              sql.NullString
+	ProfilePictureUrl   sql.NullString
+	CreatedAt           sql.NullTime
+	UpdatedAt           sql.NullTime
+	UnitPreference      UnitSystemEnum
+	HeightCm            sql.NullString
+	WeightKg            sql.NullString
+	WeeklySessionTarget sql.NullInt32
+	Timezone            sql.NullString
+	Active              sql.NullBool
 }
 
 // Weird SQLc error with using user_profiles.* here - generated empty select statement. Explicitly naming columns to select instead.
@@ -138,11 +157,14 @@ func (q *Queries) GetAllActiveUserProfiles(ctx context.Context) ([]GetAllActiveU
 			&i.LastName,
 			&i.DateOfBirth,
 			&i.Gender,
-			&i.HeightInches,
-			&i.WeightPounds,
 			&i.ProfilePictureUrl,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.UnitPreference,
+			&i.HeightCm,
+			&i.WeightKg,
+			&i.WeeklySessionTarget,
+			&i.Timezone,
 			&i.Active,
 		); err != nil {
 			return nil, err
@@ -166,11 +188,14 @@ SELECT
   ups.last_name,
   ups.date_of_birth,
   ups.gender,
-  ups.height_inches,
-  ups.weight_pounds,
   ups.profile_picture_url,
   ups.created_at,
   ups.updated_at,
+  ups.unit_preference,
+  ups.height_cm,
+  ups.weight_kg,
+  ups.weekly_session_target,
+  ups.timezone,
   u.active
 FROM user_profiles ups
 JOIN users u ON ups.user_id = u.user_id
@@ -178,18 +203,21 @@ WHERE u.active = false
 `
 
 type GetAllInactiveUserProfilesRow struct {
-	ProfileID         int32
-	UserID            sql.NullInt32
-	FirstName         sql.NullString
-	LastName          sql.NullString
-	DateOfBirth       sql.NullTime
-	Gender            sql.NullString
-	HeightInches      sql.NullInt32
-	WeightPounds      sql.NullInt32
-	ProfilePictureUrl sql.NullString
-	CreatedAt         sql.NullTime
-	UpdatedAt         sql.NullTime
-	Active            sql.NullBool
+	ProfileID           int32
+	UserID              sql.NullInt32
+	FirstName           sql.NullString
+	LastName            sql.NullString
+	DateOfBirth         sql.NullTime
+	Gender              sql.NullString
+	ProfilePictureUrl   sql.NullString
+	CreatedAt           sql.NullTime
+	UpdatedAt           sql.NullTime
+	UnitPreference      UnitSystemEnum
+	HeightCm            sql.NullString
+	WeightKg            sql.NullString
+	WeeklySessionTarget sql.NullInt32
+	Timezone            sql.NullString
+	Active              sql.NullBool
 }
 
 // Same thing here - explicitly naming columns to return.
@@ -209,11 +237,14 @@ func (q *Queries) GetAllInactiveUserProfiles(ctx context.Context) ([]GetAllInact
 			&i.LastName,
 			&i.DateOfBirth,
 			&i.Gender,
-			&i.HeightInches,
-			&i.WeightPounds,
 			&i.ProfilePictureUrl,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.UnitPreference,
+			&i.HeightCm,
+			&i.WeightKg,
+			&i.WeeklySessionTarget,
+			&i.Timezone,
 			&i.Active,
 		); err != nil {
 			return nil, err
@@ -230,7 +261,7 @@ func (q *Queries) GetAllInactiveUserProfiles(ctx context.Context) ([]GetAllInact
 }
 
 const getAllUserProfiles = `-- name: GetAllUserProfiles :many
-SELECT user_profiles.profile_id, user_profiles.user_id, user_profiles.first_name, user_profiles.last_name, user_profiles.date_of_birth, user_profiles.gender, user_profiles.height_inches, user_profiles.weight_pounds, user_profiles.profile_picture_url, user_profiles.created_at, user_profiles.updated_at
+SELECT user_profiles.profile_id, user_profiles.user_id, user_profiles.first_name, user_profiles.last_name, user_profiles.date_of_birth, user_profiles.gender, user_profiles.profile_picture_url, user_profiles.created_at, user_profiles.updated_at, user_profiles.unit_preference, user_profiles.height_cm, user_profiles.weight_kg, user_profiles.weekly_session_target, user_profiles.timezone
 FROM user_profiles
 `
 
@@ -250,11 +281,14 @@ func (q *Queries) GetAllUserProfiles(ctx context.Context) ([]UserProfile, error)
 			&i.LastName,
 			&i.DateOfBirth,
 			&i.Gender,
-			&i.HeightInches,
-			&i.WeightPounds,
 			&i.ProfilePictureUrl,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.UnitPreference,
+			&i.HeightCm,
+			&i.WeightKg,
+			&i.WeeklySessionTarget,
+			&i.Timezone,
 		); err != nil {
 			return nil, err
 		}
@@ -270,7 +304,7 @@ func (q *Queries) GetAllUserProfiles(ctx context.Context) ([]UserProfile, error)
 }
 
 const getUserProfile = `-- name: GetUserProfile :one
-SELECT user_profiles.profile_id, user_profiles.user_id, user_profiles.first_name, user_profiles.last_name, user_profiles.date_of_birth, user_profiles.gender, user_profiles.height_inches, user_profiles.weight_pounds, user_profiles.profile_picture_url, user_profiles.created_at, user_profiles.updated_at
+SELECT user_profiles.profile_id, user_profiles.user_id, user_profiles.first_name, user_profiles.last_name, user_profiles.date_of_birth, user_profiles.gender, user_profiles.profile_picture_url, user_profiles.created_at, user_profiles.updated_at, user_profiles.unit_preference, user_profiles.height_cm, user_profiles.weight_kg, user_profiles.weekly_session_target, user_profiles.timezone
 FROM user_profiles
 WHERE user_profiles.user_id = $1
 `
@@ -285,11 +319,14 @@ func (q *Queries) GetUserProfile(ctx context.Context, userID sql.NullInt32) (Use
 		&i.LastName,
 		&i.DateOfBirth,
 		&i.Gender,
-		&i.HeightInches,
-		&i.WeightPounds,
 		&i.ProfilePictureUrl,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.UnitPreference,
+		&i.HeightCm,
+		&i.WeightKg,
+		&i.WeeklySessionTarget,
+		&i.Timezone,
 	)
 	return i, err
 }
@@ -297,36 +334,49 @@ func (q *Queries) GetUserProfile(ctx context.Context, userID sql.NullInt32) (Use
 const updateUserProfile = `-- name: UpdateUserProfile :one
 UPDATE user_profiles
 SET 
-  first_name = $2, 
-  last_name = $3, 
-  date_of_birth = $4,
-  gender = $5,
-  height_inches = $6,
-  weight_pounds = $7,
+  first_name = $1, 
+  last_name = $2, 
+  date_of_birth = $3,
+  gender = $4,
+  height_cm = $5,
+  weight_kg = $6,
+  unit_preference = COALESCE($7::unit_system_enum, unit_preference),
+  weekly_session_target = CASE
+    WHEN $8::int = 0 THEN NULL
+    ELSE COALESCE($8, weekly_session_target)
+  END,
+  timezone = COALESCE($9, timezone),
   updated_at = CURRENT_TIMESTAMP
-WHERE user_id = $1
-RETURNING profile_id, user_id, first_name, last_name, date_of_birth, gender, height_inches, weight_pounds, profile_picture_url, created_at, updated_at
+WHERE user_id = $10
+RETURNING profile_id, user_id, first_name, last_name, date_of_birth, gender, profile_picture_url, created_at, updated_at, unit_preference, height_cm, weight_kg, weekly_session_target, timezone
 `
 
 type UpdateUserProfileParams struct {
-	UserID       sql.NullInt32
-	FirstName    sql.NullString
-	LastName     sql.NullString
-	DateOfBirth  sql.NullTime
-	Gender       sql.NullString
-	HeightInches sql.NullInt32
-	WeightPounds sql.NullInt32
+	FirstName           sql.NullString
+	LastName            sql.NullString
+	DateOfBirth         sql.NullTime
+	Gender              sql.NullString
+	HeightCm            sql.NullString
+	WeightKg            sql.NullString
+	UnitPreference      NullUnitSystemEnum
+	WeeklySessionTarget sql.NullInt32
+	Timezone            sql.NullString
+	UserID              sql.NullInt32
 }
 
+// weekly_session_target is left alone when NULL & cleared by 0; timezone is left alone when NULL
 func (q *Queries) UpdateUserProfile(ctx context.Context, arg UpdateUserProfileParams) (UserProfile, error) {
 	row := q.db.QueryRowContext(ctx, updateUserProfile,
-		arg.UserID,
 		arg.FirstName,
 		arg.LastName,
 		arg.DateOfBirth,
 		arg.Gender,
-		arg.HeightInches,
-		arg.WeightPounds,
+		arg.HeightCm,
+		arg.WeightKg,
+		arg.UnitPreference,
+		arg.WeeklySessionTarget,
+		arg.Timezone,
+		arg.UserID,
 	)
 	var i UserProfile
 	err := row.Scan(
@@ -336,11 +386,14 @@ func (q *Queries) UpdateUserProfile(ctx context.Context, arg UpdateUserProfilePa
 		&i.LastName,
 		&i.DateOfBirth,
 		&i.Gender,
-		&i.HeightInches,
-		&i.WeightPounds,
 		&i.ProfilePictureUrl,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.UnitPreference,
+		&i.HeightCm,
+		&i.WeightKg,
+		&i.WeeklySessionTarget,
+		&i.Timezone,
 	)
 	return i, err
 }
@@ -12,7 +12,7 @@ import (
 const createUser = `-- name: CreateUser :one
 INSERT INTO users (email, password_hash, username)
 VALUES ($1, $2, $3)
-RETURNING user_id, email, password_hash, username, active, created_at, updated_at, last_login
+RETURNING user_id, email, password_hash, username, active, created_at, updated_at, last_login, is_admin
 `
 
 type CreateUserParams struct {
@@ -33,6 +33,7 @@ func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) (User, e
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.LastLogin,
+		&i.IsAdmin,
 	)
 	return i, err
 }
@@ -50,7 +51,7 @@ const deleteUser = `-- name: DeleteUser :one
 UPDATE users
 SET active = false, updated_at = CURRENT_TIMESTAMP
 WHERE user_id = $1
-RETURNING user_id, email, password_hash, username, active, created_at, updated_at, last_login
+RETURNING user_id, email, password_hash, username, active, created_at, updated_at, last_login, is_admin
 `
 
 // Soft delete only - too many headaches if this gets actual users.
@@ -66,12 +67,13 @@ func (q *Queries) DeleteUser(ctx context.Context, userID int32) (User, error) {
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.LastLogin,
+		&i.IsAdmin,
 	)
 	return i, err
 }
 
 const getAllUsers = `-- name: GetAllUsers :many
-SELECT user_id, email, password_hash, username, active, created_at, updated_at, last_login
+SELECT user_id, email, password_hash, username, active, created_at, updated_at, last_login, is_admin
 FROM users
 `
 
@@ -93,6 +95,7 @@ func (q *Queries) GetAllUsers(ctx context.Context) ([]User, error) {
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.LastLogin,
+			&i.IsAdmin,
 		); err != nil {
 			return nil, err
 		}
@@ -109,7 +112,7 @@ func (q *Queries) GetAllUsers(ctx context.Context) ([]User, error) {
 
 const getUser = `-- name: GetUser :one
 
-SELECT users.user_id, users.email, users.password_hash, users.username, users.active, users.created_at, users.updated_at, users.last_login
+SELECT users.user_id, users.email, users.password_hash, users.username, users.active, users.created_at, users.updated_at, users.last_login, users.is_admin
 FROM users
 WHERE users.user_id = $1
 `
@@ -133,12 +136,13 @@ func (q *Queries) GetUser(ctx context.Context, userID int32) (User, error) {
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.LastLogin,
+		&i.IsAdmin,
 	)
 	return i, err
 }
 
 const getUserByEmail = `-- name: GetUserByEmail :one
-SELECT user_id, email, password_hash, username, active, created_at, updated_at, last_login FROM users 
+SELECT user_id, email, password_hash, username, active, created_at, updated_at, last_login, is_admin FROM users 
 WHERE email = $1 AND active = true
 LIMIT 1
 `
@@ -155,10 +159,23 @@ func (q *Queries) GetUserByEmail(ctx context.Context, email string) (User, error
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.LastLogin,
+		&i.IsAdmin,
 	)
 	return i, err
 }
 
+const getUserIsAdmin = `-- name: GetUserIsAdmin :one
+SELECT is_admin FROM users
+WHERE user_id = $1
+`
+
+func (q *Queries) GetUserIsAdmin(ctx context.Context, userID int32) (bool, error) {
+	row := q.db.QueryRowContext(ctx, getUserIsAdmin, userID)
+	var is_admin bool
+	err := row.Scan(&is_admin)
+	return is_admin, err
+}
+
 const updateLastLogin = `-- name: UpdateLastLogin :exec
 UPDATE users 
 SET last_login = CURRENT_TIMESTAMP
@@ -178,7 +195,7 @@ SET
   username = COALESCE($4, username),
   updated_at = CURRENT_TIMESTAMP
 WHERE user_id = $1
-RETURNING user_id, email, password_hash, username, active, created_at, updated_at, last_login
+RETURNING user_id, email, password_hash, username, active, created_at, updated_at, last_login, is_admin
 `
 
 type UpdateUserParams struct {
@@ -205,6 +222,7 @@ func (q *Queries) UpdateUser(ctx context.Context, arg UpdateUserParams) (User, e
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.LastLogin,
+		&i.IsAdmin,
 	)
 	return i, err
 }
@@ -8,10 +8,171 @@ package sqlc
 import (
 	"context"
 	"database/sql"
+	"time"
 
 	"github.com/lib/pq"
 )
 
+const completeWorkoutSet = `-- name: CompleteWorkoutSet :one
+UPDATE workout_sets
+SET completed_at = COALESCE(completed_at, CURRENT_TIMESTAMP)
+WHERE workout_id = $1
+AND overall_workout_set_number = $2
+RETURNING workout_id, exercise_id, set_number, overall_workout_set_number, reps, resistance_value, resistance_type, resistance_detail, rpe, percent_1rm, notes, created_at, completed_at, group_id, set_type, parent_set_number, duration_seconds, distance_meters, distance_unit, calories, pace_seconds_per_km
+`
+
+type CompleteWorkoutSetParams struct {
+	WorkoutID               int32
+	OverallWorkoutSetNumber int32
+}
+
+// Live session mode; re-completing a set keeps its original timestamp so rest times don't shift
+func (q *Queries) CompleteWorkoutSet(ctx context.Context, arg CompleteWorkoutSetParams) (WorkoutSet, error) {
+	row := q.db.QueryRowContext(ctx, completeWorkoutSet, arg.WorkoutID, arg.OverallWorkoutSetNumber)
+	var i WorkoutSet
+	err := row.Scan(
+		&i.WorkoutID,
+		&i.ExerciseID,
+		&i.SetNumber,
+		&i.OverallWorkoutSetNumber,
+		&i.Reps,
+		&i.ResistanceValue,
+		&i.ResistanceType,
+		&i.ResistanceDetail,
+		&i.Rpe,
+		&i.Percent1rm,
+		&i.Notes,
+		&i.CreatedAt,
+		&i.CompletedAt,
+		&i.GroupID,
+		&i.SetType,
+		&i.ParentSetNumber,
+		&i.DurationSeconds,
+		&i.DistanceMeters,
+		&i.DistanceUnit,
+		&i.Calories,
+		&i.PaceSecondsPerKm,
+	)
+	return i, err
+}
+
+const createGroupedWorkoutSets = `-- name: CreateGroupedWorkoutSets :many
+WITH input_rows AS (
+  SELECT 
+    $1::int as workout_id,
+    $2::int as group_id,
+    unnest($3::int[]) exercise_id,
+    unnest($4::int[]) set_number,
+    unnest($5::int[]) reps,
+    NULLIF(unnest($6::text[]), '')::decimal resistance_value,
+    NULLIF(unnest($7::text[]), '')::resistance_type_enum resistance_type,
+    unnest($8::text[]) resistance_detail,
+    NULLIF(unnest($9::text[]), '')::decimal rpe,
+    unnest($10::text[]) notes,
+    NULLIF(unnest($11::int[]), 0) duration_seconds,
+    NULLIF(unnest($12::text[]), '')::decimal distance_meters,
+    NULLIF(unnest($13::text[]), '')::distance_unit_enum distance_unit,
+    NULLIF(unnest($14::int[]), 0) calories,
+    COALESCE(NULLIF(unnest($15::text[]), ''), 'working')::set_type_enum set_type
+)
+INSERT INTO workout_sets 
+(workout_id, group_id, exercise_id, set_number, reps, resistance_value, resistance_type, resistance_detail, rpe, notes, duration_seconds, distance_meters, distance_unit, calories, set_type)
+SELECT
+  i.workout_id,
+  i.group_id,
+  i.exercise_id,
+  i.set_number + COALESCE((
+    SELECT MAX(ws.set_number) FROM workout_sets ws
+    WHERE ws.workout_id = i.workout_id AND ws.exercise_id = i.exercise_id
+  ), 0),
+  i.reps, i.resistance_value, i.resistance_type, i.resistance_detail, i.rpe, i.notes,
+  i.duration_seconds, i.distance_meters, i.distance_unit, i.calories, i.set_type
+FROM input_rows i
+RETURNING workout_id, exercise_id, set_number, overall_workout_set_number, reps, resistance_value, resistance_type, resistance_detail, rpe, percent_1rm, notes, created_at, completed_at, group_id, set_type, parent_set_number, duration_seconds, distance_meters, distance_unit, calories, pace_seconds_per_km
+`
+
+type CreateGroupedWorkoutSetsParams struct {
+	Column1  int32
+	Column2  int32
+	Column3  []int32
+	Column4  []int32
+	Column5  []int32
+	Column6  []string
+	Column7  []string
+	Column8  []string
+	Column9  []string
+	Column10 []string
+	Column11 []int32
+	Column12 []string
+	Column13 []string
+	Column14 []int32
+	Column15 []string
+}
+
+// Same as CreateWorkoutSets, but every row carries its own exercise_id so the rounds of a superset/circuit can interleave (A1, B1, A2, B2, ...)
+// set_type falls back to 'working'; grouped sets can't be drop sets since there's no parent set to point at
+// set_number is the round; it carries on from the exercise's sets already in the workout rather than restarting at 1
+func (q *Queries) CreateGroupedWorkoutSets(ctx context.Context, arg CreateGroupedWorkoutSetsParams) ([]WorkoutSet, error) {
+	rows, err := q.db.QueryContext(ctx, createGroupedWorkoutSets,
+		arg.Column1,
+		arg.Column2,
+		pq.Array(arg.Column3),
+		pq.Array(arg.Column4),
+		pq.Array(arg.Column5),
+		pq.Array(arg.Column6),
+		pq.Array(arg.Column7),
+		pq.Array(arg.Column8),
+		pq.Array(arg.Column9),
+		pq.Array(arg.Column10),
+		pq.Array(arg.Column11),
+		pq.Array(arg.Column12),
+		pq.Array(arg.Column13),
+		pq.Array(arg.Column14),
+		pq.Array(arg.Column15),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []WorkoutSet
+	for rows.Next() {
+		var i WorkoutSet
+		if err := rows.Scan(
+			&i.WorkoutID,
+			&i.ExerciseID,
+			&i.SetNumber,
+			&i.OverallWorkoutSetNumber,
+			&i.Reps,
+			&i.ResistanceValue,
+			&i.ResistanceType,
+			&i.ResistanceDetail,
+			&i.Rpe,
+			&i.Percent1rm,
+			&i.Notes,
+			&i.CreatedAt,
+			&i.CompletedAt,
+			&i.GroupID,
+			&i.SetType,
+			&i.ParentSetNumber,
+			&i.DurationSeconds,
+			&i.DistanceMeters,
+			&i.DistanceUnit,
+			&i.Calories,
+			&i.PaceSecondsPerKm,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const createWorkoutSets = `-- name: CreateWorkoutSets :many
 WITH input_rows AS (
   SELECT 
@@ -23,28 +184,42 @@ WITH input_rows AS (
     NULLIF(unnest($6::text[]), '')::resistance_type_enum resistance_type,
     unnest($7::text[]) resistance_detail,
     NULLIF(unnest($8::text[]), '')::decimal rpe,
-    unnest($9::text[]) notes
+    unnest($9::text[]) notes,
+    COALESCE(NULLIF(unnest($10::text[]), ''), 'working')::set_type_enum set_type,
+    NULLIF(unnest($11::int[]), 0) parent_set_number,
+    NULLIF(unnest($12::int[]), 0) duration_seconds,
+    NULLIF(unnest($13::text[]), '')::decimal distance_meters,
+    NULLIF(unnest($14::text[]), '')::distance_unit_enum distance_unit,
+    NULLIF(unnest($15::int[]), 0) calories
 )
 INSERT INTO workout_sets 
-(workout_id, exercise_id, set_number, reps, resistance_value, resistance_type, resistance_detail, rpe, notes)
-SELECT workout_id, exercise_id, set_number, reps, resistance_value, resistance_type, resistance_detail, rpe, notes FROM input_rows
-RETURNING workout_id, exercise_id, set_number, overall_workout_set_number, reps, resistance_value, resistance_type, resistance_detail, rpe, percent_1rm, notes, created_at
+(workout_id, exercise_id, set_number, reps, resistance_value, resistance_type, resistance_detail, rpe, notes, set_type, parent_set_number, duration_seconds, distance_meters, distance_unit, calories)
+SELECT workout_id, exercise_id, set_number, reps, resistance_value, resistance_type, resistance_detail, rpe, notes, set_type, parent_set_number, duration_seconds, distance_meters, distance_unit, calories FROM input_rows
+RETURNING workout_id, exercise_id, set_number, overall_workout_set_number, reps, resistance_value, resistance_type, resistance_detail, rpe, percent_1rm, notes, created_at, completed_at, group_id, set_type, parent_set_number, duration_seconds, distance_meters, distance_unit, calories, pace_seconds_per_km
 `
 
 type CreateWorkoutSetsParams struct {
-	Column1 int32
-	Column2 int32
-	Column3 []int32
-	Column4 []int32
-	Column5 []string
-	Column6 []string
-	Column7 []string
-	Column8 []string
-	Column9 []string
+	Column1  int32
+	Column2  int32
+	Column3  []int32
+	Column4  []int32
+	Column5  []string
+	Column6  []string
+	Column7  []string
+	Column8  []string
+	Column9  []string
+	Column10 []string
+	Column11 []int32
+	Column12 []int32
+	Column13 []string
+	Column14 []string
+	Column15 []int32
 }
 
 // RE: the "NULLIF" lines:
 // they accept text arrays, convert empty strings to NULL, then cast non-NULL values to decimal
+// set_type falls back to 'working' and a parent_set_number of 0 means "no parent"
+// a duration or calorie count of 0 means "not logged"
 func (q *Queries) CreateWorkoutSets(ctx context.Context, arg CreateWorkoutSetsParams) ([]WorkoutSet, error) {
 	rows, err := q.db.QueryContext(ctx, createWorkoutSets,
 		arg.Column1,
@@ -56,6 +231,12 @@ func (q *Queries) CreateWorkoutSets(ctx context.Context, arg CreateWorkoutSetsPa
 		pq.Array(arg.Column7),
 		pq.Array(arg.Column8),
 		pq.Array(arg.Column9),
+		pq.Array(arg.Column10),
+		pq.Array(arg.Column11),
+		pq.Array(arg.Column12),
+		pq.Array(arg.Column13),
+		pq.Array(arg.Column14),
+		pq.Array(arg.Column15),
 	)
 	if err != nil {
 		return nil, err
@@ -77,6 +258,15 @@ func (q *Queries) CreateWorkoutSets(ctx context.Context, arg CreateWorkoutSetsPa
 			&i.Percent1rm,
 			&i.Notes,
 			&i.CreatedAt,
+			&i.CompletedAt,
+			&i.GroupID,
+			&i.SetType,
+			&i.ParentSetNumber,
+			&i.DurationSeconds,
+			&i.DistanceMeters,
+			&i.DistanceUnit,
+			&i.Calories,
+			&i.PaceSecondsPerKm,
 		); err != nil {
 			return nil, err
 		}
@@ -115,7 +305,7 @@ const deleteWorkoutSetByID = `-- name: DeleteWorkoutSetByID :one
 DELETE FROM workout_sets 
 WHERE workout_id = $1 
 AND overall_workout_set_number = $2
-RETURNING workout_id, exercise_id, set_number, overall_workout_set_number, reps, resistance_value, resistance_type, resistance_detail, rpe, percent_1rm, notes, created_at
+RETURNING workout_id, exercise_id, set_number, overall_workout_set_number, reps, resistance_value, resistance_type, resistance_detail, rpe, percent_1rm, notes, created_at, completed_at, group_id, set_type, parent_set_number, duration_seconds, distance_meters, distance_unit, calories, pace_seconds_per_km
 `
 
 type DeleteWorkoutSetByIDParams struct {
@@ -139,6 +329,15 @@ func (q *Queries) DeleteWorkoutSetByID(ctx context.Context, arg DeleteWorkoutSet
 		&i.Percent1rm,
 		&i.Notes,
 		&i.CreatedAt,
+		&i.CompletedAt,
+		&i.GroupID,
+		&i.SetType,
+		&i.ParentSetNumber,
+		&i.DurationSeconds,
+		&i.DistanceMeters,
+		&i.DistanceUnit,
+		&i.Calories,
+		&i.PaceSecondsPerKm,
 	)
 	return i, err
 }
@@ -161,7 +360,7 @@ func (q *Queries) DeleteWorkoutSetsByExercise(ctx context.Context, arg DeleteWor
 
 const getAllWorkoutSets = `-- name: GetAllWorkoutSets :many
 SELECT 
-  ws.workout_id, ws.exercise_id, ws.set_number, ws.overall_workout_set_number, ws.reps, ws.resistance_value, ws.resistance_type, ws.resistance_detail, ws.rpe, ws.percent_1rm, ws.notes, ws.created_at,
+  ws.workout_id, ws.exercise_id, ws.set_number, ws.overall_workout_set_number, ws.reps, ws.resistance_value, ws.resistance_type, ws.resistance_detail, ws.rpe, ws.percent_1rm, ws.notes, ws.created_at, ws.completed_at, ws.group_id, ws.set_type, ws.parent_set_number, ws.duration_seconds, ws.distance_meters, ws.distance_unit, ws.calories, ws.pace_seconds_per_km,
   e.exercise_name
 FROM workout_sets ws
 JOIN exercises e ON ws.exercise_id = e.exercise_id
@@ -182,6 +381,15 @@ type GetAllWorkoutSetsRow struct {
 	Percent1rm              sql.NullString
 	Notes                   sql.NullString
 	CreatedAt               sql.NullTime
+	CompletedAt             sql.NullTime
+	GroupID                 sql.NullInt32
+	SetType                 SetTypeEnum
+	ParentSetNumber         sql.NullInt32
+	DurationSeconds         sql.NullInt32
+	DistanceMeters          sql.NullString
+	DistanceUnit            NullDistanceUnitEnum
+	Calories                sql.NullInt32
+	PaceSecondsPerKm        sql.NullString
 	ExerciseName            string
 }
 
@@ -207,6 +415,15 @@ func (q *Queries) GetAllWorkoutSets(ctx context.Context, workoutID int32) ([]Get
 			&i.Percent1rm,
 			&i.Notes,
 			&i.CreatedAt,
+			&i.CompletedAt,
+			&i.GroupID,
+			&i.SetType,
+			&i.ParentSetNumber,
+			&i.DurationSeconds,
+			&i.DistanceMeters,
+			&i.DistanceUnit,
+			&i.Calories,
+			&i.PaceSecondsPerKm,
 			&i.ExerciseName,
 		); err != nil {
 			return nil, err
@@ -222,42 +439,219 @@ func (q *Queries) GetAllWorkoutSets(ctx context.Context, workoutID int32) ([]Get
 	return items, nil
 }
 
+const getExerciseTrackingModeForSet = `-- name: GetExerciseTrackingModeForSet :one
+SELECT e.tracking_mode
+FROM workout_sets ws
+JOIN exercises e ON ws.exercise_id = e.exercise_id
+WHERE ws.workout_id = $1
+AND ws.overall_workout_set_number = $2
+`
+
+type GetExerciseTrackingModeForSetParams struct {
+	WorkoutID               int32
+	OverallWorkoutSetNumber int32
+}
+
+// Used to validate a set update against its exercise's tracking mode
+func (q *Queries) GetExerciseTrackingModeForSet(ctx context.Context, arg GetExerciseTrackingModeForSetParams) (ExerciseTrackingModeEnum, error) {
+	row := q.db.QueryRowContext(ctx, getExerciseTrackingModeForSet, arg.WorkoutID, arg.OverallWorkoutSetNumber)
+	var tracking_mode ExerciseTrackingModeEnum
+	err := row.Scan(&tracking_mode)
+	return tracking_mode, err
+}
+
+const getOneRepMaxSetsForExercise = `-- name: GetOneRepMaxSetsForExercise :many
+SELECT
+  w.workout_id,
+  w.workout_date,
+  ws.overall_workout_set_number,
+  ws.resistance_value,
+  ws.reps,
+  ws.rpe
+FROM workout_sets ws
+JOIN workouts w ON ws.workout_id = w.workout_id
+WHERE w.user_id = $1
+AND ws.exercise_id = $2
+AND ws.resistance_type = 'weight'
+AND ws.resistance_value > 0
+AND ws.reps > 0
+AND ws.reps <= $3::int
+AND (ws.set_type <> 'warmup' OR $4::boolean)
+ORDER BY w.workout_date, ws.workout_id, ws.overall_workout_set_number
+`
+
+type GetOneRepMaxSetsForExerciseParams struct {
+	UserID         sql.NullInt32
+	ExerciseID     int32
+	MaxReps        int32
+	IncludeWarmups bool
+}
+
+type GetOneRepMaxSetsForExerciseRow struct {
+	WorkoutID               int32
+	WorkoutDate             time.Time
+	OverallWorkoutSetNumber int32
+	ResistanceValue         sql.NullString
+	Reps                    sql.NullInt32
+	Rpe                     sql.NullString
+}
+
+// Weighted sets for 1RM estimation; the rep cap drops high-rep sets where the formulas stop being reliable
+func (q *Queries) GetOneRepMaxSetsForExercise(ctx context.Context, arg GetOneRepMaxSetsForExerciseParams) ([]GetOneRepMaxSetsForExerciseRow, error) {
+	rows, err := q.db.QueryContext(ctx, getOneRepMaxSetsForExercise,
+		arg.UserID,
+		arg.ExerciseID,
+		arg.MaxReps,
+		arg.IncludeWarmups,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetOneRepMaxSetsForExerciseRow
+	for rows.Next() {
+		var i GetOneRepMaxSetsForExerciseRow
+		if err := rows.Scan(
+			&i.WorkoutID,
+			&i.WorkoutDate,
+			&i.OverallWorkoutSetNumber,
+			&i.ResistanceValue,
+			&i.Reps,
+			&i.Rpe,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const swapWorkoutSetsExercise = `-- name: SwapWorkoutSetsExercise :many
+UPDATE workout_sets
+SET exercise_id = $1
+WHERE workout_id = $2
+AND exercise_id = $3
+AND completed_at IS NULL
+RETURNING workout_id, exercise_id, set_number, overall_workout_set_number, reps, resistance_value, resistance_type, resistance_detail, rpe, percent_1rm, notes, created_at, completed_at, group_id, set_type, parent_set_number, duration_seconds, distance_meters, distance_unit, calories, pace_seconds_per_km
+`
+
+type SwapWorkoutSetsExerciseParams struct {
+	ToExerciseID   int32
+	WorkoutID      int32
+	FromExerciseID int32
+}
+
+// Moves a workout's not-yet-completed sets for one exercise onto another in place, so set numbers, order, groups
+// & targets all carry over
+func (q *Queries) SwapWorkoutSetsExercise(ctx context.Context, arg SwapWorkoutSetsExerciseParams) ([]WorkoutSet, error) {
+	rows, err := q.db.QueryContext(ctx, swapWorkoutSetsExercise, arg.ToExerciseID, arg.WorkoutID, arg.FromExerciseID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []WorkoutSet
+	for rows.Next() {
+		var i WorkoutSet
+		if err := rows.Scan(
+			&i.WorkoutID,
+			&i.ExerciseID,
+			&i.SetNumber,
+			&i.OverallWorkoutSetNumber,
+			&i.Reps,
+			&i.ResistanceValue,
+			&i.ResistanceType,
+			&i.ResistanceDetail,
+			&i.Rpe,
+			&i.Percent1rm,
+			&i.Notes,
+			&i.CreatedAt,
+			&i.CompletedAt,
+			&i.GroupID,
+			&i.SetType,
+			&i.ParentSetNumber,
+			&i.DurationSeconds,
+			&i.DistanceMeters,
+			&i.DistanceUnit,
+			&i.Calories,
+			&i.PaceSecondsPerKm,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const updateWorkoutSetByID = `-- name: UpdateWorkoutSetByID :one
 UPDATE workout_sets 
 SET 
-  reps = $2,
-  resistance_value = $3,
-  resistance_type = $4,
-  resistance_detail = $5,
-  rpe = $6,
-  notes = $7
-WHERE workout_id = $8 
-AND overall_workout_set_number = $1
-RETURNING workout_id, exercise_id, set_number, overall_workout_set_number, reps, resistance_value, resistance_type, resistance_detail, rpe, percent_1rm, notes, created_at
+  reps = $1,
+  resistance_value = $2,
+  resistance_type = $3,
+  resistance_detail = $4,
+  rpe = $5,
+  notes = $6,
+  duration_seconds = $7,
+  distance_meters = $8,
+  distance_unit = $9,
+  calories = $10,
+  set_type = COALESCE($11::set_type_enum, set_type),
+  parent_set_number = CASE
+    WHEN $11::set_type_enum IS NULL THEN parent_set_number
+    ELSE $12::int
+  END
+WHERE workout_id = $13
+AND overall_workout_set_number = $14
+RETURNING workout_id, exercise_id, set_number, overall_workout_set_number, reps, resistance_value, resistance_type, resistance_detail, rpe, percent_1rm, notes, created_at, completed_at, group_id, set_type, parent_set_number, duration_seconds, distance_meters, distance_unit, calories, pace_seconds_per_km
 `
 
 type UpdateWorkoutSetByIDParams struct {
-	OverallWorkoutSetNumber int32
 	Reps                    sql.NullInt32
 	ResistanceValue         sql.NullString
 	ResistanceType          NullResistanceTypeEnum
 	ResistanceDetail        sql.NullString
 	Rpe                     sql.NullString
 	Notes                   sql.NullString
+	DurationSeconds         sql.NullInt32
+	DistanceMeters          sql.NullString
+	DistanceUnit            NullDistanceUnitEnum
+	Calories                sql.NullInt32
+	SetType                 NullSetTypeEnum
+	ParentSetNumber         sql.NullInt32
 	WorkoutID               int32
+	OverallWorkoutSetNumber int32
 }
 
 // Make batch version of this later
+// set_type & parent_set_number are only touched when a new set_type is sent, so partial updates keep drop set links intact
 func (q *Queries) UpdateWorkoutSetByID(ctx context.Context, arg UpdateWorkoutSetByIDParams) (WorkoutSet, error) {
 	row := q.db.QueryRowContext(ctx, updateWorkoutSetByID,
-		arg.OverallWorkoutSetNumber,
 		arg.Reps,
 		arg.ResistanceValue,
 		arg.ResistanceType,
 		arg.ResistanceDetail,
 		arg.Rpe,
 		arg.Notes,
+		arg.DurationSeconds,
+		arg.DistanceMeters,
+		arg.DistanceUnit,
+		arg.Calories,
+		arg.SetType,
+		arg.ParentSetNumber,
 		arg.WorkoutID,
+		arg.OverallWorkoutSetNumber,
 	)
 	var i WorkoutSet
 	err := row.Scan(
@@ -273,6 +667,35 @@ func (q *Queries) UpdateWorkoutSetByID(ctx context.Context, arg UpdateWorkoutSet
 		&i.Percent1rm,
 		&i.Notes,
 		&i.CreatedAt,
+		&i.CompletedAt,
+		&i.GroupID,
+		&i.SetType,
+		&i.ParentSetNumber,
+		&i.DurationSeconds,
+		&i.DistanceMeters,
+		&i.DistanceUnit,
+		&i.Calories,
+		&i.PaceSecondsPerKm,
 	)
 	return i, err
 }
+
+const workoutHasExercise = `-- name: WorkoutHasExercise :one
+SELECT EXISTS(
+  SELECT 1 FROM workout_sets
+  WHERE workout_id = $1
+  AND exercise_id = $2
+)
+`
+
+type WorkoutHasExerciseParams struct {
+	WorkoutID  int32
+	ExerciseID int32
+}
+
+func (q *Queries) WorkoutHasExercise(ctx context.Context, arg WorkoutHasExerciseParams) (bool, error) {
+	row := q.db.QueryRowContext(ctx, workoutHasExercise, arg.WorkoutID, arg.ExerciseID)
+	var exists bool
+	err := row.Scan(&exists)
+	return exists, err
+}
@@ -12,15 +12,17 @@ import (
 )
 
 const createWorkout = `-- name: CreateWorkout :one
-INSERT INTO workouts (user_id, workout_date, title)
-VALUES ($1, $2, $3)
-RETURNING workout_id, user_id, workout_date, title, created_at
+INSERT INTO workouts (user_id, workout_date, title, started_at, ended_at)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING workout_id, user_id, workout_date, title, started_at, ended_at, created_at
 `
 
 type CreateWorkoutParams struct {
 	UserID      sql.NullInt32
 	WorkoutDate time.Time
 	Title       sql.NullString
+	StartedAt   sql.NullTime
+	EndedAt     sql.NullTime
 }
 
 type CreateWorkoutRow struct {
@@ -28,18 +30,28 @@ type CreateWorkoutRow struct {
 	UserID      sql.NullInt32
 	WorkoutDate time.Time
 	Title       sql.NullString
+	StartedAt   sql.NullTime
+	EndedAt     sql.NullTime
 	CreatedAt   sql.NullTime
 }
 
 // CREATE: Insert a new workout
 func (q *Queries) CreateWorkout(ctx context.Context, arg CreateWorkoutParams) (CreateWorkoutRow, error) {
-	row := q.db.QueryRowContext(ctx, createWorkout, arg.UserID, arg.WorkoutDate, arg.Title)
+	row := q.db.QueryRowContext(ctx, createWorkout,
+		arg.UserID,
+		arg.WorkoutDate,
+		arg.Title,
+		arg.StartedAt,
+		arg.EndedAt,
+	)
 	var i CreateWorkoutRow
 	err := row.Scan(
 		&i.WorkoutID,
 		&i.UserID,
 		&i.WorkoutDate,
 		&i.Title,
+		&i.StartedAt,
+		&i.EndedAt,
 		&i.CreatedAt,
 	)
 	return i, err
@@ -74,17 +86,75 @@ func (q *Queries) DeleteWorkout(ctx context.Context, arg DeleteWorkoutParams) (i
 	return workout_id, err
 }
 
+const finishWorkout = `-- name: FinishWorkout :one
+UPDATE workouts
+SET
+  ended_at = CURRENT_TIMESTAMP,
+  updated_at = CURRENT_TIMESTAMP
+WHERE workout_id = $1 AND user_id = $2 AND started_at IS NOT NULL AND ended_at IS NULL
+RETURNING workout_id, user_id, workout_date, title, created_at, updated_at, started_at, ended_at
+`
+
+type FinishWorkoutParams struct {
+	WorkoutID int32
+	UserID    sql.NullInt32
+}
+
+// UPDATE: Stamp the end of a live session. Only in-progress workouts can be finished.
+func (q *Queries) FinishWorkout(ctx context.Context, arg FinishWorkoutParams) (Workout, error) {
+	row := q.db.QueryRowContext(ctx, finishWorkout, arg.WorkoutID, arg.UserID)
+	var i Workout
+	err := row.Scan(
+		&i.WorkoutID,
+		&i.UserID,
+		&i.WorkoutDate,
+		&i.Title,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.StartedAt,
+		&i.EndedAt,
+	)
+	return i, err
+}
+
+const getActiveWorkoutForUser = `-- name: GetActiveWorkoutForUser :one
+SELECT workout_id, user_id, workout_date, title, created_at, updated_at, started_at, ended_at
+FROM workouts
+WHERE user_id = $1 AND started_at IS NOT NULL AND ended_at IS NULL
+ORDER BY started_at DESC
+LIMIT 1
+`
+
+// READ: The user's in-progress (started, not yet finished) workout, if any
+func (q *Queries) GetActiveWorkoutForUser(ctx context.Context, userID sql.NullInt32) (Workout, error) {
+	row := q.db.QueryRowContext(ctx, getActiveWorkoutForUser, userID)
+	var i Workout
+	err := row.Scan(
+		&i.WorkoutID,
+		&i.UserID,
+		&i.WorkoutDate,
+		&i.Title,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.StartedAt,
+		&i.EndedAt,
+	)
+	return i, err
+}
+
 const getAllWorkoutsForUser = `-- name: GetAllWorkoutsForUser :many
-SELECT workout_id, workout_date, title, created_at
+SELECT workout_id, workout_date, title, started_at, ended_at, created_at
 FROM workouts
 WHERE user_id = $1
-ORDER BY workout_date DESC
+ORDER BY workout_date DESC, started_at DESC NULLS LAST, workout_id DESC
 `
 
 type GetAllWorkoutsForUserRow struct {
 	WorkoutID   int32
 	WorkoutDate time.Time
 	Title       sql.NullString
+	StartedAt   sql.NullTime
+	EndedAt     sql.NullTime
 	CreatedAt   sql.NullTime
 }
 
@@ -102,6 +172,8 @@ func (q *Queries) GetAllWorkoutsForUser(ctx context.Context, userID sql.NullInt3
 			&i.WorkoutID,
 			&i.WorkoutDate,
 			&i.Title,
+			&i.StartedAt,
+			&i.EndedAt,
 			&i.CreatedAt,
 		); err != nil {
 			return nil, err
@@ -118,7 +190,7 @@ func (q *Queries) GetAllWorkoutsForUser(ctx context.Context, userID sql.NullInt3
 }
 
 const getWorkoutByIDForUser = `-- name: GetWorkoutByIDForUser :one
-SELECT workout_id, user_id, workout_date, title, created_at
+SELECT workout_id, user_id, workout_date, title, started_at, ended_at, created_at
 FROM workouts
 WHERE workout_id = $1 AND user_id = $2
 `
@@ -133,6 +205,8 @@ type GetWorkoutByIDForUserRow struct {
 	UserID      sql.NullInt32
 	WorkoutDate time.Time
 	Title       sql.NullString
+	StartedAt   sql.NullTime
+	EndedAt     sql.NullTime
 	CreatedAt   sql.NullTime
 }
 
@@ -145,38 +219,130 @@ func (q *Queries) GetWorkoutByIDForUser(ctx context.Context, arg GetWorkoutByIDF
 		&i.UserID,
 		&i.WorkoutDate,
 		&i.Title,
+		&i.StartedAt,
+		&i.EndedAt,
 		&i.CreatedAt,
 	)
 	return i, err
 }
 
-const getWorkoutByUserIDAndDate = `-- name: GetWorkoutByUserIDAndDate :one
-SELECT workout_id, workout_date, title, created_at
+const getWorkoutDatesForUser = `-- name: GetWorkoutDatesForUser :many
+SELECT DISTINCT workout_date
+FROM workouts
+WHERE user_id = $1
+AND workout_date BETWEEN $2::date AND $3::date
+ORDER BY workout_date
+`
+
+type GetWorkoutDatesForUserParams struct {
+	UserID   sql.NullInt32
+	FromDate time.Time
+	ToDate   time.Time
+}
+
+// READ: Dates in a range the user already has at least one workout on (used by imports to flag clashes)
+func (q *Queries) GetWorkoutDatesForUser(ctx context.Context, arg GetWorkoutDatesForUserParams) ([]time.Time, error) {
+	rows, err := q.db.QueryContext(ctx, getWorkoutDatesForUser, arg.UserID, arg.FromDate, arg.ToDate)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []time.Time
+	for rows.Next() {
+		var workout_date time.Time
+		if err := rows.Scan(&workout_date); err != nil {
+			return nil, err
+		}
+		items = append(items, workout_date)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getWorkoutsByUserIDAndDate = `-- name: GetWorkoutsByUserIDAndDate :many
+SELECT workout_id, workout_date, title, started_at, ended_at, created_at
 FROM workouts
 WHERE user_id = $1 AND workout_date = $2
+ORDER BY started_at NULLS LAST, workout_id
 `
 
-type GetWorkoutByUserIDAndDateParams struct {
+type GetWorkoutsByUserIDAndDateParams struct {
 	UserID      sql.NullInt32
 	WorkoutDate time.Time
 }
 
-type GetWorkoutByUserIDAndDateRow struct {
+type GetWorkoutsByUserIDAndDateRow struct {
 	WorkoutID   int32
 	WorkoutDate time.Time
 	Title       sql.NullString
+	StartedAt   sql.NullTime
+	EndedAt     sql.NullTime
 	CreatedAt   sql.NullTime
 }
 
-// READ: Get workout from a date (client-side) & userID (from context)
-func (q *Queries) GetWorkoutByUserIDAndDate(ctx context.Context, arg GetWorkoutByUserIDAndDateParams) (GetWorkoutByUserIDAndDateRow, error) {
-	row := q.db.QueryRowContext(ctx, getWorkoutByUserIDAndDate, arg.UserID, arg.WorkoutDate)
-	var i GetWorkoutByUserIDAndDateRow
+// READ: Get every workout (session) from a date (client-side) & userID (from context), in the order they happened
+func (q *Queries) GetWorkoutsByUserIDAndDate(ctx context.Context, arg GetWorkoutsByUserIDAndDateParams) ([]GetWorkoutsByUserIDAndDateRow, error) {
+	rows, err := q.db.QueryContext(ctx, getWorkoutsByUserIDAndDate, arg.UserID, arg.WorkoutDate)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetWorkoutsByUserIDAndDateRow
+	for rows.Next() {
+		var i GetWorkoutsByUserIDAndDateRow
+		if err := rows.Scan(
+			&i.WorkoutID,
+			&i.WorkoutDate,
+			&i.Title,
+			&i.StartedAt,
+			&i.EndedAt,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const startWorkout = `-- name: StartWorkout :one
+UPDATE workouts
+SET
+  started_at = CURRENT_TIMESTAMP,
+  updated_at = CURRENT_TIMESTAMP
+WHERE workout_id = $1 AND user_id = $2 AND started_at IS NULL
+RETURNING workout_id, user_id, workout_date, title, created_at, updated_at, started_at, ended_at
+`
+
+type StartWorkoutParams struct {
+	WorkoutID int32
+	UserID    sql.NullInt32
+}
+
+// UPDATE: Stamp the start of a live session. Workouts that were already started are left alone.
+func (q *Queries) StartWorkout(ctx context.Context, arg StartWorkoutParams) (Workout, error) {
+	row := q.db.QueryRowContext(ctx, startWorkout, arg.WorkoutID, arg.UserID)
+	var i Workout
 	err := row.Scan(
 		&i.WorkoutID,
+		&i.UserID,
 		&i.WorkoutDate,
 		&i.Title,
 		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.StartedAt,
+		&i.EndedAt,
 	)
 	return i, err
 }
@@ -185,13 +351,17 @@ const updateWorkout = `-- name: UpdateWorkout :one
 UPDATE workouts
 SET 
   title = $1,
+  started_at = COALESCE($2::timestamptz, started_at),
+  ended_at = COALESCE($3::timestamptz, ended_at),
   updated_at = CURRENT_TIMESTAMP
-WHERE workout_id = $2 AND user_id = $3
-RETURNING workout_id, workout_date, title, updated_at
+WHERE workout_id = $4 AND user_id = $5
+RETURNING workout_id, workout_date, title, started_at, ended_at, updated_at
 `
 
 type UpdateWorkoutParams struct {
 	Title     sql.NullString
+	StartedAt sql.NullTime
+	EndedAt   sql.NullTime
 	WorkoutID int32
 	UserID    sql.NullInt32
 }
@@ -200,17 +370,27 @@ type UpdateWorkoutRow struct {
 	WorkoutID   int32
 	WorkoutDate time.Time
 	Title       sql.NullString
+	StartedAt   sql.NullTime
+	EndedAt     sql.NullTime
 	UpdatedAt   sql.NullTime
 }
 
-// UPDATE: Modify an existing workout
+// UPDATE: Modify an existing workout. Session timestamps are only overwritten when provided.
 func (q *Queries) UpdateWorkout(ctx context.Context, arg UpdateWorkoutParams) (UpdateWorkoutRow, error) {
-	row := q.db.QueryRowContext(ctx, updateWorkout, arg.Title, arg.WorkoutID, arg.UserID)
+	row := q.db.QueryRowContext(ctx, updateWorkout,
+		arg.Title,
+		arg.StartedAt,
+		arg.EndedAt,
+		arg.WorkoutID,
+		arg.UserID,
+	)
 	var i UpdateWorkoutRow
 	err := row.Scan(
 		&i.WorkoutID,
 		&i.WorkoutDate,
 		&i.Title,
+		&i.StartedAt,
+		&i.EndedAt,
 		&i.UpdatedAt,
 	)
 	return i, err
@@ -0,0 +1,52 @@
+package importer
+
+import (
+	"fmt"
+	"time"
+)
+
+/*
+FitNotes: one row per set, with no workout times or names, so each date becomes one workout.
+Date,Exercise,Category,Weight (kgs),Reps,Distance,Distance Unit,Time,Comment
+2024-03-04,Flat Barbell Bench Press,Chest,60.0,8,,,,
+2024-03-04,Treadmill,Cardio,,,5.0,km,0:25:00,
+Exports from apps set to imperial have "Weight (lbs)" instead.
+*/
+
+func parseFitNotesRow(r row, opts Options) (parsedRow, error) {
+	exercise := r.get("exercise")
+	if exercise == "" {
+		return parsedRow{}, fmt.Errorf("missing exercise")
+	}
+
+	date, err := time.Parse("2006-01-02", r.get("date"))
+	if err != nil {
+		return parsedRow{}, fmt.Errorf("unrecognised date %q", r.get("date"))
+	}
+
+	set := Set{ExerciseName: exercise, SetType: "working", Notes: r.get("comment")}
+	if _, ok := r.columns["weight (lbs)"]; ok {
+		set.WeightKg, err = r.weightKg("weight (lbs)", "lb")
+	} else {
+		set.WeightKg, err = r.weightKg("weight (kgs)", "kg")
+	}
+	if err != nil {
+		return parsedRow{}, err
+	}
+	if set.Reps, err = r.reps("reps"); err != nil {
+		return parsedRow{}, err
+	}
+
+	distanceUnit := r.get("distance unit")
+	if distanceUnit == "" {
+		distanceUnit = opts.DistanceUnit
+	}
+	if set.DistanceMeters, set.DistanceUnit, err = r.distance("distance", distanceUnit); err != nil {
+		return parsedRow{}, err
+	}
+	if set.DurationSeconds, err = parseClockDuration(r.get("time")); err != nil {
+		return parsedRow{}, err
+	}
+
+	return parsedRow{key: r.get("date"), workout: Workout{Date: date}, set: set}, nil
+}
@@ -0,0 +1,64 @@
+package importer
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseFitNotes(t *testing.T) {
+	tests := []struct {
+		name         string
+		csv          string
+		opts         Options
+		want         []wantWorkout
+		wantWarnings []string
+	}{
+		{
+			name: "one workout per date",
+			csv: `Date,Exercise,Category,Weight (kgs),Reps,Distance,Distance Unit,Time,Comment
+2024-03-04,Flat Barbell Bench Press,Chest,60.0,8,,,,
+2024-03-04,Treadmill,Cardio,,,5.0,km,0:25:00,easy
+2024-03-03,Pull Up,Back,0,12,,,,
+2024-03-05,Plank,Core,,,,,,
+2024-03-05,Plank,Core,,,,,ages,
+2024-03-05,Rowing Machine,Cardio,,,2000,,,
+`,
+			opts: Options{DistanceUnit: "m"},
+			want: []wantWorkout{
+				{date: "2024-03-03", sets: []string{"Pull Up, working, 12 reps"}},
+				{date: "2024-03-04", sets: []string{
+					"Flat Barbell Bench Press, working, 8 reps, 60.000 kg",
+					`Treadmill, working, 5000.00 m (km), 1500 s, "easy"`,
+				}},
+				{date: "2024-03-05", sets: []string{"Rowing Machine, working, 2000.00 m (m)"}},
+			},
+			wantWarnings: []string{
+				"line 5: no reps, weight, distance or time; skipped",
+				`line 6: unrecognised time "ages"`,
+			},
+		},
+		{
+			name: "semicolons with comma decimals in pounds",
+			csv: `Date;Exercise;Category;Weight (lbs);Reps;Distance;Distance Unit;Time;Comment
+2024-03-04;Flat Barbell Bench Press;Chest;132,5;8;;;;
+2024-03-04;Treadmill;Cardio;;;3,1;miles;0:25:00;
+`,
+			want: []wantWorkout{
+				{date: "2024-03-04", sets: []string{
+					"Flat Barbell Bench Press, working, 8 reps, 60.101 kg",
+					"Treadmill, working, 4988.97 m (mi), 1500 s",
+				}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse(strings.NewReader(tt.csv), tt.opts)
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+			checkResult(t, got, FormatFitNotes, tt.want, tt.wantWarnings)
+		})
+	}
+}
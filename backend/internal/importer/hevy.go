@@ -0,0 +1,75 @@
+package importer
+
+import (
+	"fmt"
+	"strings"
+)
+
+/*
+Hevy: one row per set, newest workout first.
+"title","start_time","end_time","description","exercise_title","superset_id","exercise_notes","set_index","set_type","weight_kg","reps","distance_km","duration_seconds","rpe"
+"Push Day","4 Mar 2024, 18:02","4 Mar 2024, 19:07","","Bench Press (Barbell)",,"",0,"normal",60,8,,,8
+Accounts set to imperial export "weight_lbs" & "distance_miles" instead.
+*/
+
+var hevyTimeLayouts = []string{"2 Jan 2006, 15:04", "2 Jan 2006 15:04", "2006-01-02 15:04:05", "2006-01-02T15:04:05Z07:00", "2006-01-02 15:04"}
+
+var hevySetTypes = map[string]string{"normal": "working", "warmup": "warmup", "dropset": "drop", "failure": "failure"}
+
+func parseHevyRow(r row, opts Options) (parsedRow, error) {
+	exercise := r.get("exercise_title")
+	if exercise == "" {
+		return parsedRow{}, fmt.Errorf("missing exercise_title")
+	}
+
+	started, err := parseTime(r.get("start_time"), hevyTimeLayouts, opts.Location)
+	if err != nil {
+		return parsedRow{}, err
+	}
+
+	setType, ok := hevySetTypes[strings.ToLower(r.get("set_type"))]
+	if !ok {
+		if r.get("set_type") != "" {
+			return parsedRow{}, fmt.Errorf("unrecognised set_type %q", r.get("set_type"))
+		}
+		setType = "working"
+	}
+
+	set := Set{ExerciseName: exercise, SetType: setType, RPE: r.rpe("rpe")}
+	// exercise notes are repeated on every set of the exercise, so they're only kept on the first
+	if index := r.get("set_index"); index == "" || index == "0" {
+		set.Notes = r.get("exercise_notes")
+	}
+
+	if _, ok := r.columns["weight_lbs"]; ok {
+		set.WeightKg, err = r.weightKg("weight_lbs", "lb")
+	} else {
+		set.WeightKg, err = r.weightKg("weight_kg", "kg")
+	}
+	if err != nil {
+		return parsedRow{}, err
+	}
+	if set.Reps, err = r.reps("reps"); err != nil {
+		return parsedRow{}, err
+	}
+	if _, ok := r.columns["distance_miles"]; ok {
+		set.DistanceMeters, set.DistanceUnit, err = r.distance("distance_miles", "mi")
+	} else {
+		set.DistanceMeters, set.DistanceUnit, err = r.distance("distance_km", "km")
+	}
+	if err != nil {
+		return parsedRow{}, err
+	}
+	if set.DurationSeconds, err = r.seconds("duration_seconds"); err != nil {
+		return parsedRow{}, err
+	}
+
+	// an imported workout is over; with no end it would look like a session still in progress
+	ended := started
+	if end, err := parseTime(r.get("end_time"), hevyTimeLayouts, opts.Location); err == nil && end.After(started) {
+		ended = end
+	}
+	workout := Workout{Date: dateOf(started), Title: r.get("title"), StartedAt: &started, EndedAt: &ended}
+
+	return parsedRow{key: r.get("start_time") + "|" + workout.Title, workout: workout, set: set}, nil
+}
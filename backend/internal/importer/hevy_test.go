@@ -0,0 +1,100 @@
+package importer
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseHevy(t *testing.T) {
+	tests := []struct {
+		name         string
+		csv          string
+		want         []wantWorkout
+		wantWarnings []string
+	}{
+		{
+			name: "newest first, with drop sets & notes on the first set",
+			csv: `"title","start_time","end_time","description","exercise_title","superset_id","exercise_notes","set_index","set_type","weight_kg","reps","distance_km","duration_seconds","rpe"
+"Push Day","4 Mar 2024, 18:02","4 Mar 2024, 19:07","","Bench Press (Barbell)",,"Paused",0,"normal",60,8,,,8
+"Push Day","4 Mar 2024, 18:02","4 Mar 2024, 19:07","","Bench Press (Barbell)",,"Paused",1,"dropset",45,10,,,
+"Push Day","4 Mar 2024, 18:02","4 Mar 2024, 19:07","","Bench Press (Barbell)",,"Paused",2,"failure",45,6,,,10
+"Push Day","4 Mar 2024, 18:02","4 Mar 2024, 19:07","","Bench Press (Barbell)",,"Paused",3,"superset",45,6,,,
+"Pull Day","2 Mar 2024, 09:00","2 Mar 2024, 08:00","","Rowing (Machine)",,"",0,"warmup",,,2.5,600,
+`,
+			want: []wantWorkout{
+				{
+					date: "2024-03-02", title: "Pull Day",
+					startedAt: "2024-03-02T09:00:00Z", endedAt: "2024-03-02T09:00:00Z", // an end before the start is dropped
+					sets: []string{"Rowing (Machine), warmup, 2500.00 m (km), 600 s"},
+				},
+				{
+					date: "2024-03-04", title: "Push Day",
+					startedAt: "2024-03-04T18:02:00Z", endedAt: "2024-03-04T19:07:00Z",
+					sets: []string{
+						`Bench Press (Barbell), working, 8 reps, 60.000 kg, @8, "Paused"`,
+						"Bench Press (Barbell), drop, 10 reps, 45.000 kg",
+						"Bench Press (Barbell), failure, 6 reps, 45.000 kg, @10",
+					},
+				},
+			},
+			wantWarnings: []string{`line 5: unrecognised set_type "superset"`},
+		},
+		{
+			name: "no end time ends the workout when it started",
+			csv: `"title","start_time","end_time","description","exercise_title","superset_id","exercise_notes","set_index","set_type","weight_kg","reps","distance_km","duration_seconds","rpe"
+"Push Day","4 Mar 2024, 18:02","","","Bench Press (Barbell)",,"",0,"normal",60,8,,,
+`,
+			want: []wantWorkout{
+				{
+					date: "2024-03-04", title: "Push Day",
+					startedAt: "2024-03-04T18:02:00Z", endedAt: "2024-03-04T18:02:00Z",
+					sets: []string{"Bench Press (Barbell), working, 8 reps, 60.000 kg"},
+				},
+			},
+		},
+		{
+			name: "imperial accounts",
+			csv: `"title","start_time","end_time","description","exercise_title","superset_id","exercise_notes","set_index","set_type","weight_lbs","reps","distance_miles","duration_seconds","rpe"
+"Legs","2024-03-04 07:15:00","2024-03-04 08:00:00","","Squat (Barbell)",,"",0,"",225,5,,,
+"Legs","2024-03-04 07:15:00","2024-03-04 08:00:00","","Walking",,"",0,"normal",,,1,900,
+`,
+			want: []wantWorkout{
+				{
+					date: "2024-03-04", title: "Legs",
+					startedAt: "2024-03-04T07:15:00Z", endedAt: "2024-03-04T08:00:00Z",
+					sets: []string{
+						"Squat (Barbell), working, 5 reps, 102.058 kg",
+						"Walking, working, 1609.34 m (mi), 900 s",
+					},
+				},
+			},
+		},
+		{
+			name: "semicolons with comma decimals",
+			csv: `"title";"start_time";"end_time";"description";"exercise_title";"superset_id";"exercise_notes";"set_index";"set_type";"weight_kg";"reps";"distance_km";"duration_seconds";"rpe"
+"Push Day";"4 Mar 2024, 18:02";"4 Mar 2024, 19:07";"";"Bench Press (Barbell)";;"";0;"normal";82,5;8;;;8,5
+"Push Day";"4 Mar 2024, 18:02";"4 Mar 2024, 19:07";"";"Bench Press (Barbell)";;"";1;"dropset";62,5;10;;;
+`,
+			want: []wantWorkout{
+				{
+					date: "2024-03-04", title: "Push Day",
+					startedAt: "2024-03-04T18:02:00Z", endedAt: "2024-03-04T19:07:00Z",
+					sets: []string{
+						"Bench Press (Barbell), working, 8 reps, 82.500 kg, @8.5",
+						"Bench Press (Barbell), drop, 10 reps, 62.500 kg",
+					},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse(strings.NewReader(tt.csv), Options{})
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+			checkResult(t, got, FormatHevy, tt.want, tt.wantWarnings)
+		})
+	}
+}
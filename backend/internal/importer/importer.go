@@ -0,0 +1,403 @@
+package importer
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"go-reppy/backend/internal/api/utils"
+)
+
+// Apps whose CSV exports can be read
+type Format string
+
+const (
+	FormatStrong   Format = "strong"
+	FormatHevy     Format = "hevy"
+	FormatFitNotes Format = "fitnotes"
+)
+
+var Formats = []Format{FormatStrong, FormatHevy, FormatFitNotes}
+
+// Past this many, problem rows are only counted so one bad column can't produce thousands of warnings
+const maxWarnings = 50
+
+// One set as logged in the other app, with weights in kg & distances in meters
+type Set struct {
+	ExerciseName    string
+	SetType         string // 'warmup', 'working', 'drop' or 'failure'
+	Reps            *int32
+	WeightKg        *float64
+	DistanceMeters  *float64
+	DistanceUnit    string // the unit the distance was logged in, as one of the distance_unit_enum values
+	DurationSeconds *int32
+	RPE             *float64
+	Notes           string
+	Line            int // line in the file, for pointing at problems
+}
+
+type Workout struct {
+	Date      time.Time // date only, UTC midnight like every other workout_date
+	Title     string
+	StartedAt *time.Time
+	EndedAt   *time.Time
+	Sets      []Set // in the order they were logged
+}
+
+type Options struct {
+	Format       Format         // empty to detect it from the header row
+	WeightUnit   string         // 'kg' or 'lb'; only used for files that don't say which they're in
+	DistanceUnit string         // 'm', 'km', 'mi', 'yd' or 'ft'; same as above
+	Location     *time.Location // timestamps in these exports are wall-clock times; UTC if nil
+}
+
+type Result struct {
+	Format   Format
+	Workouts []Workout // oldest first
+	Warnings []string  // rows that were skipped or partly read, by line
+}
+
+// One data row, read through the header so columns can be looked up by name (case-insensitively)
+type row struct {
+	fields  []string
+	columns map[string]int
+	line    int
+	decimal byte // ',' for exports from locales that write "82,5"
+}
+
+func (r row) get(column string) string {
+	i, ok := r.columns[column]
+	if !ok || i >= len(r.fields) {
+		return ""
+	}
+	return strings.TrimSpace(r.fields[i])
+}
+
+// What a format's parser makes of one row: the workout it belongs to (identified by key) & the set on it
+type parsedRow struct {
+	key     string
+	workout Workout
+	set     Set
+	skip    bool // not a set at all (e.g. Strong's rest timer rows)
+}
+
+type formatParser struct {
+	required []string // lowercased header names that identify the format
+	parse    func(r row, opts Options) (parsedRow, error)
+}
+
+var parsers = map[Format]formatParser{
+	FormatStrong:   {required: []string{"date", "workout name", "exercise name", "set order"}, parse: parseStrongRow},
+	FormatHevy:     {required: []string{"title", "start_time", "exercise_title", "set_type"}, parse: parseHevyRow},
+	FormatFitNotes: {required: []string{"date", "exercise", "category", "reps"}, parse: parseFitNotesRow},
+}
+
+// Reads a whole export into workouts. Rows that can't be read are skipped with a warning rather than failing the import;
+// only an unreadable or unrecognised file is an error.
+func Parse(r io.Reader, opts Options) (Result, error) {
+	if opts.Location == nil {
+		opts.Location = time.UTC
+	}
+	if opts.WeightUnit == "" {
+		opts.WeightUnit = "kg"
+	}
+	if opts.DistanceUnit == "" {
+		opts.DistanceUnit = "m"
+	}
+
+	buffered := bufio.NewReader(r)
+	if bom, err := buffered.Peek(3); err == nil && bytes.Equal(bom, []byte("\xef\xbb\xbf")) {
+		buffered.Discard(3)
+	}
+
+	// some locales export with ';' between fields & ',' as the decimal separator
+	delimiter, decimal := ',', byte('.')
+	peeked, _ := buffered.Peek(buffered.Size())
+	if header := firstLineOf(peeked); strings.Count(header, ";") > strings.Count(header, ",") {
+		delimiter, decimal = ';', ','
+	}
+
+	reader := csv.NewReader(buffered)
+	reader.Comma = delimiter
+	reader.LazyQuotes = true
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return Result{}, errors.New("file is empty")
+	}
+	if err != nil {
+		return Result{}, fmt.Errorf("could not read the header row: %w", err)
+	}
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+
+	format := opts.Format
+	if format == "" {
+		format = detectFormat(columns)
+		if format == "" {
+			return Result{}, errors.New("unrecognised file; expected a CSV export from Strong, Hevy or FitNotes")
+		}
+	}
+	parser, ok := parsers[format]
+	if !ok {
+		return Result{}, fmt.Errorf("unsupported format %q", format)
+	}
+	if missing := missingColumns(columns, parser.required); len(missing) > 0 {
+		return Result{}, fmt.Errorf("not a %s export; missing column(s): %s", format, strings.Join(missing, ", "))
+	}
+
+	result := Result{Format: format}
+	skipped := 0
+	warn := func(line int, message string) {
+		if len(result.Warnings) < maxWarnings {
+			result.Warnings = append(result.Warnings, fmt.Sprintf("line %d: %s", line, message))
+		} else {
+			skipped++
+		}
+	}
+
+	byKey := make(map[string]int)
+	for {
+		fields, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			var parseErr *csv.ParseError
+			if errors.As(err, &parseErr) {
+				warn(parseErr.Line, parseErr.Err.Error())
+				continue
+			}
+			return Result{}, fmt.Errorf("could not read the file: %w", err)
+		}
+		line, _ := reader.FieldPos(0)
+		if isBlank(fields) {
+			continue
+		}
+
+		parsed, err := parser.parse(row{fields: fields, columns: columns, line: line, decimal: decimal}, opts)
+		if err != nil {
+			warn(line, err.Error())
+			continue
+		}
+		if parsed.skip {
+			continue
+		}
+		parsed.set.Line = line
+		if !parsed.set.hasMetrics() {
+			warn(line, "no reps, weight, distance or time; skipped")
+			continue
+		}
+
+		i, ok := byKey[parsed.key]
+		if !ok {
+			i = len(result.Workouts)
+			byKey[parsed.key] = i
+			result.Workouts = append(result.Workouts, parsed.workout)
+		}
+		result.Workouts[i].Sets = append(result.Workouts[i].Sets, parsed.set)
+	}
+	if skipped > 0 {
+		result.Warnings = append(result.Warnings, fmt.Sprintf("...and %d more rows with problems", skipped))
+	}
+
+	// exports run newest first (Hevy) or oldest first (Strong, FitNotes); same-day workouts keep their start order
+	sort.SliceStable(result.Workouts, func(i, j int) bool {
+		a, b := result.Workouts[i], result.Workouts[j]
+		if !a.Date.Equal(b.Date) {
+			return a.Date.Before(b.Date)
+		}
+		return a.StartedAt != nil && b.StartedAt != nil && a.StartedAt.Before(*b.StartedAt)
+	})
+	return result, nil
+}
+
+func (s Set) hasMetrics() bool {
+	return s.Reps != nil || s.WeightKg != nil || s.DistanceMeters != nil || s.DurationSeconds != nil
+}
+
+func detectFormat(columns map[string]int) Format {
+	// Hevy & Strong are checked first since FitNotes' columns are the most generic
+	for _, format := range []Format{FormatHevy, FormatStrong, FormatFitNotes} {
+		if len(missingColumns(columns, parsers[format].required)) == 0 {
+			return format
+		}
+	}
+	return ""
+}
+
+func missingColumns(columns map[string]int, required []string) []string {
+	var missing []string
+	for _, column := range required {
+		if _, ok := columns[column]; !ok {
+			missing = append(missing, column)
+		}
+	}
+	return missing
+}
+
+func firstLineOf(b []byte) string {
+	if i := bytes.IndexByte(b, '\n'); i >= 0 {
+		b = b[:i]
+	}
+	return string(b)
+}
+
+func isBlank(fields []string) bool {
+	for _, field := range fields {
+		if strings.TrimSpace(field) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// Anything this heavy is a typo (or a different column); it also wouldn't fit resistance_value's DECIMAL(8,3)
+const maxWeightKg = 10000
+
+// The spellings these apps use for each unit
+var unitAliases = map[string]string{
+	"kg": "kg", "kgs": "kg", "kilograms": "kg",
+	"lb": "lb", "lbs": "lb", "pounds": "lb",
+	"m": "m", "meter": "m", "meters": "m", "metres": "m",
+	"km": "km", "kms": "km", "kilometers": "km", "kilometres": "km",
+	"mi": "mi", "mile": "mi", "miles": "mi",
+	"yd": "yd", "yds": "yd", "yards": "yd",
+	"ft": "ft", "feet": "ft",
+}
+
+func normalizeUnit(unit string) string {
+	return unitAliases[strings.ToLower(strings.TrimSpace(unit))]
+}
+
+// Parses an optional number; "" is nil, as are zero & negative values since these apps log "0" for "not recorded"
+func (r row) number(column string) (*float64, error) {
+	value := r.get(column)
+	if value == "" {
+		return nil, nil
+	}
+	if r.decimal == ',' {
+		value = strings.Replace(value, ",", ".", 1)
+	}
+	parsed, err := strconv.ParseFloat(value, 64)
+	if err != nil || math.IsNaN(parsed) || math.IsInf(parsed, 0) {
+		return nil, fmt.Errorf("%s %q is not a number", column, r.get(column))
+	}
+	if parsed <= 0 {
+		return nil, nil
+	}
+	return &parsed, nil
+}
+
+func (r row) weightKg(column, unit string) (*float64, error) {
+	weight, err := r.number(column)
+	if err != nil || weight == nil {
+		return nil, err
+	}
+	kg := *weight
+	switch normalizeUnit(unit) {
+	case "kg":
+	case "lb":
+		kg *= utils.KgPerPound
+	default:
+		return nil, fmt.Errorf("unknown weight unit %q", unit)
+	}
+	if kg >= maxWeightKg {
+		return nil, fmt.Errorf("%s %q is too heavy to be real", column, r.get(column))
+	}
+	return &kg, nil
+}
+
+// Returns the distance in meters & the (normalised) unit it was logged in
+func (r row) distance(column, unit string) (*float64, string, error) {
+	distance, err := r.number(column)
+	if err != nil || distance == nil {
+		return nil, "", err
+	}
+	normalized := normalizeUnit(unit)
+	factor, ok := utils.MetersPerDistanceUnit[normalized]
+	if !ok {
+		return nil, "", fmt.Errorf("unknown distance unit %q", unit)
+	}
+	meters := *distance * factor
+	return &meters, normalized, nil
+}
+
+func (r row) reps(column string) (*int32, error) {
+	reps, err := r.number(column)
+	if err != nil || reps == nil {
+		return nil, err
+	}
+	if *reps != math.Trunc(*reps) || *reps > math.MaxInt32 {
+		return nil, fmt.Errorf("%s %q is not a whole number", column, r.get(column))
+	}
+	whole := int32(*reps)
+	return &whole, nil
+}
+
+func (r row) seconds(column string) (*int32, error) {
+	seconds, err := r.number(column)
+	if err != nil || seconds == nil {
+		return nil, err
+	}
+	rounded := int32(math.Round(math.Min(*seconds, math.MaxInt32)))
+	return &rounded, nil
+}
+
+// RPE outside 1-10 is dropped rather than failing the row; some apps write 0 for "not rated"
+func (r row) rpe(column string) *float64 {
+	rpe, err := r.number(column)
+	if err != nil || rpe == nil || *rpe < 1 || *rpe > 10 {
+		return nil
+	}
+	return rpe
+}
+
+// Parses a wall-clock timestamp in the first layout that fits
+func parseTime(value string, layouts []string, loc *time.Location) (time.Time, error) {
+	for _, layout := range layouts {
+		if t, err := time.ParseInLocation(layout, value, loc); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("unrecognised date %q", value)
+}
+
+func dateOf(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// Parses "h:mm:ss" or "mm:ss" into seconds
+func parseClockDuration(value string) (*int32, error) {
+	if value == "" {
+		return nil, nil
+	}
+	parts := strings.Split(value, ":")
+	if len(parts) > 3 {
+		return nil, fmt.Errorf("unrecognised time %q", value)
+	}
+	total := 0
+	for _, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 {
+			return nil, fmt.Errorf("unrecognised time %q", value)
+		}
+		total = total*60 + n
+	}
+	if total == 0 {
+		return nil, nil
+	}
+	seconds := int32(total)
+	return &seconds, nil
+}
@@ -0,0 +1,175 @@
+package importer
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+// One line per set with everything that was read, so expected sets read like the file they came from
+func describeSet(s Set) string {
+	parts := []string{s.ExerciseName, s.SetType}
+	if s.Reps != nil {
+		parts = append(parts, fmt.Sprintf("%d reps", *s.Reps))
+	}
+	if s.WeightKg != nil {
+		parts = append(parts, fmt.Sprintf("%.3f kg", *s.WeightKg))
+	}
+	if s.DistanceMeters != nil {
+		parts = append(parts, fmt.Sprintf("%.2f m (%s)", *s.DistanceMeters, s.DistanceUnit))
+	}
+	if s.DurationSeconds != nil {
+		parts = append(parts, fmt.Sprintf("%d s", *s.DurationSeconds))
+	}
+	if s.RPE != nil {
+		parts = append(parts, fmt.Sprintf("@%g", *s.RPE))
+	}
+	if s.Notes != "" {
+		parts = append(parts, fmt.Sprintf("%q", s.Notes))
+	}
+	return strings.Join(parts, ", ")
+}
+
+type wantWorkout struct {
+	date      string
+	title     string
+	startedAt string // RFC 3339, "" for none
+	endedAt   string
+	sets      []string // as describeSet writes them
+}
+
+func checkResult(t *testing.T, got Result, wantFormat Format, want []wantWorkout, wantWarnings []string) {
+	t.Helper()
+	if got.Format != wantFormat {
+		t.Errorf("Format = %q, want %q", got.Format, wantFormat)
+	}
+	if len(got.Workouts) != len(want) {
+		t.Fatalf("got %d workouts, want %d: %+v", len(got.Workouts), len(want), got.Workouts)
+	}
+	for i, workout := range got.Workouts {
+		w := want[i]
+		if date := workout.Date.Format("2006-01-02"); date != w.date || workout.Title != w.title {
+			t.Errorf("workout %d = %s %q, want %s %q", i, date, workout.Title, w.date, w.title)
+		}
+		if started := formatTimePtr(workout.StartedAt); started != w.startedAt {
+			t.Errorf("workout %d StartedAt = %q, want %q", i, started, w.startedAt)
+		}
+		if ended := formatTimePtr(workout.EndedAt); ended != w.endedAt {
+			t.Errorf("workout %d EndedAt = %q, want %q", i, ended, w.endedAt)
+		}
+		var sets []string
+		for _, set := range workout.Sets {
+			sets = append(sets, describeSet(set))
+		}
+		if strings.Join(sets, "\n") != strings.Join(w.sets, "\n") {
+			t.Errorf("workout %d sets:\n%s\nwant:\n%s", i, strings.Join(sets, "\n"), strings.Join(w.sets, "\n"))
+		}
+	}
+	if strings.Join(got.Warnings, "\n") != strings.Join(wantWarnings, "\n") {
+		t.Errorf("Warnings = %q, want %q", got.Warnings, wantWarnings)
+	}
+}
+
+func formatTimePtr(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
+
+func TestParseDetectsFormat(t *testing.T) {
+	tests := []struct {
+		name    string
+		header  string
+		want    Format
+		wantErr string
+	}{
+		{name: "strong", header: "Date,Workout Name,Duration,Exercise Name,Set Order,Weight,Reps", want: FormatStrong},
+		{name: "hevy", header: `"title","start_time","end_time","exercise_title","set_type","weight_kg","reps"`, want: FormatHevy},
+		{name: "fitnotes", header: "Date,Exercise,Category,Weight (kgs),Reps", want: FormatFitNotes},
+		{name: "header case & spacing don't matter", header: " date , EXERCISE ,Category,Reps", want: FormatFitNotes},
+		{name: "byte order mark", header: "\xef\xbb\xbfDate,Exercise,Category,Reps", want: FormatFitNotes},
+		{name: "semicolons", header: "Date;Exercise;Category;Reps", want: FormatFitNotes},
+		{name: "unrecognised", header: "when,what,how many", wantErr: "unrecognised file"},
+		{name: "empty", header: "", wantErr: "file is empty"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse(strings.NewReader(tt.header+"\n"), Options{})
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Parse() error = %v, want one containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+			if got.Format != tt.want {
+				t.Errorf("Parse() Format = %q, want %q", got.Format, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseChecksAGivenFormatsColumns(t *testing.T) {
+	csv := "Date,Exercise,Category,Reps\n2024-03-04,Pull Up,Back,12\n"
+	_, err := Parse(strings.NewReader(csv), Options{Format: FormatStrong})
+	if err == nil || !strings.Contains(err.Error(), "missing column(s): workout name, exercise name, set order") {
+		t.Errorf("Parse() error = %v, want the missing Strong columns", err)
+	}
+}
+
+func TestParseCapsWarnings(t *testing.T) {
+	var b strings.Builder
+	b.WriteString("Date,Exercise,Category,Reps\n")
+	for i := 0; i < maxWarnings+3; i++ {
+		b.WriteString("yesterday,Pull Up,Back,12\n")
+	}
+
+	got, err := Parse(strings.NewReader(b.String()), Options{})
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if len(got.Warnings) != maxWarnings+1 {
+		t.Fatalf("got %d warnings, want %d", len(got.Warnings), maxWarnings+1)
+	}
+	if first, last := got.Warnings[0], got.Warnings[maxWarnings]; first != `line 2: unrecognised date "yesterday"` || last != "...and 3 more rows with problems" {
+		t.Errorf("Warnings start with %q & end with %q", first, last)
+	}
+}
+
+func TestParseClockDuration(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    int32 // 0 for none
+		wantErr bool
+	}{
+		{value: "", want: 0},
+		{value: "0:00:00", want: 0},
+		{value: "45", want: 45},
+		{value: "1:30", want: 90},
+		{value: "1:02:03", want: 3723},
+		{value: "1:2:3:4", wantErr: true},
+		{value: "1:-30", wantErr: true},
+		{value: "half an hour", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := parseClockDuration(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseClockDuration(%q) error = %v, wantErr %v", tt.value, err, tt.wantErr)
+			}
+			seconds := int32(0)
+			if got != nil {
+				seconds = *got
+			}
+			if seconds != tt.want {
+				t.Errorf("parseClockDuration(%q) = %d, want %d", tt.value, seconds, tt.want)
+			}
+		})
+	}
+}
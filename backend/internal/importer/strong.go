@@ -0,0 +1,98 @@
+package importer
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+/*
+Strong: one row per set, in workout order.
+Date,Workout Name,Duration,Exercise Name,Set Order,Weight,Reps,Distance,Seconds,Notes,Workout Notes,RPE
+2024-03-04 18:02:11,"Push Day",1h 5m,"Bench Press (Barbell)",1,60,8,0,0,,,8
+Newer exports add "Weight Unit" & "Distance Unit" columns; older ones are in whatever units the app was set to.
+*/
+
+var strongDateLayouts = []string{"2006-01-02 15:04:05", "2006-01-02 15:04", "2006-01-02"}
+
+var strongDurationPart = regexp.MustCompile(`(\d+)\s*([hms])`)
+
+// "W" = warm-up, "D" = drop set, "F" = failure; plain numbers are working sets
+var strongSetTypes = map[string]string{"w": "warmup", "d": "drop", "f": "failure"}
+
+func parseStrongRow(r row, opts Options) (parsedRow, error) {
+	setOrder := strings.ToLower(r.get("set order"))
+	if setOrder == "rest timer" || strings.ToLower(r.get("exercise name")) == "rest timer" {
+		return parsedRow{skip: true}, nil
+	}
+
+	exercise := r.get("exercise name")
+	if exercise == "" {
+		return parsedRow{}, fmt.Errorf("missing exercise name")
+	}
+
+	started, err := parseTime(r.get("date"), strongDateLayouts, opts.Location)
+	if err != nil {
+		return parsedRow{}, err
+	}
+
+	setType := "working"
+	if mapped, ok := strongSetTypes[setOrder]; ok {
+		setType = mapped
+	} else if _, err := strconv.Atoi(setOrder); err != nil {
+		return parsedRow{}, fmt.Errorf("unrecognised set order %q", r.get("set order"))
+	}
+
+	weightUnit, distanceUnit := opts.WeightUnit, opts.DistanceUnit
+	if unit := r.get("weight unit"); unit != "" {
+		weightUnit = unit
+	}
+	if unit := r.get("distance unit"); unit != "" {
+		distanceUnit = unit
+	}
+
+	set := Set{ExerciseName: exercise, SetType: setType, Notes: r.get("notes"), RPE: r.rpe("rpe")}
+	if set.WeightKg, err = r.weightKg("weight", weightUnit); err != nil {
+		return parsedRow{}, err
+	}
+	if set.Reps, err = r.reps("reps"); err != nil {
+		return parsedRow{}, err
+	}
+	if set.DistanceMeters, set.DistanceUnit, err = r.distance("distance", distanceUnit); err != nil {
+		return parsedRow{}, err
+	}
+	if set.DurationSeconds, err = r.seconds("seconds"); err != nil {
+		return parsedRow{}, err
+	}
+
+	workout := Workout{Date: dateOf(started), Title: r.get("workout name")}
+	if r.get("date") != started.Format("2006-01-02") {
+		// an imported workout is over; with no end it would look like a session still in progress
+		ended := started
+		if duration := parseStrongDuration(r.get("duration")); duration > 0 {
+			ended = started.Add(duration)
+		}
+		workout.StartedAt, workout.EndedAt = &started, &ended
+	}
+
+	return parsedRow{key: r.get("date") + "|" + workout.Title, workout: workout, set: set}, nil
+}
+
+// Parses Strong's "1h 5m" / "45m" / "30s" durations; anything else counts as unknown
+func parseStrongDuration(value string) time.Duration {
+	var duration time.Duration
+	for _, match := range strongDurationPart.FindAllStringSubmatch(value, -1) {
+		n, _ := strconv.Atoi(match[1])
+		switch match[2] {
+		case "h":
+			duration += time.Duration(n) * time.Hour
+		case "m":
+			duration += time.Duration(n) * time.Minute
+		case "s":
+			duration += time.Duration(n) * time.Second
+		}
+	}
+	return duration
+}
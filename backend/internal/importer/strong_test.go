@@ -0,0 +1,158 @@
+package importer
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseStrong(t *testing.T) {
+	tests := []struct {
+		name         string
+		csv          string
+		opts         Options
+		want         []wantWorkout
+		wantWarnings []string
+	}{
+		{
+			name: "set types, rest timers & cardio",
+			csv: `Date,Workout Name,Duration,Exercise Name,Set Order,Weight,Reps,Distance,Seconds,Notes,Workout Notes,RPE
+2024-03-04 18:02:11,"Push Day",1h 5m,"Bench Press (Barbell)",W,40,10,0,0,,,
+2024-03-04 18:02:11,"Push Day",1h 5m,"Bench Press (Barbell)",1,60,8,0,0,felt good,,8
+2024-03-04 18:02:11,"Push Day",1h 5m,"Bench Press (Barbell)",D,45,10,0,0,,,
+2024-03-04 18:02:11,"Push Day",1h 5m,"Rest Timer",Rest Timer,0,0,0,90,,,
+2024-03-04 18:02:11,"Push Day",1h 5m,"Running",1,0,0,5,1500,,,
+2024-03-02 09:00:00,"Legs",45m,"Squat (Barbell)",1,100,5,0,0,,,11
+2024-03-02 09:00:00,"Legs",45m,"Squat (Barbell)",X,100,5,0,0,,,
+`,
+			opts: Options{DistanceUnit: "km"},
+			want: []wantWorkout{
+				{
+					date: "2024-03-02", title: "Legs",
+					startedAt: "2024-03-02T09:00:00Z", endedAt: "2024-03-02T09:45:00Z",
+					sets: []string{"Squat (Barbell), working, 5 reps, 100.000 kg"},
+				},
+				{
+					date: "2024-03-04", title: "Push Day",
+					startedAt: "2024-03-04T18:02:11Z", endedAt: "2024-03-04T19:07:11Z",
+					sets: []string{
+						"Bench Press (Barbell), warmup, 10 reps, 40.000 kg",
+						`Bench Press (Barbell), working, 8 reps, 60.000 kg, @8, "felt good"`,
+						"Bench Press (Barbell), drop, 10 reps, 45.000 kg",
+						"Running, working, 5000.00 m (km), 1500 s",
+					},
+				},
+			},
+			wantWarnings: []string{`line 8: unrecognised set order "X"`},
+		},
+		{
+			name: "semicolons with comma decimals & per-row units",
+			csv: `Date;Workout Name;Duration;Exercise Name;Set Order;Weight;Reps;Distance;Seconds;Notes;Workout Notes;RPE;Weight Unit;Distance Unit
+2024-03-04 18:02:11;Push Day;1h;Bench Press (Barbell);1;82,5;8;0;0;;;7,5;kg;km
+2024-03-04 18:02:11;Push Day;1h;Bench Press (Barbell);D;100;8;0;0;;;;lbs;km
+2024-03-04 18:02:11;Push Day;1h;Rowing (Machine);1;0;0;1,5;420;;;;kg;mi
+`,
+			opts: Options{WeightUnit: "kg"},
+			want: []wantWorkout{
+				{
+					date: "2024-03-04", title: "Push Day",
+					startedAt: "2024-03-04T18:02:11Z", endedAt: "2024-03-04T19:02:11Z",
+					sets: []string{
+						"Bench Press (Barbell), working, 8 reps, 82.500 kg, @7.5",
+						"Bench Press (Barbell), drop, 8 reps, 45.359 kg",
+						"Rowing (Machine), working, 2414.02 m (mi), 420 s",
+					},
+				},
+			},
+		},
+		{
+			name: "no duration ends the workout when it started",
+			csv: `Date,Workout Name,Duration,Exercise Name,Set Order,Weight,Reps,Distance,Seconds,Notes,Workout Notes,RPE
+2024-03-04 18:02:11,Push Day,,Bench Press (Barbell),1,60,8,0,0,,,
+2024-03-05 07:30:00,Pull Day,0s,Pull Up,1,0,12,0,0,,,
+`,
+			want: []wantWorkout{
+				{
+					date: "2024-03-04", title: "Push Day",
+					startedAt: "2024-03-04T18:02:11Z", endedAt: "2024-03-04T18:02:11Z",
+					sets: []string{"Bench Press (Barbell), working, 8 reps, 60.000 kg"},
+				},
+				{
+					date: "2024-03-05", title: "Pull Day",
+					startedAt: "2024-03-05T07:30:00Z", endedAt: "2024-03-05T07:30:00Z",
+					sets: []string{"Pull Up, working, 12 reps"},
+				},
+			},
+		},
+		{
+			name: "older exports use the units asked for & may have no times",
+			csv: `Date,Workout Name,Duration,Exercise Name,Set Order,Weight,Reps,Distance,Seconds,Notes,Workout Notes,RPE
+2024-03-04,Push Day,,Bench Press (Barbell),1,135,8,0,0,,,
+2024-03-04,Push Day,,Bench Press (Barbell),2,heavy,8,0,0,,,
+2024-03-04,Push Day,,Bench Press (Barbell),3,0,0,0,0,,,
+`,
+			opts: Options{WeightUnit: "lb"},
+			want: []wantWorkout{
+				{
+					date: "2024-03-04", title: "Push Day",
+					sets: []string{"Bench Press (Barbell), working, 8 reps, 61.235 kg"},
+				},
+			},
+			wantWarnings: []string{
+				`line 3: weight "heavy" is not a number`,
+				"line 4: no reps, weight, distance or time; skipped",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse(strings.NewReader(tt.csv), tt.opts)
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+			checkResult(t, got, FormatStrong, tt.want, tt.wantWarnings)
+		})
+	}
+}
+
+func TestParseStrongInLocation(t *testing.T) {
+	location, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("no tzdata: %v", err)
+	}
+	csv := `Date,Workout Name,Duration,Exercise Name,Set Order,Weight,Reps,Distance,Seconds,Notes,Workout Notes,RPE
+2024-03-04 21:30:00,Late Push,30m,Bench Press (Barbell),1,60,8,0,0,,,
+`
+
+	got, err := Parse(strings.NewReader(csv), Options{Location: location})
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	// the date stays the local one even though it's already the 5th in UTC
+	checkResult(t, got, FormatStrong, []wantWorkout{{
+		date: "2024-03-04", title: "Late Push",
+		startedAt: "2024-03-04T21:30:00-05:00", endedAt: "2024-03-04T22:00:00-05:00",
+		sets: []string{"Bench Press (Barbell), working, 8 reps, 60.000 kg"},
+	}}, nil)
+}
+
+func TestParseStrongDuration(t *testing.T) {
+	tests := []struct {
+		value string
+		want  time.Duration
+	}{
+		{value: "1h 5m", want: time.Hour + 5*time.Minute},
+		{value: "45m", want: 45 * time.Minute},
+		{value: "30s", want: 30 * time.Second},
+		{value: "2h", want: 2 * time.Hour},
+		{value: "", want: 0},
+		{value: "a while", want: 0},
+	}
+
+	for _, tt := range tests {
+		if got := parseStrongDuration(tt.value); got != tt.want {
+			t.Errorf("parseStrongDuration(%q) = %v, want %v", tt.value, got, tt.want)
+		}
+	}
+}
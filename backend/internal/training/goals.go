@@ -0,0 +1,100 @@
+package training
+
+import (
+	"math"
+	"time"
+)
+
+// Projections further out than this are dropped; a near-flat trend would otherwise "reach" the target decades from now
+const maxProjectionDays = 3 * 365
+
+// Whether current has reached target, coming from start. A target below the start (e.g. cutting bodyweight) is
+// reached from above.
+func GoalReached(start, current, target float64) bool {
+	if target < start {
+		return current <= target
+	}
+	return current >= target
+}
+
+// How far current has moved from start towards target, as a percentage clamped to 0-100
+func GoalProgress(start, current, target float64) float64 {
+	if target == start {
+		if GoalReached(start, current, target) {
+			return 100
+		}
+		return 0
+	}
+	percent := (current - start) / (target - start) * 100
+	return round2(math.Min(math.Max(percent, 0), 100))
+}
+
+// The date the recent trend reaches target if it carries on at the same rate. False when there's no trend yet, or it's
+// flat, heading away from the target, or too slow to get there within a few years. points must be oldest first.
+func ProjectGoalDate(points []TrendPoint, current, target float64, today time.Time) (time.Time, bool) {
+	perWeek, ok := WeeklyTrend(points)
+	if !ok || perWeek == 0 {
+		return time.Time{}, false
+	}
+
+	remaining := target - current
+	if remaining == 0 {
+		return today, true
+	}
+	if (remaining > 0) != (perWeek > 0) {
+		return time.Time{}, false
+	}
+
+	days := math.Ceil(remaining / perWeek * 7)
+	if days > maxProjectionDays {
+		return time.Time{}, false
+	}
+	return today.AddDate(0, 0, int(days)), true
+}
+
+// Where a "N sessions a week" goal stands, in 7-day blocks counted from its start date
+type SessionBlocks struct {
+	Total           int
+	Met             int  // blocks that reached the target
+	Missed          bool // a block ended short of the target, so the goal can no longer be completed
+	CurrentSessions int  // sessions so far in the block today falls in
+}
+
+// Splits start..deadline into 7-day blocks (the last one runs on to the deadline) and counts the sessions in each.
+// days must have one entry per date.
+func SessionGoalBlocks(days []TrainingDay, target int, start, deadline, today time.Time) SessionBlocks {
+	total := max((daysBetween(start, deadline)+1)/7, 1) // leftover days join the last block
+
+	counts := make([]int, total)
+	for _, day := range days {
+		if day.Date.Before(start) || day.Date.After(deadline) {
+			continue
+		}
+		counts[blockIndex(start, day.Date, total)] += day.Sessions
+	}
+
+	blocks := SessionBlocks{Total: total}
+	for i, count := range counts {
+		blockEnd := start.AddDate(0, 0, (i+1)*7-1)
+		if i == total-1 || blockEnd.After(deadline) {
+			blockEnd = deadline
+		}
+		if count >= target {
+			blocks.Met++
+		} else if blockEnd.Before(today) {
+			blocks.Missed = true
+		}
+	}
+	if !today.Before(start) && !today.After(deadline) {
+		blocks.CurrentSessions = counts[blockIndex(start, today, total)]
+	}
+	return blocks
+}
+
+func blockIndex(start, date time.Time, total int) int {
+	return min(daysBetween(start, date)/7, total-1)
+}
+
+func daysBetween(from, to time.Time) int {
+	return int(math.Round(to.Sub(from).Hours() / 24))
+}
@@ -0,0 +1,167 @@
+package training
+
+import (
+	"testing"
+	"time"
+)
+
+// A date in March 2026; days past the 31st roll over into April
+func march(day int) time.Time {
+	return time.Date(2026, 3, day, 0, 0, 0, 0, time.UTC)
+}
+
+func TestGoalProgress(t *testing.T) {
+	tests := []struct {
+		name                   string
+		start, current, target float64
+		wantProgress           float64
+		wantReached            bool
+	}{
+		{name: "halfway up", start: 100, current: 110, target: 120, wantProgress: 50},
+		{name: "halfway down", start: 90, current: 85, target: 80, wantProgress: 50},
+		{name: "past the target", start: 100, current: 130, target: 120, wantProgress: 100, wantReached: true},
+		{name: "past a lower target", start: 90, current: 78, target: 80, wantProgress: 100, wantReached: true},
+		{name: "moving away", start: 100, current: 95, target: 120, wantProgress: 0},
+		{name: "target is the start", start: 100, current: 100, target: 100, wantProgress: 100, wantReached: true},
+		{name: "below a target that was the start", start: 100, current: 99, target: 100, wantProgress: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := GoalProgress(tt.start, tt.current, tt.target); got != tt.wantProgress {
+				t.Errorf("GoalProgress() = %v, want %v", got, tt.wantProgress)
+			}
+			if got := GoalReached(tt.start, tt.current, tt.target); got != tt.wantReached {
+				t.Errorf("GoalReached() = %v, want %v", got, tt.wantReached)
+			}
+		})
+	}
+}
+
+func TestProjectGoalDate(t *testing.T) {
+	today := march(15)
+
+	tests := []struct {
+		name            string
+		points          []TrendPoint
+		current, target float64
+		want            time.Time
+		wantOK          bool
+	}{
+		{
+			name:    "on trend",
+			points:  []TrendPoint{{march(1), 80}, {march(8), 79}},
+			current: 79, target: 75,
+			want: march(15 + 28), wantOK: true,
+		},
+		{
+			name:    "part days round up",
+			points:  []TrendPoint{{march(1), 80}, {march(8), 78.5}},
+			current: 78.5, target: 74.5,
+			want: march(15 + 19), wantOK: true,
+		},
+		{
+			name:    "already there",
+			points:  []TrendPoint{{march(1), 80}, {march(8), 79}},
+			current: 75, target: 75,
+			want: today, wantOK: true,
+		},
+		{
+			name:    "heading away",
+			points:  []TrendPoint{{march(1), 80}, {march(8), 79}},
+			current: 79, target: 85,
+		},
+		{
+			name:    "flat",
+			points:  []TrendPoint{{march(1), 80}, {march(8), 80}},
+			current: 80, target: 75,
+		},
+		{
+			name:    "one reading",
+			points:  []TrendPoint{{march(8), 80}},
+			current: 80, target: 75,
+		},
+		{
+			name:    "too slow",
+			points:  []TrendPoint{{march(1), 80}, {march(8), 79.99}},
+			current: 79.99, target: 75,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := ProjectGoalDate(tt.points, tt.current, tt.target, today)
+			if ok != tt.wantOK || !got.Equal(tt.want) {
+				t.Errorf("ProjectGoalDate() = %v, %v; want %v, %v", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestSessionGoalBlocks(t *testing.T) {
+	sessions := func(counts map[int]int) []TrainingDay {
+		var days []TrainingDay
+		for day, count := range counts {
+			days = append(days, TrainingDay{Date: march(day), Sessions: count})
+		}
+		return days
+	}
+
+	tests := []struct {
+		name            string
+		days            []TrainingDay
+		start, deadline time.Time
+		today           time.Time
+		want            SessionBlocks
+	}{
+		{
+			name:  "whole weeks, every block met",
+			days:  sessions(map[int]int{2: 1, 4: 1, 6: 1, 9: 3, 16: 1, 18: 2, 23: 1, 25: 1, 29: 1}),
+			start: march(2), deadline: march(29), today: march(30),
+			want: SessionBlocks{Total: 4, Met: 4},
+		},
+		{
+			name:  "leftover days join the last block",
+			days:  sessions(map[int]int{2: 3, 23: 1, 30: 1, 31: 1}),
+			start: march(2), deadline: march(31), today: march(31),
+			want: SessionBlocks{Total: 4, Met: 2, Missed: true, CurrentSessions: 3},
+		},
+		{
+			name:  "a block short of the target is missed once it's over",
+			days:  sessions(map[int]int{2: 3, 10: 1, 16: 2}),
+			start: march(2), deadline: march(29), today: march(18),
+			want: SessionBlocks{Total: 4, Met: 1, Missed: true, CurrentSessions: 2},
+		},
+		{
+			name:  "the current block isn't missed on its last day",
+			days:  sessions(map[int]int{2: 1, 8: 1}),
+			start: march(2), deadline: march(29), today: march(8),
+			want: SessionBlocks{Total: 4, CurrentSessions: 2},
+		},
+		{
+			name:  "sessions outside the goal don't count",
+			days:  sessions(map[int]int{1: 3, 2: 1, 30: 3}),
+			start: march(2), deadline: march(29), today: march(3),
+			want: SessionBlocks{Total: 4, CurrentSessions: 1},
+		},
+		{
+			name:  "shorter than a week is one block",
+			days:  sessions(map[int]int{2: 1, 3: 1, 4: 1}),
+			start: march(2), deadline: march(4), today: march(4),
+			want: SessionBlocks{Total: 1, Met: 1, CurrentSessions: 3},
+		},
+		{
+			name:  "not started yet",
+			start: march(2), deadline: march(29), today: march(1),
+			want: SessionBlocks{Total: 4},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SessionGoalBlocks(tt.days, 3, tt.start, tt.deadline, tt.today); got != tt.want {
+				t.Errorf("SessionGoalBlocks() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,97 @@
+package training
+
+import (
+	"math"
+	"time"
+)
+
+const (
+	AcuteWindowDays   = 7
+	ChronicWindowDays = 28
+)
+
+// One day's training load; days with no training can simply be left out
+type DailyLoad struct {
+	Date time.Time
+	Load float64
+}
+
+type LoadPoint struct {
+	Date    time.Time
+	Load    float64
+	Acute   float64  // load summed over the last 7 days
+	Chronic float64  // average weekly load over the last 28 days
+	ACWR    *float64 // acute / chronic; nil while there's no chronic load
+	// Foster's monotony (mean / standard deviation of the last 7 daily loads) & strain (weekly load x monotony);
+	// nil when every one of the 7 days had the same load
+	Monotony *float64
+	Strain   *float64
+}
+
+// Builds a day-by-day series from "from" to "to". loads must include the 27 days before "from" for the first points'
+// chronic load to be complete.
+func LoadSeries(loads []DailyLoad, from, to time.Time) []LoadPoint {
+	byDate := make(map[string]float64, len(loads))
+	for _, daily := range loads {
+		byDate[daily.Date.Format("2006-01-02")] += daily.Load
+	}
+	loadOn := func(day time.Time) float64 {
+		return byDate[day.Format("2006-01-02")]
+	}
+
+	var series []LoadPoint
+	for day := from; !day.After(to); day = day.AddDate(0, 0, 1) {
+		week := make([]float64, AcuteWindowDays)
+		for i := range week {
+			week[i] = loadOn(day.AddDate(0, 0, -i))
+		}
+		acute := sum(week)
+
+		chronicTotal := acute
+		for i := AcuteWindowDays; i < ChronicWindowDays; i++ {
+			chronicTotal += loadOn(day.AddDate(0, 0, -i))
+		}
+		chronic := chronicTotal / (ChronicWindowDays / AcuteWindowDays)
+
+		point := LoadPoint{
+			Date:    day,
+			Load:    week[0],
+			Acute:   round2(acute),
+			Chronic: round2(chronic),
+		}
+		if chronic > 0 {
+			acwr := round2(acute / chronic)
+			point.ACWR = &acwr
+		}
+		if sd := stddev(week); sd > 0 {
+			monotony := round2(acute / AcuteWindowDays / sd)
+			strain := round2(acute * monotony)
+			point.Monotony = &monotony
+			point.Strain = &strain
+		}
+		series = append(series, point)
+	}
+	return series
+}
+
+func sum(values []float64) float64 {
+	total := 0.0
+	for _, value := range values {
+		total += value
+	}
+	return total
+}
+
+// Population standard deviation
+func stddev(values []float64) float64 {
+	mean := sum(values) / float64(len(values))
+	variance := 0.0
+	for _, value := range values {
+		variance += (value - mean) * (value - mean)
+	}
+	return math.Sqrt(variance / float64(len(values)))
+}
+
+func round2(value float64) float64 {
+	return math.Round(value*100) / 100
+}
@@ -0,0 +1,110 @@
+package training
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLoadSeries(t *testing.T) {
+	day := time.Date(2026, 3, 31, 0, 0, 0, 0, time.UTC)
+	daysBefore := func(n int, load float64) DailyLoad {
+		return DailyLoad{Date: day.AddDate(0, 0, -n), Load: load}
+	}
+	ratio := func(value float64) *float64 { return &value }
+
+	var steady []DailyLoad
+	for i := 0; i < ChronicWindowDays; i++ {
+		steady = append(steady, daysBefore(i, 100))
+	}
+
+	tests := []struct {
+		name  string
+		loads []DailyLoad
+		want  LoadPoint
+	}{
+		{
+			name: "no training",
+			want: LoadPoint{},
+		},
+		{
+			name:  "steady load has an acwr of 1 & no monotony",
+			loads: steady,
+			want:  LoadPoint{Load: 100, Acute: 700, Chronic: 700, ACWR: ratio(1)},
+		},
+		{
+			name:  "one big session",
+			loads: []DailyLoad{daysBefore(0, 700)},
+			want:  LoadPoint{Load: 700, Acute: 700, Chronic: 175, ACWR: ratio(4), Monotony: ratio(0.41), Strain: ratio(287)},
+		},
+		{
+			name:  "every other day",
+			loads: []DailyLoad{daysBefore(0, 100), daysBefore(2, 100), daysBefore(4, 100), daysBefore(6, 100)},
+			want:  LoadPoint{Load: 100, Acute: 400, Chronic: 100, ACWR: ratio(4), Monotony: ratio(1.15), Strain: ratio(460)},
+		},
+		{
+			name:  "loads on the same day are summed",
+			loads: []DailyLoad{daysBefore(0, 300), daysBefore(0, 400)},
+			want:  LoadPoint{Load: 700, Acute: 700, Chronic: 175, ACWR: ratio(4), Monotony: ratio(0.41), Strain: ratio(287)},
+		},
+		{
+			name:  "chronic load only counts the last 28 days",
+			loads: []DailyLoad{daysBefore(10, 400), daysBefore(27, 400), daysBefore(28, 4000)},
+			want:  LoadPoint{Chronic: 200, ACWR: ratio(0)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			series := LoadSeries(tt.loads, day, day)
+			if len(series) != 1 {
+				t.Fatalf("LoadSeries() returned %d points, want 1", len(series))
+			}
+			got := series[0]
+
+			if !got.Date.Equal(day) || got.Load != tt.want.Load || got.Acute != tt.want.Acute || got.Chronic != tt.want.Chronic {
+				t.Errorf("LoadSeries() = %v load %v, acute %v, chronic %v; want %v load %v, acute %v, chronic %v",
+					got.Date, got.Load, got.Acute, got.Chronic, day, tt.want.Load, tt.want.Acute, tt.want.Chronic)
+			}
+			checkRatio(t, "ACWR", got.ACWR, tt.want.ACWR)
+			checkRatio(t, "Monotony", got.Monotony, tt.want.Monotony)
+			checkRatio(t, "Strain", got.Strain, tt.want.Strain)
+		})
+	}
+}
+
+func TestLoadSeriesCoversEveryDay(t *testing.T) {
+	from := time.Date(2026, 2, 26, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2026, 3, 3, 0, 0, 0, 0, time.UTC)
+
+	series := LoadSeries([]DailyLoad{{Date: from.AddDate(0, 0, 1), Load: 50}}, from, to)
+	if len(series) != 6 {
+		t.Fatalf("LoadSeries() returned %d points, want 6", len(series))
+	}
+	for i, point := range series {
+		if want := from.AddDate(0, 0, i); !point.Date.Equal(want) {
+			t.Errorf("point %d is for %v, want %v", i, point.Date, want)
+		}
+	}
+	// the load stays in the acute window for a week
+	if series[1].Load != 50 || series[5].Acute != 50 {
+		t.Errorf("got load %v on day 1 & acute %v on day 5, want 50 & 50", series[1].Load, series[5].Acute)
+	}
+}
+
+func checkRatio(t *testing.T, name string, got, want *float64) {
+	t.Helper()
+	switch {
+	case got == nil && want == nil:
+	case got == nil || want == nil:
+		t.Errorf("%s = %v, want %v", name, deref(got), deref(want))
+	case *got != *want:
+		t.Errorf("%s = %v, want %v", name, *got, *want)
+	}
+}
+
+func deref(value *float64) any {
+	if value == nil {
+		return nil
+	}
+	return *value
+}
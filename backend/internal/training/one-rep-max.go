@@ -0,0 +1,97 @@
+// Training math shared by the analytics endpoints: 1RM estimation, etc.
+package training
+
+import (
+	"fmt"
+	"math"
+)
+
+type Formula string
+
+const (
+	FormulaEpley    Formula = "epley"
+	FormulaBrzycki  Formula = "brzycki"
+	FormulaLombardi Formula = "lombardi"
+	FormulaWathan   Formula = "wathan"
+	FormulaRPE      Formula = "rpe" // RTS chart; needs the set's RPE
+)
+
+// Brzycki is what the exercise_one_rm view has always used
+const DefaultFormula = FormulaBrzycki
+
+var Formulas = []Formula{FormulaEpley, FormulaBrzycki, FormulaLombardi, FormulaWathan, FormulaRPE}
+
+func ParseFormula(s string) (Formula, error) {
+	if s == "" {
+		return DefaultFormula, nil
+	}
+	for _, f := range Formulas {
+		if Formula(s) == f {
+			return f, nil
+		}
+	}
+	return "", fmt.Errorf("formula must be one of 'epley', 'brzycki', 'lombardi', 'wathan', 'rpe'")
+}
+
+// RTS RPE chart flattened into one sequence of %1RM values. Each half point of RPE below 10 costs the same as one extra rep,
+// so the percentage for (reps, rpe) sits at index 2*(reps-1) + 2*(10-rpe). Covers 1-12 reps at RPE 6.5-10.
+var rtsPercentages = []float64{
+	100, 97.8, 95.5, 93.9, 92.2, 90.7, 89.2, 87.8, 86.3, 85.0,
+	83.7, 82.4, 81.1, 79.9, 78.6, 77.4, 76.2, 75.1, 73.9, 72.3,
+	70.7, 69.4, 68.0, 66.7, 65.3, 64.0, 62.6, 61.3, 59.9, 58.6,
+}
+
+const (
+	rtsMaxReps = 12
+	rtsMinRPE  = 6.5
+)
+
+// Estimates a 1RM from a single set. rpe is only used (and required) by FormulaRPE. A single rep to failure is its own 1RM
+// for every formula except RPE, which still discounts it by how many reps were left in the tank.
+func EstimateOneRepMax(formula Formula, weight float64, reps int32, rpe *float64) (float64, error) {
+	if weight <= 0 || reps <= 0 {
+		return 0, fmt.Errorf("weight and reps must be greater than 0")
+	}
+	if reps == 1 && formula != FormulaRPE {
+		return weight, nil
+	}
+
+	r := float64(reps)
+	switch formula {
+	case FormulaEpley:
+		return weight * (1 + r/30), nil
+	case FormulaBrzycki:
+		if reps >= 37 {
+			return 0, fmt.Errorf("brzycki is undefined for 37+ reps")
+		}
+		return weight * 36 / (37 - r), nil
+	case FormulaLombardi:
+		return weight * math.Pow(r, 0.10), nil
+	case FormulaWathan:
+		return 100 * weight / (48.8 + 53.8*math.Exp(-0.075*r)), nil
+	case FormulaRPE:
+		if rpe == nil {
+			return 0, fmt.Errorf("the rpe formula needs the set's RPE")
+		}
+		percentage, err := rtsPercentage(reps, *rpe)
+		if err != nil {
+			return 0, err
+		}
+		return weight / (percentage / 100), nil
+	default:
+		return 0, fmt.Errorf("unknown formula %q", formula)
+	}
+}
+
+func rtsPercentage(reps int32, rpe float64) (float64, error) {
+	rpe = math.Round(rpe*2) / 2 // chart only goes in half points
+	if reps > rtsMaxReps {
+		return 0, fmt.Errorf("the RPE chart only covers up to %d reps", rtsMaxReps)
+	}
+	if rpe < rtsMinRPE || rpe > 10 {
+		return 0, fmt.Errorf("the RPE chart only covers RPE %.1f-10", rtsMinRPE)
+	}
+
+	index := 2*int(reps-1) + int(2*(10-rpe))
+	return rtsPercentages[index], nil
+}
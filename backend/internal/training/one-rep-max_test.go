@@ -0,0 +1,123 @@
+package training
+
+import (
+	"math"
+	"testing"
+)
+
+func TestEstimateOneRepMax(t *testing.T) {
+	rpe := func(value float64) *float64 { return &value }
+
+	tests := []struct {
+		name    string
+		formula Formula
+		weight  float64
+		reps    int32
+		rpe     *float64
+		want    float64
+		wantErr bool
+	}{
+		{name: "epley", formula: FormulaEpley, weight: 100, reps: 5, want: 116.67},
+		{name: "brzycki", formula: FormulaBrzycki, weight: 100, reps: 5, want: 112.5},
+		{name: "lombardi", formula: FormulaLombardi, weight: 100, reps: 5, want: 117.46},
+		{name: "wathan", formula: FormulaWathan, weight: 100, reps: 5, want: 116.58},
+		{name: "single rep is its own 1RM", formula: FormulaEpley, weight: 140, reps: 1, want: 140},
+		{name: "single rep ignores the formula", formula: FormulaWathan, weight: 140, reps: 1, want: 140},
+		{name: "brzycki at 36 reps", formula: FormulaBrzycki, weight: 100, reps: 36, want: 3600},
+		{name: "brzycki caps at 37 reps", formula: FormulaBrzycki, weight: 100, reps: 37, wantErr: true},
+		{name: "rpe", formula: FormulaRPE, weight: 100, reps: 5, rpe: rpe(8), want: 123.3},
+		{name: "rpe single rep at 10", formula: FormulaRPE, weight: 100, reps: 1, rpe: rpe(10), want: 100},
+		{name: "rpe still discounts a single rep", formula: FormulaRPE, weight: 97.8, reps: 1, rpe: rpe(9.5), want: 100},
+		{name: "rpe at the chart's last cell", formula: FormulaRPE, weight: 100, reps: 12, rpe: rpe(6.5), want: 170.65},
+		{name: "rpe caps at 12 reps", formula: FormulaRPE, weight: 100, reps: 13, rpe: rpe(8), wantErr: true},
+		{name: "rpe below the chart", formula: FormulaRPE, weight: 100, reps: 5, rpe: rpe(6), wantErr: true},
+		{name: "rpe above the chart", formula: FormulaRPE, weight: 100, reps: 5, rpe: rpe(10.5), wantErr: true},
+		{name: "rpe needs an rpe", formula: FormulaRPE, weight: 100, reps: 5, wantErr: true},
+		{name: "no weight", formula: FormulaEpley, weight: 0, reps: 5, wantErr: true},
+		{name: "no reps", formula: FormulaEpley, weight: 100, reps: 0, wantErr: true},
+		{name: "unknown formula", formula: "mayhew", weight: 100, reps: 5, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := EstimateOneRepMax(tt.formula, tt.weight, tt.reps, tt.rpe)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("EstimateOneRepMax() = %v, want an error", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("EstimateOneRepMax() error = %v", err)
+			}
+			if math.Abs(got-tt.want) > 0.01 {
+				t.Errorf("EstimateOneRepMax() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRTSPercentage(t *testing.T) {
+	tests := []struct {
+		name    string
+		reps    int32
+		rpe     float64
+		want    float64
+		wantErr bool
+	}{
+		{name: "1 @ 10", reps: 1, rpe: 10, want: 100},
+		{name: "1 @ 9.5", reps: 1, rpe: 9.5, want: 97.8},
+		{name: "a rep costs the same as half an rpe point", reps: 2, rpe: 10, want: 95.5},
+		{name: "5 @ 8", reps: 5, rpe: 8, want: 81.1},
+		{name: "8 @ 7", reps: 8, rpe: 7, want: 70.7},
+		{name: "12 @ 6.5", reps: 12, rpe: 6.5, want: 58.6},
+		{name: "rounds to the nearest half point", reps: 5, rpe: 7.8, want: 81.1},
+		{name: "13 reps", reps: 13, rpe: 10, wantErr: true},
+		{name: "rpe 6", reps: 5, rpe: 6, wantErr: true},
+		{name: "rpe 10.5", reps: 5, rpe: 10.5, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := rtsPercentage(tt.reps, tt.rpe)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("rtsPercentage() = %v, want an error", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("rtsPercentage() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("rtsPercentage() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseFormula(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    Formula
+		wantErr bool
+	}{
+		{input: "", want: DefaultFormula},
+		{input: "epley", want: FormulaEpley},
+		{input: "rpe", want: FormulaRPE},
+		{input: "Epley", wantErr: true},
+		{input: "mayhew", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseFormula(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseFormula(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseFormula(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
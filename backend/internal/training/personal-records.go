@@ -0,0 +1,42 @@
+package training
+
+// The per-set record types; session volume is summed across a workout so it's worked out by the caller.
+type RecordType string
+
+const (
+	RecordMaxWeight       RecordType = "max_weight"
+	RecordEstimated1RM    RecordType = "estimated_1rm"
+	RecordMaxRepsAtWeight RecordType = "max_reps_at_weight"
+	RecordSetVolume       RecordType = "set_volume"
+	RecordSessionVolume   RecordType = "session_volume"
+)
+
+// Estimates from sets above this many reps are too loose to count as a 1RM record
+const MaxRepsForEstimated1RMRecord = 10
+
+// What a single set could claim. Value is kg for weight/1RM/volume records and reps for RecordMaxRepsAtWeight,
+// whose records are kept per WeightKg.
+type RecordCandidate struct {
+	Type     RecordType
+	Value    float64
+	WeightKg float64
+}
+
+// Lists every per-set record a weighted set of weightKg x reps could set; it's a record if it beats the current value.
+func SetRecordCandidates(weightKg float64, reps int32) []RecordCandidate {
+	if weightKg <= 0 || reps <= 0 {
+		return nil
+	}
+
+	candidates := []RecordCandidate{
+		{Type: RecordMaxWeight, Value: weightKg},
+		{Type: RecordMaxRepsAtWeight, Value: float64(reps), WeightKg: weightKg},
+		{Type: RecordSetVolume, Value: weightKg * float64(reps)},
+	}
+	if reps <= MaxRepsForEstimated1RMRecord {
+		if oneRM, err := EstimateOneRepMax(DefaultFormula, weightKg, reps, nil); err == nil {
+			candidates = append(candidates, RecordCandidate{Type: RecordEstimated1RM, Value: oneRM})
+		}
+	}
+	return candidates
+}
@@ -0,0 +1,60 @@
+package training
+
+import "testing"
+
+func TestSetRecordCandidates(t *testing.T) {
+	tests := []struct {
+		name     string
+		weightKg float64
+		reps     int32
+		want     []RecordCandidate
+	}{
+		{
+			name:     "a single",
+			weightKg: 140, reps: 1,
+			want: []RecordCandidate{
+				{Type: RecordMaxWeight, Value: 140},
+				{Type: RecordMaxRepsAtWeight, Value: 1, WeightKg: 140},
+				{Type: RecordSetVolume, Value: 140},
+				{Type: RecordEstimated1RM, Value: 140},
+			},
+		},
+		{
+			name:     "an estimated 1RM right at the rep cap",
+			weightKg: 100, reps: 10,
+			want: []RecordCandidate{
+				{Type: RecordMaxWeight, Value: 100},
+				{Type: RecordMaxRepsAtWeight, Value: 10, WeightKg: 100},
+				{Type: RecordSetVolume, Value: 1000},
+				{Type: RecordEstimated1RM, Value: 100 * 36.0 / 27},
+			},
+		},
+		{
+			name:     "no estimated 1RM past the rep cap",
+			weightKg: 100, reps: 11,
+			want: []RecordCandidate{
+				{Type: RecordMaxWeight, Value: 100},
+				{Type: RecordMaxRepsAtWeight, Value: 11, WeightKg: 100},
+				{Type: RecordSetVolume, Value: 1100},
+			},
+		},
+		{
+			name:     "unloaded",
+			weightKg: 0, reps: 20,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := SetRecordCandidates(tt.weightKg, tt.reps)
+			if len(got) != len(tt.want) {
+				t.Fatalf("SetRecordCandidates() = %+v, want %+v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("SetRecordCandidates()[%d] = %+v, want %+v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
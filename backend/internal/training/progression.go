@@ -0,0 +1,275 @@
+package training
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+)
+
+// One working set from a past session; WeightKg is 0 for unloaded sets
+type SessionSet struct {
+	WeightKg float64
+	Reps     int32
+	RPE      *float64
+}
+
+type Session struct {
+	WorkoutID int32
+	Date      time.Time
+	Sets      []SessionSet
+}
+
+type ProgressionSettings struct {
+	MinReps           int32 // bottom of the rep range; also the target after a load increase or deload
+	MaxReps           int32 // top of the rep range; every set reaching it earns a load increase
+	LoadIncrementKg   float64
+	TargetRPE         float64 // rpe strategy only
+	DeloadAfterMisses int32   // 0 turns deloads off
+	DeloadPercent     float64
+}
+
+// Used for exercises the user hasn't configured; mirrors the exercise_progression_settings column defaults
+var DefaultProgressionSettings = ProgressionSettings{
+	MinReps:           8,
+	MaxReps:           12,
+	LoadIncrementKg:   2.5,
+	TargetRPE:         8,
+	DeloadAfterMisses: 3,
+	DeloadPercent:     10,
+}
+
+type Target struct {
+	Strategy    string
+	WeightKg    float64
+	Reps        int32
+	Sets        int
+	TargetRPE   *float64
+	Deload      bool
+	Explanation string
+}
+
+// Formats a kg weight in the caller's units for explanations ("102.5 kg", "225 lbs")
+type WeightFormatter func(kg float64) string
+
+// A way of picking the next session's target from past sessions. history is newest first and never empty.
+type ProgressionStrategy interface {
+	NextTarget(history []Session, settings ProgressionSettings, format WeightFormatter) Target
+	// Whether a session fell short of what this strategy would have asked for; enough misses in a row trigger a deload
+	MissedTarget(session Session, settings ProgressionSettings) bool
+}
+
+const (
+	StrategyDoubleProgression = "double_progression"
+	StrategyRPE               = "rpe"
+)
+
+const DefaultProgressionStrategy = StrategyDoubleProgression
+
+// New strategies only need adding here (and to progression_strategy_enum if they should be saveable per exercise)
+var progressionStrategies = map[string]ProgressionStrategy{
+	StrategyDoubleProgression: DoubleProgression{},
+	StrategyRPE:               RPEAutoregulation{},
+}
+
+func ParseProgressionStrategy(name string) (string, error) {
+	if name == "" {
+		return DefaultProgressionStrategy, nil
+	}
+	if _, ok := progressionStrategies[name]; !ok {
+		return "", fmt.Errorf("strategy must be one of 'double_progression', 'rpe'")
+	}
+	return name, nil
+}
+
+var ErrNoProgressionHistory = fmt.Errorf("no working sets logged for this exercise yet")
+
+// Picks the next target with the named strategy, unless the last DeloadAfterMisses sessions all missed, in which case it's a deload.
+func NextTarget(strategyName string, history []Session, settings ProgressionSettings, format WeightFormatter) (Target, error) {
+	strategy, ok := progressionStrategies[strategyName]
+	if !ok {
+		return Target{}, fmt.Errorf("unknown progression strategy %q", strategyName)
+	}
+	if len(history) == 0 {
+		return Target{}, ErrNoProgressionHistory
+	}
+
+	if settings.DeloadAfterMisses > 0 && consecutiveMisses(strategy, history, settings) >= int(settings.DeloadAfterMisses) {
+		return deloadTarget(strategyName, history[0], settings, format), nil
+	}
+
+	target := strategy.NextTarget(history, settings, format)
+	target.Strategy = strategyName
+	return target, nil
+}
+
+func consecutiveMisses(strategy ProgressionStrategy, history []Session, settings ProgressionSettings) int {
+	misses := 0
+	for _, session := range history {
+		if !strategy.MissedTarget(session, settings) {
+			break
+		}
+		misses++
+	}
+	return misses
+}
+
+func deloadTarget(strategyName string, last Session, settings ProgressionSettings, format WeightFormatter) Target {
+	topWeight, topSets := topWorkingSets(last)
+	weight := roundDownToIncrement(topWeight*(1-settings.DeloadPercent/100), settings.LoadIncrementKg)
+	return Target{
+		Strategy: strategyName,
+		WeightKg: weight,
+		Reps:     settings.MinReps,
+		Sets:     len(topSets),
+		Deload:   true,
+		Explanation: fmt.Sprintf("You've missed your target %d sessions in a row, so this is a deload: %g%% lighter than last session's %s, at %s for %d reps.",
+			settings.DeloadAfterMisses, settings.DeloadPercent, format(topWeight), format(weight), settings.MinReps),
+	}
+}
+
+// Work up to the top of the rep range at a fixed load, then add load and start again from the bottom of the range.
+type DoubleProgression struct{}
+
+func (DoubleProgression) MissedTarget(session Session, settings ProgressionSettings) bool {
+	_, topSets := topWorkingSets(session)
+	for _, set := range topSets {
+		if set.Reps < settings.MinReps {
+			return true
+		}
+	}
+	return false
+}
+
+func (DoubleProgression) NextTarget(history []Session, settings ProgressionSettings, format WeightFormatter) Target {
+	topWeight, topSets := topWorkingSets(history[0])
+	lowestReps := topSets[0].Reps
+	for _, set := range topSets {
+		lowestReps = min(lowestReps, set.Reps)
+	}
+
+	if topWeight == 0 {
+		return Target{
+			Reps:        lowestReps + 1,
+			Sets:        len(topSets),
+			Explanation: fmt.Sprintf("No load was logged last session, so progress by adding a rep: %d reps per set.", lowestReps+1),
+		}
+	}
+
+	if lowestReps >= settings.MaxReps {
+		weight := topWeight + settings.LoadIncrementKg
+		return Target{
+			WeightKg: weight,
+			Reps:     settings.MinReps,
+			Sets:     len(topSets),
+			Explanation: fmt.Sprintf("All %d sets at %s reached the top of your %d-%d rep range last session (%s reps), so the load goes up to %s and reps start back at %d.",
+				len(topSets), format(topWeight), settings.MinReps, settings.MaxReps, repsList(topSets), format(weight), settings.MinReps),
+		}
+	}
+
+	reps := min(max(lowestReps+1, settings.MinReps), settings.MaxReps)
+	return Target{
+		WeightKg: topWeight,
+		Reps:     reps,
+		Sets:     len(topSets),
+		Explanation: fmt.Sprintf("Last session's sets at %s got %s reps. Stay at %s and aim for %d reps per set; the load goes up once every set reaches %d.",
+			format(topWeight), repsList(topSets), format(topWeight), reps, settings.MaxReps),
+	}
+}
+
+// Works out an e1RM from the last top set's weight, reps & RPE, then picks the load that should land on the target RPE.
+// Falls back to double progression when the last session has no usable RPE.
+type RPEAutoregulation struct{}
+
+func (RPEAutoregulation) MissedTarget(session Session, settings ProgressionSettings) bool {
+	top, ok := topSetWithRPE(session)
+	if !ok {
+		return DoubleProgression{}.MissedTarget(session, settings)
+	}
+	// a full RPE point over target means the load was too heavy for the day
+	return top.Reps < settings.MinReps || *top.RPE >= settings.TargetRPE+1
+}
+
+func (RPEAutoregulation) NextTarget(history []Session, settings ProgressionSettings, format WeightFormatter) Target {
+	top, ok := topSetWithRPE(history[0])
+	if !ok || top.WeightKg == 0 {
+		return rpeFallback(history, settings, format, "No RPE was logged for last session's top set")
+	}
+
+	oneRM, err := EstimateOneRepMax(FormulaRPE, top.WeightKg, top.Reps, top.RPE)
+	if err != nil {
+		return rpeFallback(history, settings, format, fmt.Sprintf("Last session's top set is off the RPE chart (%s)", err.Error()))
+	}
+
+	reps := min(max(top.Reps, settings.MinReps), settings.MaxReps, rtsMaxReps)
+	percentage, err := rtsPercentage(reps, settings.TargetRPE)
+	if err != nil {
+		return rpeFallback(history, settings, format, fmt.Sprintf("Your target RPE is off the RPE chart (%s)", err.Error()))
+	}
+	weight := roundDownToIncrement(oneRM*percentage/100, settings.LoadIncrementKg)
+
+	_, topSets := topWorkingSets(history[0])
+	targetRPE := settings.TargetRPE
+	return Target{
+		WeightKg:  weight,
+		Reps:      reps,
+		Sets:      len(topSets),
+		TargetRPE: &targetRPE,
+		Explanation: fmt.Sprintf("Last session's top set was %s x %d @ RPE %g, an estimated 1RM of %s. %d reps @ RPE %g is about %g%% of that, which rounds down to %s.",
+			format(top.WeightKg), top.Reps, *top.RPE, format(oneRM), reps, settings.TargetRPE, percentage, format(weight)),
+	}
+}
+
+func rpeFallback(history []Session, settings ProgressionSettings, format WeightFormatter, reason string) Target {
+	target := DoubleProgression{}.NextTarget(history, settings, format)
+	target.Explanation = reason + ", so this falls back to double progression. " + target.Explanation
+	return target
+}
+
+// The heaviest load in a session & the sets done at it (every set for unloaded work)
+func topWorkingSets(session Session) (float64, []SessionSet) {
+	topWeight := 0.0
+	for _, set := range session.Sets {
+		topWeight = max(topWeight, set.WeightKg)
+	}
+
+	var topSets []SessionSet
+	for _, set := range session.Sets {
+		if set.WeightKg == topWeight {
+			topSets = append(topSets, set)
+		}
+	}
+	return topWeight, topSets
+}
+
+// Heaviest set with an RPE logged, most reps breaking ties
+func topSetWithRPE(session Session) (SessionSet, bool) {
+	var top SessionSet
+	found := false
+	for _, set := range session.Sets {
+		if set.RPE == nil {
+			continue
+		}
+		if !found || set.WeightKg > top.WeightKg || (set.WeightKg == top.WeightKg && set.Reps > top.Reps) {
+			top = set
+			found = true
+		}
+	}
+	return top, found
+}
+
+func roundDownToIncrement(weightKg, incrementKg float64) float64 {
+	if incrementKg <= 0 {
+		return weightKg
+	}
+	// the small epsilon stops float error from knocking exact multiples down a whole increment
+	return math.Floor(weightKg/incrementKg+1e-9) * incrementKg
+}
+
+func repsList(sets []SessionSet) string {
+	reps := make([]string, len(sets))
+	for i, set := range sets {
+		reps[i] = fmt.Sprint(set.Reps)
+	}
+	return strings.Join(reps, "/")
+}
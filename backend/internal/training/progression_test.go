@@ -0,0 +1,209 @@
+package training
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNextTarget(t *testing.T) {
+	format := func(kg float64) string { return fmt.Sprintf("%g kg", kg) }
+	rpe := func(value float64) *float64 { return &value }
+	session := func(sets ...SessionSet) Session {
+		return Session{Date: time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC), Sets: sets}
+	}
+	missed := session(SessionSet{WeightKg: 100, Reps: 6}, SessionSet{WeightKg: 100, Reps: 6})
+
+	noDeloads := DefaultProgressionSettings
+	noDeloads.DeloadAfterMisses = 0
+
+	tests := []struct {
+		name        string
+		strategy    string
+		history     []Session
+		settings    ProgressionSettings
+		wantWeight  float64
+		wantReps    int32
+		wantSets    int
+		wantDeload  bool
+		wantRPE     bool
+		explanation string // prefix
+	}{
+		{
+			name:       "double progression adds a rep",
+			strategy:   StrategyDoubleProgression,
+			history:    []Session{session(SessionSet{WeightKg: 100, Reps: 10}, SessionSet{WeightKg: 100, Reps: 9}, SessionSet{WeightKg: 100, Reps: 10})},
+			wantWeight: 100,
+			wantReps:   10,
+			wantSets:   3,
+		},
+		{
+			name:       "double progression adds load at the top of the range",
+			strategy:   StrategyDoubleProgression,
+			history:    []Session{session(SessionSet{WeightKg: 100, Reps: 12}, SessionSet{WeightKg: 100, Reps: 13})},
+			wantWeight: 102.5,
+			wantReps:   8,
+			wantSets:   2,
+		},
+		{
+			name:       "double progression ignores lighter back-off sets",
+			strategy:   StrategyDoubleProgression,
+			history:    []Session{session(SessionSet{WeightKg: 100, Reps: 12}, SessionSet{WeightKg: 100, Reps: 12}, SessionSet{WeightKg: 80, Reps: 15})},
+			wantWeight: 102.5,
+			wantReps:   8,
+			wantSets:   2,
+		},
+		{
+			name:       "double progression lifts reps below the range to the bottom of it",
+			strategy:   StrategyDoubleProgression,
+			history:    []Session{session(SessionSet{WeightKg: 100, Reps: 6}, SessionSet{WeightKg: 100, Reps: 7})},
+			wantWeight: 100,
+			wantReps:   8,
+			wantSets:   2,
+		},
+		{
+			name:       "unloaded sets only add reps",
+			strategy:   StrategyDoubleProgression,
+			history:    []Session{session(SessionSet{Reps: 15}, SessionSet{Reps: 14})},
+			wantWeight: 0,
+			wantReps:   15,
+			wantSets:   2,
+		},
+		{
+			name:       "deload after enough misses in a row",
+			strategy:   StrategyDoubleProgression,
+			history:    []Session{missed, missed, missed},
+			wantWeight: 90,
+			wantReps:   8,
+			wantSets:   2,
+			wantDeload: true,
+		},
+		{
+			name:       "no deload when an older session hit",
+			strategy:   StrategyDoubleProgression,
+			history:    []Session{missed, missed, session(SessionSet{WeightKg: 100, Reps: 8}), missed},
+			wantWeight: 100,
+			wantReps:   8,
+			wantSets:   2,
+		},
+		{
+			name:       "no deload when deloads are off",
+			strategy:   StrategyDoubleProgression,
+			history:    []Session{missed, missed, missed},
+			settings:   noDeloads,
+			wantWeight: 100,
+			wantReps:   8,
+			wantSets:   2,
+		},
+		{
+			name:       "rpe holds the load that landed on target",
+			strategy:   StrategyRPE,
+			history:    []Session{session(SessionSet{WeightKg: 100, Reps: 8, RPE: rpe(8)}, SessionSet{WeightKg: 100, Reps: 8})},
+			wantWeight: 100,
+			wantReps:   8,
+			wantSets:   2,
+			wantRPE:    true,
+		},
+		{
+			name:       "rpe lightens a set that was harder than target",
+			strategy:   StrategyRPE,
+			history:    []Session{session(SessionSet{WeightKg: 100, Reps: 8, RPE: rpe(9)})},
+			wantWeight: 95,
+			wantReps:   8,
+			wantSets:   1,
+			wantRPE:    true,
+		},
+		{
+			name:        "rpe falls back without an rpe",
+			strategy:    StrategyRPE,
+			history:     []Session{session(SessionSet{WeightKg: 100, Reps: 12}, SessionSet{WeightKg: 100, Reps: 12})},
+			wantWeight:  102.5,
+			wantReps:    8,
+			wantSets:    2,
+			explanation: "No RPE was logged",
+		},
+		{
+			name:        "rpe falls back off the chart",
+			strategy:    StrategyRPE,
+			history:     []Session{session(SessionSet{WeightKg: 60, Reps: 15, RPE: rpe(8)})},
+			wantWeight:  62.5,
+			wantReps:    8,
+			wantSets:    1,
+			explanation: "Last session's top set is off the RPE chart",
+		},
+		{
+			name:     "rpe counts a set a full point over target as a miss",
+			strategy: StrategyRPE,
+			history: []Session{
+				session(SessionSet{WeightKg: 100, Reps: 8, RPE: rpe(9)}),
+				session(SessionSet{WeightKg: 100, Reps: 8, RPE: rpe(9.5)}),
+				session(SessionSet{WeightKg: 100, Reps: 8, RPE: rpe(9)}),
+			},
+			wantWeight: 90,
+			wantReps:   8,
+			wantSets:   1,
+			wantDeload: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			settings := tt.settings
+			if settings == (ProgressionSettings{}) {
+				settings = DefaultProgressionSettings
+			}
+
+			got, err := NextTarget(tt.strategy, tt.history, settings, format)
+			if err != nil {
+				t.Fatalf("NextTarget() error = %v", err)
+			}
+			if got.Strategy != tt.strategy {
+				t.Errorf("Strategy = %q, want %q", got.Strategy, tt.strategy)
+			}
+			if got.WeightKg != tt.wantWeight || got.Reps != tt.wantReps || got.Sets != tt.wantSets {
+				t.Errorf("NextTarget() = %g kg x %d x %d sets, want %g kg x %d x %d sets",
+					got.WeightKg, got.Reps, got.Sets, tt.wantWeight, tt.wantReps, tt.wantSets)
+			}
+			if got.Deload != tt.wantDeload {
+				t.Errorf("Deload = %v, want %v", got.Deload, tt.wantDeload)
+			}
+			if (got.TargetRPE != nil) != tt.wantRPE {
+				t.Errorf("TargetRPE = %v, want set %v", got.TargetRPE, tt.wantRPE)
+			}
+			if !strings.HasPrefix(got.Explanation, tt.explanation) {
+				t.Errorf("Explanation = %q, want it to start with %q", got.Explanation, tt.explanation)
+			}
+		})
+	}
+}
+
+func TestNextTargetErrors(t *testing.T) {
+	format := func(kg float64) string { return fmt.Sprint(kg) }
+	history := []Session{{Sets: []SessionSet{{WeightKg: 100, Reps: 8}}}}
+
+	if _, err := NextTarget("linear", history, DefaultProgressionSettings, format); err == nil {
+		t.Error("NextTarget() with an unknown strategy: want an error")
+	}
+	if _, err := NextTarget(StrategyDoubleProgression, nil, DefaultProgressionSettings, format); !errors.Is(err, ErrNoProgressionHistory) {
+		t.Errorf("NextTarget() with no history error = %v, want %v", err, ErrNoProgressionHistory)
+	}
+}
+
+func TestRoundDownToIncrement(t *testing.T) {
+	tests := []struct {
+		weight, increment, want float64
+	}{
+		{weight: 91.12, increment: 2.5, want: 90},
+		{weight: 100, increment: 2.5, want: 100},
+		{weight: 99.9999999999, increment: 2.5, want: 100},
+		{weight: 7.3, increment: 0, want: 7.3},
+	}
+
+	for _, tt := range tests {
+		if got := roundDownToIncrement(tt.weight, tt.increment); got != tt.want {
+			t.Errorf("roundDownToIncrement(%v, %v) = %v, want %v", tt.weight, tt.increment, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package training
+
+import (
+	"math"
+	"time"
+)
+
+// One set's work on one muscle
+type MuscleWork struct {
+	Primary     bool
+	PerformedAt time.Time
+	Reps        int32    // 0 if not logged (e.g. timed sets)
+	RPE         *float64 // nil if not logged
+}
+
+type RecoverySettings struct {
+	HalfLife        time.Duration // time for a set's fatigue to halve
+	SecondaryWeight float64       // share of a set's fatigue that lands on secondary muscles
+}
+
+const (
+	DefaultRecoveryHalfLife       = 24 * time.Hour
+	DefaultSecondaryRecoveryShare = 0.5
+	// Fatigue at which a muscle reads 0% recovered; roughly 10 hard, 10-rep sets at RPE 10 right now
+	FatigueCapacity = 10.0
+	// Assumed for sets without an RPE; most working sets are taken somewhere near 8
+	assumedRPE = 8.0
+)
+
+// Bigger muscle groups take longer to come back; groups not listed use DefaultRecoveryHalfLife
+var DefaultRecoveryHalfLives = map[string]time.Duration{
+	"Legs":       36 * time.Hour,
+	"Lower Back": 36 * time.Hour,
+	"Back":       30 * time.Hour,
+	"Glutes":     30 * time.Hour,
+	"Chest":      28 * time.Hour,
+	"Shoulders":  24 * time.Hour,
+	"Arms":       20 * time.Hour,
+	"Core":       16 * time.Hour,
+}
+
+// Fatigue a set puts on a muscle when it's done: 1 for a primary 10-rep set at RPE 10, scaled by involvement, RPE & reps
+func FatigueDose(work MuscleWork, secondaryWeight float64) float64 {
+	dose := 1.0
+	if !work.Primary {
+		dose *= secondaryWeight
+	}
+
+	rpe := assumedRPE
+	if work.RPE != nil {
+		rpe = *work.RPE
+	}
+	dose *= rpe / 10
+
+	// more reps means more work, within reason - a 30-rep set isn't three times a 10-rep one
+	if work.Reps > 0 {
+		dose *= min(max(float64(work.Reps)/10, 0.5), 1.5)
+	}
+	return dose
+}
+
+// How recovered (0-100%) a muscle is at "now" given its recent work; each set's fatigue decays exponentially.
+func RecoveryPercent(work []MuscleWork, now time.Time, settings RecoverySettings) float64 {
+	fatigue := 0.0
+	for _, w := range work {
+		// planned sets that haven't been done yet put no fatigue on anything
+		if w.PerformedAt.After(now) {
+			continue
+		}
+		elapsed := now.Sub(w.PerformedAt)
+		fatigue += FatigueDose(w, settings.SecondaryWeight) * math.Pow(0.5, elapsed.Hours()/settings.HalfLife.Hours())
+	}
+
+	recovery := 100 * (1 - min(fatigue/FatigueCapacity, 1))
+	return math.Round(recovery*10) / 10
+}
@@ -0,0 +1,88 @@
+package training
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestFatigueDose(t *testing.T) {
+	rpe := func(value float64) *float64 { return &value }
+
+	tests := []struct {
+		name            string
+		work            MuscleWork
+		secondaryWeight float64
+		want            float64
+	}{
+		{name: "primary 10 reps at rpe 10", work: MuscleWork{Primary: true, Reps: 10, RPE: rpe(10)}, secondaryWeight: 0.5, want: 1},
+		{name: "no rpe assumes 8", work: MuscleWork{Primary: true, Reps: 10}, secondaryWeight: 0.5, want: 0.8},
+		{name: "secondary takes its share", work: MuscleWork{Reps: 10, RPE: rpe(10)}, secondaryWeight: 0.5, want: 0.5},
+		{name: "secondary share of nothing", work: MuscleWork{Reps: 10, RPE: rpe(10)}, secondaryWeight: 0, want: 0},
+		{name: "primaries ignore the secondary share", work: MuscleWork{Primary: true, Reps: 10, RPE: rpe(10)}, secondaryWeight: 0, want: 1},
+		{name: "fewer reps", work: MuscleWork{Primary: true, Reps: 6, RPE: rpe(10)}, secondaryWeight: 0.5, want: 0.6},
+		{name: "low reps bottom out at half", work: MuscleWork{Primary: true, Reps: 3, RPE: rpe(10)}, secondaryWeight: 0.5, want: 0.5},
+		{name: "high reps top out at one and a half", work: MuscleWork{Primary: true, Reps: 30, RPE: rpe(10)}, secondaryWeight: 0.5, want: 1.5},
+		{name: "timed sets have no reps to scale by", work: MuscleWork{Primary: true, RPE: rpe(10)}, secondaryWeight: 0.5, want: 1},
+		{name: "everything at once", work: MuscleWork{Reps: 15, RPE: rpe(7)}, secondaryWeight: 0.5, want: 0.525},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FatigueDose(tt.work, tt.secondaryWeight); math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("FatigueDose() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRecoveryPercent(t *testing.T) {
+	now := time.Date(2026, 3, 10, 18, 0, 0, 0, time.UTC)
+	rpe10 := 10.0
+	// n sets that each put exactly 1 (primary) or the secondary weight on the muscle
+	sets := func(n int, primary bool, ago time.Duration) []MuscleWork {
+		work := make([]MuscleWork, n)
+		for i := range work {
+			work[i] = MuscleWork{Primary: primary, PerformedAt: now.Add(-ago), Reps: 10, RPE: &rpe10}
+		}
+		return work
+	}
+	halfLife := func(group string) RecoverySettings {
+		return RecoverySettings{HalfLife: DefaultRecoveryHalfLives[group], SecondaryWeight: DefaultSecondaryRecoveryShare}
+	}
+	defaults := RecoverySettings{HalfLife: DefaultRecoveryHalfLife, SecondaryWeight: DefaultSecondaryRecoveryShare}
+
+	tests := []struct {
+		name     string
+		work     []MuscleWork
+		settings RecoverySettings
+		want     float64
+	}{
+		{name: "no work", settings: defaults, want: 100},
+		{name: "just trained", work: sets(5, true, 0), settings: defaults, want: 50},
+		{name: "one half life later", work: sets(5, true, 24*time.Hour), settings: defaults, want: 75},
+		{name: "two half lives later", work: sets(5, true, 48*time.Hour), settings: defaults, want: 87.5},
+		{name: "legs decay slower", work: sets(5, true, 24*time.Hour), settings: halfLife("Legs"), want: 68.5},
+		{name: "legs after their half life", work: sets(5, true, 36*time.Hour), settings: halfLife("Legs"), want: 75},
+		{name: "arms decay faster", work: sets(5, true, 24*time.Hour), settings: halfLife("Arms"), want: 78.2},
+		{name: "core after three half lives", work: sets(5, true, 48*time.Hour), settings: halfLife("Core"), want: 93.8},
+		{name: "more fatigue than capacity", work: sets(12, true, 0), settings: defaults, want: 0},
+		{name: "secondary sets count at the default share", work: sets(4, false, 0), settings: defaults, want: 80},
+		{
+			name:     "secondary sets count at a custom share",
+			work:     sets(4, false, 0),
+			settings: RecoverySettings{HalfLife: DefaultRecoveryHalfLife, SecondaryWeight: 0.25},
+			want:     90,
+		},
+		{name: "primary & secondary add up", work: append(sets(2, true, 0), sets(2, false, 0)...), settings: defaults, want: 70},
+		{name: "sets that haven't happened yet", work: sets(5, true, -time.Hour), settings: defaults, want: 100},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := RecoveryPercent(tt.work, now, tt.settings); got != tt.want {
+				t.Errorf("RecoveryPercent() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,66 @@
+package training
+
+import "time"
+
+// The sessions logged on one calendar day
+type TrainingDay struct {
+	Date     time.Time
+	Sessions int
+}
+
+// Consecutive calendar days with at least one session. The current streak is still alive if the last training day was
+// today or yesterday, so a rest day so far today doesn't reset it. days must be oldest first with one entry per date.
+func DayStreaks(days []TrainingDay, today time.Time) (current, longest int) {
+	run := 0
+	for i, day := range days {
+		if i > 0 && day.Date.Equal(days[i-1].Date.AddDate(0, 0, 1)) {
+			run++
+		} else {
+			run = 1
+		}
+		longest = max(longest, run)
+	}
+
+	if len(days) > 0 {
+		last := days[len(days)-1].Date
+		if last.Equal(today) || last.Equal(today.AddDate(0, 0, -1)) {
+			current = run
+		}
+	}
+	return current, longest
+}
+
+// Monday of the week the date falls in
+func WeekStart(date time.Time) time.Time {
+	offset := (int(date.Weekday()) + 6) % 7
+	return date.AddDate(0, 0, -offset)
+}
+
+// Sessions per week (keyed by WeekStart) across all the given days
+func WeeklySessions(days []TrainingDay) map[time.Time]int {
+	weeks := make(map[time.Time]int)
+	for _, day := range days {
+		weeks[WeekStart(day.Date)] += day.Sessions
+	}
+	return weeks
+}
+
+// Consecutive weeks, counting back from the current one, that reached the target. The current week only counts once
+// it has reached the target; until then the streak runs through last week.
+func TargetWeekStreak(weekly map[time.Time]int, target int, today time.Time) int {
+	if target <= 0 {
+		return 0
+	}
+
+	week := WeekStart(today)
+	if weekly[week] < target {
+		week = week.AddDate(0, 0, -7)
+	}
+
+	streak := 0
+	for weekly[week] >= target {
+		streak++
+		week = week.AddDate(0, 0, -7)
+	}
+	return streak
+}
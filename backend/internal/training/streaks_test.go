@@ -0,0 +1,132 @@
+package training
+
+import (
+	"testing"
+	"time"
+)
+
+// One session on each of the given March 2026 days (which must be in order)
+func trainedOn(days ...int) []TrainingDay {
+	trained := make([]TrainingDay, len(days))
+	for i, day := range days {
+		trained[i] = TrainingDay{Date: march(day), Sessions: 1}
+	}
+	return trained
+}
+
+func TestDayStreaks(t *testing.T) {
+	tests := []struct {
+		name        string
+		days        []TrainingDay
+		today       time.Time
+		wantCurrent int
+		wantLongest int
+	}{
+		{name: "never trained", today: march(10)},
+		{name: "trained today", days: trainedOn(8, 9, 10), today: march(10), wantCurrent: 3, wantLongest: 3},
+		{name: "still alive via yesterday", days: trainedOn(8, 9, 10), today: march(11), wantCurrent: 3, wantLongest: 3},
+		{name: "broken by a missed day", days: trainedOn(8, 9, 10), today: march(12), wantCurrent: 0, wantLongest: 3},
+		{name: "longest was earlier", days: trainedOn(1, 2, 3, 4, 8, 9), today: march(9), wantCurrent: 2, wantLongest: 4},
+		{name: "a single day", days: trainedOn(10), today: march(10), wantCurrent: 1, wantLongest: 1},
+		{name: "across a month boundary", days: trainedOn(-1, 0, 1), today: march(1), wantCurrent: 3, wantLongest: 3},
+		{
+			name:        "several sessions in a day are still one day",
+			days:        []TrainingDay{{Date: march(9), Sessions: 2}, {Date: march(10), Sessions: 3}},
+			today:       march(10),
+			wantCurrent: 2,
+			wantLongest: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			current, longest := DayStreaks(tt.days, tt.today)
+			if current != tt.wantCurrent || longest != tt.wantLongest {
+				t.Errorf("DayStreaks() = %d, %d; want %d, %d", current, longest, tt.wantCurrent, tt.wantLongest)
+			}
+		})
+	}
+}
+
+func TestWeekStart(t *testing.T) {
+	tests := []struct {
+		name string
+		date time.Time
+		want time.Time
+	}{
+		{name: "monday is its own week start", date: march(2), want: march(2)},
+		{name: "wednesday", date: march(4), want: march(2)},
+		{name: "sunday ends the week", date: march(8), want: march(2)},
+		{name: "the next monday starts a new week", date: march(9), want: march(9)},
+		{name: "sunday back into the previous month", date: march(1), want: march(-5)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := WeekStart(tt.date); !got.Equal(tt.want) {
+				t.Errorf("WeekStart(%s) = %s, want %s", tt.date.Format("Mon 2006-01-02"), got.Format("Mon 2006-01-02"), tt.want.Format("Mon 2006-01-02"))
+			}
+		})
+	}
+}
+
+func TestTargetWeekStreak(t *testing.T) {
+	tests := []struct {
+		name   string
+		days   []TrainingDay
+		target int
+		today  time.Time
+		want   int
+	}{
+		{
+			name:   "current week already reached",
+			days:   trainedOn(2, 4, 6, 9, 11, 13, 16, 17, 18),
+			target: 3, today: march(18),
+			want: 3,
+		},
+		{
+			name:   "current week not reached yet runs through last week",
+			days:   trainedOn(2, 4, 6, 9, 11, 13, 16),
+			target: 3, today: march(18),
+			want: 2,
+		},
+		{
+			name:   "a short week ends the streak",
+			days:   trainedOn(2, 4, 6, 9, 16, 17, 18),
+			target: 3, today: march(18),
+			want: 1,
+		},
+		{
+			name:   "short last week & current week not reached yet",
+			days:   trainedOn(2, 4, 6, 9, 16),
+			target: 3, today: march(18),
+			want: 0,
+		},
+		{
+			name:   "sunday's session counts towards the week it ends",
+			days:   trainedOn(9, 11, 15),
+			target: 3, today: march(15),
+			want: 1,
+		},
+		{
+			name:   "monday's session counts towards the new week",
+			days:   trainedOn(9, 11, 16),
+			target: 3, today: march(16),
+			want: 0,
+		},
+		{
+			name:   "no target",
+			days:   trainedOn(2, 4, 6),
+			target: 0, today: march(8),
+			want: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := TargetWeekStreak(WeeklySessions(tt.days), tt.target, tt.today); got != tt.want {
+				t.Errorf("TargetWeekStreak() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,54 @@
+package training
+
+import (
+	"math"
+	"time"
+)
+
+// One dated reading of a body measurement (or any other metric that's tracked over time)
+type TrendPoint struct {
+	Date  time.Time
+	Value float64
+}
+
+// Trailing moving average: each point averaged with every reading from the windowDays days up to and including it.
+// Time-based rather than count-based so gaps in logging don't stretch the window. points must be oldest first.
+func MovingAverage(points []TrendPoint, windowDays int) []float64 {
+	averages := make([]float64, len(points))
+	start := 0
+	total := 0.0
+	for i, point := range points {
+		total += point.Value
+		for points[start].Date.Before(point.Date.AddDate(0, 0, -(windowDays - 1))) {
+			total -= points[start].Value
+			start++
+		}
+		averages[i] = round2(total / float64(i-start+1))
+	}
+	return averages
+}
+
+// Least-squares slope in units per week; false with fewer than two readings on different days
+func WeeklyTrend(points []TrendPoint) (float64, bool) {
+	if len(points) < 2 {
+		return 0, false
+	}
+
+	origin := points[0].Date
+	var sumX, sumY, sumXY, sumXX float64
+	for _, point := range points {
+		x := point.Date.Sub(origin).Hours() / 24
+		sumX += x
+		sumY += point.Value
+		sumXY += x * point.Value
+		sumXX += x * x
+	}
+
+	n := float64(len(points))
+	denominator := n*sumXX - sumX*sumX
+	if math.Abs(denominator) < 1e-9 {
+		return 0, false
+	}
+	perDay := (n*sumXY - sumX*sumY) / denominator
+	return round2(perDay * 7), true
+}
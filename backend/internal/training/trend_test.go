@@ -0,0 +1,94 @@
+package training
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestMovingAverage(t *testing.T) {
+	tests := []struct {
+		name       string
+		points     []TrendPoint
+		windowDays int
+		want       []float64
+	}{
+		{name: "no readings", windowDays: 7, want: []float64{}},
+		{name: "one reading", points: []TrendPoint{{march(1), 80}}, windowDays: 7, want: []float64{80}},
+		{
+			name:       "a reading 6 days back is in a 7 day window, 7 days back isn't",
+			points:     []TrendPoint{{march(1), 80}, {march(7), 82}, {march(8), 84}},
+			windowDays: 7,
+			want:       []float64{80, 81, 83},
+		},
+		{
+			name:       "a gap in logging empties the window",
+			points:     []TrendPoint{{march(1), 80}, {march(2), 82}, {march(20), 90}},
+			windowDays: 7,
+			want:       []float64{80, 81, 90},
+		},
+		{
+			name:       "readings on the same day all count",
+			points:     []TrendPoint{{march(5), 80}, {march(5), 81}, {march(5), 82}},
+			windowDays: 7,
+			want:       []float64{80, 80.5, 81},
+		},
+		{
+			name:       "a 1 day window is just that day",
+			points:     []TrendPoint{{march(1), 80}, {march(2), 82}, {march(2), 83}},
+			windowDays: 1,
+			want:       []float64{80, 82, 82.5},
+		},
+		{
+			name:       "rounded to 2 places",
+			points:     []TrendPoint{{march(1), 1}, {march(2), 1}, {march(3), 2}},
+			windowDays: 7,
+			want:       []float64{1, 1, 1.33},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := MovingAverage(tt.points, tt.windowDays)
+			if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Errorf("MovingAverage() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWeeklyTrend(t *testing.T) {
+	tests := []struct {
+		name   string
+		points []TrendPoint
+		want   float64
+		wantOK bool
+	}{
+		{name: "no readings"},
+		{name: "one reading", points: []TrendPoint{{march(1), 80}}},
+		{name: "every reading on the same day", points: []TrendPoint{{march(1), 80}, {march(1), 81}, {march(1), 79}}},
+		{name: "down a kilo in a week", points: []TrendPoint{{march(1), 80}, {march(8), 79}}, want: -1, wantOK: true},
+		{name: "up over a single day", points: []TrendPoint{{march(1), 80}, {march(2), 80.5}}, want: 3.5, wantOK: true},
+		{name: "flat", points: []TrendPoint{{march(1), 80}, {march(8), 80}}, want: 0, wantOK: true},
+		{
+			name:   "gaps in logging are spaced by date, not by reading",
+			points: []TrendPoint{{march(1), 80}, {march(3), 80}, {march(15), 78}},
+			want:   -1.06,
+			wantOK: true,
+		},
+		{
+			name:   "noisy readings",
+			points: []TrendPoint{{march(1), 80}, {march(2), 81}, {march(3), 79.5}, {march(4), 80.5}, {march(8), 80}},
+			want:   -0.26,
+			wantOK: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := WeeklyTrend(tt.points)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("WeeklyTrend() = %v, %v; want %v, %v", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}